@@ -27,10 +27,18 @@ func (h *Handler) CreateAmenity(c echo.Context) error {
 	}
 
 	amenity := model.Amenity{
-		Name: amenityCreate.Name,
+		Name:     amenityCreate.Name,
+		Category: amenityCreate.Category,
+		IconKey:  amenityCreate.IconKey,
+		Labels:   amenityCreate.Labels,
 	}
 
 	if err := h.service.CreateAmenity(c.Request().Context(), &amenity); err != nil {
+		if strings.Contains(err.Error(), "invalid amenity category") {
+			return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+				Error: err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, ErrorInternal{
 			Error: err.Error(),
 		})
@@ -43,6 +51,7 @@ func (h *Handler) CreateAmenity(c echo.Context) error {
 // @Tags amenities
 // @Produce json
 // @Param id path int true "Amenity ID"
+// @Param lang query string false "Язык подписи (ru, en)"
 // @Success 200 {object} model.Amenity
 // @Failure 400 {object} ErrorBadRequest
 // @Failure 404 {object} ErrorNotFound
@@ -57,7 +66,7 @@ func (h *Handler) GetAmenityByID(c echo.Context) error {
 		})
 	}
 
-	amenity, err := h.service.GetAmenityByID(c.Request().Context(), id)
+	amenity, err := h.service.GetAmenityByID(c.Request().Context(), id, parseLocale(c))
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return c.JSON(http.StatusNotFound, ErrorNotFound{
@@ -75,11 +84,18 @@ func (h *Handler) GetAmenityByID(c echo.Context) error {
 // @Summary Получить все удобства
 // @Tags amenities
 // @Produce json
+// @Param category query string false "Ключ категории"
+// @Param lang query string false "Язык подписи (ru, en)"
 // @Success 200 {array} model.Amenity
 // @Failure 500 {object} ErrorInternal
 // @Router /api/amenities [get]
 func (h *Handler) GetAllAmenities(c echo.Context) error {
-	amenities, err := h.service.GetAllAmenities(c.Request().Context())
+	var category *string
+	if categoryStr := c.QueryParam("category"); categoryStr != "" {
+		category = &categoryStr
+	}
+
+	amenities, err := h.service.GetAllAmenities(c.Request().Context(), category, parseLocale(c))
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, ErrorInternal{
 			Error: err.Error(),
@@ -89,6 +105,24 @@ func (h *Handler) GetAllAmenities(c echo.Context) error {
 	return c.JSON(http.StatusOK, amenities)
 }
 
+// @Summary Получить категории удобств
+// @Tags amenities
+// @Produce json
+// @Param lang query string false "Язык подписи (ru, en)"
+// @Success 200 {array} model.AmenityCategory
+// @Failure 500 {object} ErrorInternal
+// @Router /api/amenities/categories [get]
+func (h *Handler) GetAmenityCategories(c echo.Context) error {
+	categories, err := h.service.GetAmenityCategories(c.Request().Context(), parseLocale(c))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorInternal{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, categories)
+}
+
 // @Summary Обновить удобство
 // @Tags amenities
 // @Accept json
@@ -117,8 +151,11 @@ func (h *Handler) UpdateAmenity(c echo.Context) error {
 	}
 
 	amenity := model.Amenity{
-		ID:   id,
-		Name: amenityUpdate.Name,
+		ID:       id,
+		Name:     amenityUpdate.Name,
+		Category: amenityUpdate.Category,
+		IconKey:  amenityUpdate.IconKey,
+		Labels:   amenityUpdate.Labels,
 	}
 
 	if err := h.service.UpdateAmenity(c.Request().Context(), &amenity); err != nil {
@@ -127,6 +164,11 @@ func (h *Handler) UpdateAmenity(c echo.Context) error {
 				Error: err.Error(),
 			})
 		}
+		if strings.Contains(err.Error(), "invalid amenity category") {
+			return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+				Error: err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, ErrorInternal{
 			Error: err.Error(),
 		})
@@ -261,6 +303,7 @@ func (h *Handler) RemoveAmenityFromListing(c echo.Context) error {
 // @Tags amenities
 // @Produce json
 // @Param listing_id path int true "Listing ID"
+// @Param lang query string false "Язык подписи (ru, en)"
 // @Success 200 {array} model.Amenity
 // @Failure 400 {object} ErrorBadRequest
 // @Failure 500 {object} ErrorInternal
@@ -274,7 +317,7 @@ func (h *Handler) GetAmenitiesByListingID(c echo.Context) error {
 		})
 	}
 
-	amenities, err := h.service.GetAmenitiesByListingID(c.Request().Context(), listingID)
+	amenities, err := h.service.GetAmenitiesByListingID(c.Request().Context(), listingID, parseLocale(c))
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, ErrorInternal{
 			Error: err.Error(),
@@ -283,3 +326,52 @@ func (h *Handler) GetAmenitiesByListingID(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, amenities)
 }
+
+// @Summary Заменить набор удобств объявления
+// @Tags amenities
+// @Accept json
+// @Produce json
+// @Param listing_id path int true "Listing ID"
+// @Param amenities body ListingAmenitiesUpdate true "Полный список ID удобств"
+// @Param lang query string false "Язык подписи (ru, en)"
+// @Success 200 {array} model.Amenity
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/listings/{listing_id}/amenities [put]
+func (h *Handler) ReplaceListingAmenities(c echo.Context) error {
+	listingIDStr := c.Param("listing_id")
+	listingID, err := strconv.Atoi(listingIDStr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid listing id",
+		})
+	}
+
+	var amenitiesUpdate ListingAmenitiesUpdate
+	if err := c.Bind(&amenitiesUpdate); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid request body",
+		})
+	}
+
+	amenities, err := h.service.ReplaceListingAmenities(c.Request().Context(), listingID, amenitiesUpdate.AmenityIDs,
+		parseLocale(c))
+	if err != nil {
+		if strings.Contains(err.Error(), "listing not found") {
+			return c.JSON(http.StatusNotFound, ErrorNotFound{
+				Error: err.Error(),
+			})
+		}
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+				Error: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorInternal{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, amenities)
+}
@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Аналитика по объявлениям (представление listings_summary)
+// @Tags analytics
+// @Produce json
+// @Param host_id query int false "Host ID"
+// @Param available query bool false "Доступность объявления"
+// @Param sort query string false "Поле сортировки (listing_id, price_per_night, average_rating, reviews_count, bookings_count, total_revenue, avg_payment_amount, active_bookings_count, favorites_count)" default(total_revenue)
+// @Param order query string false "Направление сортировки (asc, desc)"
+// @Param limit query int false "Размер страницы (по умолчанию 20, максимум 100)"
+// @Param offset query int false "Смещение"
+// @Success 200 {object} model.ListingsAnalyticsPage
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 500 {object} ErrorInternal
+// @Router /api/analytics/listings [get]
+func (h *Handler) GetListingsAnalytics(c echo.Context) error {
+	filter, err := parseAnalyticsFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	page, err := h.service.GetListingsAnalytics(c.Request().Context(), filter)
+	if err != nil {
+		return analyticsError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+// @Summary Аналитика по хостам (представление hosts_analytics)
+// @Tags analytics
+// @Produce json
+// @Param host_id query int false "Host ID"
+// @Param sort query string false "Поле сортировки (host_id, total_listings, total_bookings, active_bookings, average_rating, total_reviews, total_revenue, avg_booking_revenue, failed_payments_count)" default(total_revenue)
+// @Param order query string false "Направление сортировки (asc, desc)"
+// @Param limit query int false "Размер страницы (по умолчанию 20, максимум 100)"
+// @Param offset query int false "Смещение"
+// @Success 200 {object} model.HostsAnalyticsPage
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 500 {object} ErrorInternal
+// @Router /api/analytics/hosts [get]
+func (h *Handler) GetHostsAnalytics(c echo.Context) error {
+	filter, err := parseAnalyticsFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	page, err := h.service.GetHostsAnalytics(c.Request().Context(), filter)
+	if err != nil {
+		return analyticsError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+// @Summary Аналитика по бронированиям и платежам (представление bookings_payments_analytics)
+// @Description Бронирование с несколькими платежами дает несколько строк
+// @Tags analytics
+// @Produce json
+// @Param host_id query int false "Host ID"
+// @Param guest_id query int false "Guest ID"
+// @Param listing_id query int false "Listing ID"
+// @Param status query string false "Статус бронирования (completed, active, upcoming)"
+// @Param payment_status query string false "Статус платежа (pending, completed, failed, refunded)"
+// @Param start_date query string false "Заезд не раньше" format(date-time) example(2025-01-01T00:00:00Z)
+// @Param end_date query string false "Выезд не позже" format(date-time) example(2025-12-31T23:59:59Z)
+// @Param sort query string false "Поле сортировки (booking_id, in_date, out_date, duration_days, total_price, payment_amount, paid_at, review_score)" default(in_date)
+// @Param order query string false "Направление сортировки (asc, desc)"
+// @Param limit query int false "Размер страницы (по умолчанию 20, максимум 100)"
+// @Param offset query int false "Смещение"
+// @Success 200 {object} model.BookingsAnalyticsPage
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 500 {object} ErrorInternal
+// @Router /api/analytics/bookings [get]
+func (h *Handler) GetBookingsAnalytics(c echo.Context) error {
+	filter, err := parseAnalyticsFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	page, err := h.service.GetBookingsAnalytics(c.Request().Context(), filter)
+	if err != nil {
+		return analyticsError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+func parseAnalyticsFilter(c echo.Context) (model.AnalyticsFilter, error) {
+	var filter model.AnalyticsFilter
+	var err error
+
+	filter.Limit, filter.Offset, err = parsePagination(c)
+	if err != nil {
+		return filter, err
+	}
+
+	filter.Sort = c.QueryParam("sort")
+	filter.Order = strings.ToLower(c.QueryParam("order"))
+
+	if filter.HostID, err = parseIntParam(c, "host_id"); err != nil {
+		return filter, err
+	}
+	if filter.GuestID, err = parseIntParam(c, "guest_id"); err != nil {
+		return filter, err
+	}
+	if filter.ListingID, err = parseIntParam(c, "listing_id"); err != nil {
+		return filter, err
+	}
+
+	if availableStr := c.QueryParam("available"); availableStr != "" {
+		available, err := strconv.ParseBool(availableStr)
+		if err != nil {
+			return filter, errors.New("invalid available")
+		}
+		filter.IsAvailable = &available
+	}
+
+	filter.BookingStatus = parseStringParam(c, "status")
+	filter.PaymentStatus = parseStringParam(c, "payment_status")
+
+	if filter.StartDate, err = parseTimeParam(c, "start_date"); err != nil {
+		return filter, err
+	}
+	if filter.EndDate, err = parseTimeParam(c, "end_date"); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+func analyticsError(c echo.Context, err error) error {
+	if strings.Contains(err.Error(), "invalid analytics filter") {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, ErrorInternal{
+		Error: err.Error(),
+	})
+}
@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Сравнить объявление с похожими
+// @Description Похожие - с тем же числом комнат и кроватей, общими удобствами и в радиусе radius_km
+// @Description (или в том же городе, если нет координат). Если поблизости меньше 5 похожих, место не учитывается.
+// @Description Возвращает перцентили цены, рейтинга, числа бронирований и загрузки за 90 дней и рекомендуемый диапазон цены
+// @Tags listings
+// @Produce json
+// @Param id path int true "Listing ID"
+// @Param radius_km query number false "Радиус поиска похожих в км (по умолчанию 25, максимум 500)"
+// @Success 200 {object} model.ListingBenchmark
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/listings/{id}/benchmark [get]
+func (h *Handler) GetListingBenchmark(c echo.Context) error {
+	listingID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid listing id",
+		})
+	}
+
+	radiusKm, err := parseFloatParam(c, "radius_km")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	benchmark, err := h.service.GetListingBenchmark(c.Request().Context(), listingID, radiusKm)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, ErrorNotFound{
+				Error: err.Error(),
+			})
+		}
+		if strings.Contains(err.Error(), "invalid benchmark") {
+			return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+				Error: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorInternal{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, benchmark)
+}
@@ -0,0 +1,257 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Начать переписку гостя с хозяином
+// @Description Если переписка по объявлению, гостю и бронированию уже есть, возвращается она
+// @Tags conversations
+// @Accept json
+// @Produce json
+// @Param conversation body ConversationCreate true "Объявление, гость и необязательное бронирование"
+// @Success 201 {object} model.Conversation
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/conversations [post]
+func (h *Handler) StartConversation(c echo.Context) error {
+	var conversationCreate ConversationCreate
+	if err := c.Bind(&conversationCreate); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid request body",
+		})
+	}
+
+	conversation := model.Conversation{
+		ListingID: conversationCreate.ListingID,
+		GuestID:   conversationCreate.GuestID,
+		BookingID: conversationCreate.BookingID,
+	}
+
+	if err := h.service.StartConversation(c.Request().Context(), &conversation); err != nil {
+		return conversationError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, conversation)
+}
+
+// @Summary Получить переписки пользователя
+// @Tags conversations
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Param limit query int false "Размер страницы (по умолчанию 20, максимум 100)"
+// @Param offset query int false "Смещение"
+// @Success 200 {array} model.Conversation
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/users/{user_id}/conversations [get]
+func (h *Handler) GetUserConversations(c echo.Context) error {
+	userID, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid user id",
+		})
+	}
+
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	conversations, err := h.service.GetUserConversations(c.Request().Context(), userID, limit, offset)
+	if err != nil {
+		return conversationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, conversations)
+}
+
+// @Summary Получить переписку
+// @Description Временная заглушка до появления аутентификации: участник определяется по переданному user_id, которому сервер доверяет без проверки
+// @Tags conversations
+// @Produce json
+// @Param id path int true "Conversation ID"
+// @Param user_id query int true "ID участника переписки (до появления аутентификации не проверяется)"
+// @Success 200 {object} model.Conversation
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 403 {object} ErrorForbidden
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/conversations/{id} [get]
+func (h *Handler) GetConversation(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid conversation id",
+		})
+	}
+
+	userID, err := strconv.Atoi(c.QueryParam("user_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid user id",
+		})
+	}
+
+	conversation, err := h.service.GetConversation(c.Request().Context(), id, userID)
+	if err != nil {
+		return conversationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, conversation)
+}
+
+// @Summary Получить сообщения переписки
+// @Description Сообщения отдаются от новых к старым.
+// @Description Временная заглушка до появления аутентификации: участник определяется по переданному user_id, которому сервер доверяет без проверки
+// @Tags conversations
+// @Produce json
+// @Param id path int true "Conversation ID"
+// @Param user_id query int true "ID участника переписки (до появления аутентификации не проверяется)"
+// @Param limit query int false "Размер страницы (по умолчанию 20, максимум 100)"
+// @Param offset query int false "Смещение"
+// @Success 200 {array} model.Message
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 403 {object} ErrorForbidden
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/conversations/{id}/messages [get]
+func (h *Handler) GetConversationMessages(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid conversation id",
+		})
+	}
+
+	userID, err := strconv.Atoi(c.QueryParam("user_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid user id",
+		})
+	}
+
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	messages, err := h.service.GetConversationMessages(c.Request().Context(), id, userID, limit, offset)
+	if err != nil {
+		return conversationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, messages)
+}
+
+// @Summary Отправить сообщение
+// @Description Временная заглушка до появления аутентификации: участник определяется по переданному sender_id, которому сервер доверяет без проверки
+// @Tags conversations
+// @Accept json
+// @Produce json
+// @Param id path int true "Conversation ID"
+// @Param message body MessageCreate true "Отправитель и текст"
+// @Success 201 {object} model.Message
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 403 {object} ErrorForbidden
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/conversations/{id}/messages [post]
+func (h *Handler) SendMessage(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid conversation id",
+		})
+	}
+
+	var messageCreate MessageCreate
+	if err := c.Bind(&messageCreate); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid request body",
+		})
+	}
+
+	message := model.Message{
+		ConversationID: id,
+		SenderID:       messageCreate.SenderID,
+		Text:           messageCreate.Text,
+	}
+
+	if err := h.service.SendMessage(c.Request().Context(), &message); err != nil {
+		return conversationError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, message)
+}
+
+// @Summary Отметить переписку прочитанной
+// @Description Отмечает прочитанными все входящие сообщения пользователя в переписке.
+// @Description Временная заглушка до появления аутентификации: участник определяется по переданному user_id, которому сервер доверяет без проверки
+// @Tags conversations
+// @Accept json
+// @Produce json
+// @Param id path int true "Conversation ID"
+// @Param reader body ConversationReadUpdate true "ID участника переписки"
+// @Success 200 {object} ConversationReadResult
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 403 {object} ErrorForbidden
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/conversations/{id}/read [put]
+func (h *Handler) MarkConversationRead(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid conversation id",
+		})
+	}
+
+	var readUpdate ConversationReadUpdate
+	if err := c.Bind(&readUpdate); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid request body",
+		})
+	}
+
+	marked, err := h.service.MarkConversationRead(c.Request().Context(), id, readUpdate.UserID)
+	if err != nil {
+		return conversationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, ConversationReadResult{
+		MarkedCount: marked,
+	})
+}
+
+func conversationError(c echo.Context, err error) error {
+	if strings.Contains(err.Error(), "not found") {
+		return c.JSON(http.StatusNotFound, ErrorNotFound{
+			Error: err.Error(),
+		})
+	}
+	if strings.Contains(err.Error(), "not a participant") {
+		return c.JSON(http.StatusForbidden, ErrorForbidden{
+			Error: err.Error(),
+		})
+	}
+	if strings.Contains(err.Error(), "invalid conversation") || strings.Contains(err.Error(), "invalid message") {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, ErrorInternal{
+		Error: err.Error(),
+	})
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Получить кабинет хоста
+// @Description Выручка (всего и за 30 дней), средний рейтинг, ожидающие платежи, загрузка за 30 дней,
+// @Description заезды и выезды на ближайшие 7 дней и показатели по каждому объявлению
+// @Tags hosts
+// @Produce json
+// @Param id path int true "Host ID"
+// @Success 200 {object} model.HostDashboard
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/hosts/{id}/dashboard [get]
+func (h *Handler) GetHostDashboard(c echo.Context) error {
+	hostID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid host id",
+		})
+	}
+
+	dashboard, err := h.service.GetHostDashboard(c.Request().Context(), hostID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, ErrorNotFound{
+				Error: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorInternal{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, dashboard)
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Rissochek/db-cw/internal/export"
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// exportErrorTrailer передает причину обрыва выгрузки, начатой с кодом 200
+const exportErrorTrailer = "X-Export-Error"
+
+// parseExportFormat выбирает формат выгрузки по параметру format или заголовку Accept; пустая строка означает JSON.
+// Из Accept берется тип с наибольшим q, типы с q=0 не рассматриваются
+func parseExportFormat(c echo.Context) (string, error) {
+	if format := strings.ToLower(c.QueryParam("format")); format != "" {
+		if format == "json" {
+			return "", nil
+		}
+		if !export.IsSupported(format) {
+			return "", errors.New("invalid format, use json, csv or xlsx")
+		}
+		return format, nil
+	}
+
+	best, bestQuality := "", 0.0
+	for _, accepted := range strings.Split(c.Request().Header.Get(echo.HeaderAccept), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+
+		format, ok := acceptedExportFormat(mediaType)
+		if !ok {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if quality, err = strconv.ParseFloat(q, 64); err != nil {
+				continue
+			}
+		}
+		if quality > bestQuality {
+			best, bestQuality = format, quality
+		}
+	}
+
+	return best, nil
+}
+
+// acceptedExportFormat сопоставляет тип из Accept с форматом выгрузки; JSON соответствует пустая строка
+func acceptedExportFormat(mediaType string) (string, bool) {
+	if mediaType == echo.MIMEApplicationJSON {
+		return "", true
+	}
+	for _, format := range []string{export.FormatCSV, export.FormatXLSX} {
+		exportType, _, _ := mime.ParseMediaType(export.ContentType(format))
+		if mediaType == exportType {
+			return format, true
+		}
+	}
+	return "", false
+}
+
+// streamExport отдает отчет файлом по мере чтения строк. Пока ничего не отправлено,
+// ошибка возвращается обычным JSON-ответом; после начала выгрузки файл завершается строкой-маркером
+// ошибки, а причина дублируется в трейлере X-Export-Error.
+func streamExport[T any](c echo.Context, format, name string, stream func(fn func(T) error) error) error {
+	res := c.Response()
+	writer, err := export.NewWriter(format, res, reflect.TypeFor[T]())
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	res.Header().Set("Trailer", exportErrorTrailer)
+	res.Header().Set(echo.HeaderContentType, export.ContentType(format))
+	res.Header().Set(echo.HeaderContentDisposition,
+		fmt.Sprintf(`attachment; filename="%s-%s.%s"`, name, time.Now().Format("20060102"), format))
+
+	err = stream(func(row T) error {
+		return writer.WriteRow(row)
+	})
+	if err == nil {
+		err = writer.Close()
+	}
+	if err == nil {
+		return nil
+	}
+
+	if !res.Committed {
+		res.Header().Del("Trailer")
+		res.Header().Del(echo.HeaderContentType)
+		res.Header().Del(echo.HeaderContentDisposition)
+		return reportError(c, err)
+	}
+
+	zap.S().Errorf("failed to export %s report: %v", name, err)
+	res.Header().Set(exportErrorTrailer, err.Error())
+	if abortErr := writer.Abort(err.Error()); abortErr != nil {
+		zap.S().Errorf("failed to mark %s export as failed: %v", name, abortErr)
+	}
+	return nil
+}
+
+// exportRows выгружает уже загруженный небольшой отчет
+func exportRows[T any](c echo.Context, format, name string, rows []T) error {
+	return streamExport(c, format, name, func(fn func(T) error) error {
+		for _, row := range rows {
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -13,9 +13,10 @@ import (
 // @Tags favorites
 // @Accept json
 // @Produce json
-// @Param favorite body FavoriteCreate true "Данные избранного"
+// @Param favorite body FavoriteCreate true "Данные избранного; без wishlist_id объявление попадает в список по умолчанию"
 // @Success 201 {object} model.Favorite
 // @Failure 400 {object} ErrorBadRequest
+// @Failure 403 {object} ErrorBadRequest
 // @Failure 500 {object} ErrorInternal
 // @Router /api/favorites [post]
 func (h *Handler) CreateFavorite(c echo.Context) error {
@@ -27,12 +28,19 @@ func (h *Handler) CreateFavorite(c echo.Context) error {
 	}
 
 	favorite := model.Favorite{
-		UserID:    favoriteCreate.UserID,
-		ListingID: favoriteCreate.ListingID,
+		UserID:     favoriteCreate.UserID,
+		ListingID:  favoriteCreate.ListingID,
+		WishlistID: favoriteCreate.WishlistID,
+		Note:       favoriteCreate.Note,
 	}
 
 	if err := h.service.CreateFavorite(c.Request().Context(), &favorite); err != nil {
-		if strings.Contains(err.Error(), "already exists") {
+		if strings.Contains(err.Error(), "another user") {
+			return c.JSON(http.StatusForbidden, ErrorBadRequest{
+				Error: err.Error(),
+			})
+		}
+		if strings.Contains(err.Error(), "already exists") || strings.Contains(err.Error(), "not found") {
 			return c.JSON(http.StatusBadRequest, ErrorBadRequest{
 				Error: err.Error(),
 			})
@@ -82,6 +90,7 @@ func (h *Handler) GetFavoriteByID(c echo.Context) error {
 // @Tags favorites
 // @Produce json
 // @Param user_id path int true "User ID"
+// @Param cards query bool false "Добавить карточки объявлений (адрес, цена, рейтинг, фото)"
 // @Success 200 {array} model.Favorite
 // @Failure 400 {object} ErrorBadRequest
 // @Failure 500 {object} ErrorInternal
@@ -95,7 +104,17 @@ func (h *Handler) GetFavoritesByUserID(c echo.Context) error {
 		})
 	}
 
-	favorites, err := h.service.GetFavoritesByUserID(c.Request().Context(), userID)
+	var withCards bool
+	if cardsStr := c.QueryParam("cards"); cardsStr != "" {
+		withCards, err = strconv.ParseBool(cardsStr)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+				Error: "invalid cards",
+			})
+		}
+	}
+
+	favorites, err := h.service.GetFavoritesByUserID(c.Request().Context(), userID, withCards)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, ErrorInternal{
 			Error: err.Error(),
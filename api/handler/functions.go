@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"cmp"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/Rissochek/db-cw/internal/model"
 	"github.com/labstack/echo/v4"
 )
 
@@ -117,12 +120,34 @@ func (h *Handler) GetListingActiveBookingsCount(c echo.Context) error {
 }
 
 // @Summary Получить статистический отчет по объявлениям
+// @Description Данные берутся из материализованного представления и актуальны на момент из заголовка X-Stale-As-Of
 // @Tags functions
-// @Produce json
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Формат (json, csv, xlsx); также учитывается заголовок Accept"
 // @Success 200 {array} model.ListingStatisticsReport
+// @Header 200 {string} X-Stale-As-Of "Время последнего обновления представления (RFC3339)"
+// @Failure 400 {object} ErrorBadRequest
 // @Failure 500 {object} ErrorInternal
 // @Router /api/reports/listings-statistics [get]
 func (h *Handler) GetListingsStatisticsReport(c echo.Context) error {
+	format, err := parseExportFormat(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	// время обновления читается до данных, чтобы X-Stale-As-Of не оказался новее самих строк
+	if err := h.setStaleAsOfHeader(c, model.ReportViewListingsStatistics); err != nil {
+		return reportError(c, err)
+	}
+
+	if format != "" {
+		return streamExport(c, format, "listings-statistics", func(fn func(model.ListingStatisticsReport) error) error {
+			return h.service.StreamListingsStatisticsReport(c.Request().Context(), fn)
+		})
+	}
+
 	reports, err := h.service.GetListingsStatisticsReport(c.Request().Context())
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, ErrorInternal{
@@ -133,13 +158,73 @@ func (h *Handler) GetListingsStatisticsReport(c echo.Context) error {
 	return c.JSON(http.StatusOK, reports)
 }
 
+// @Summary Получить отчет по городам: бронирования, выручка и средняя оценка
+// @Tags functions
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param country query string false "Страна"
+// @Param format query string false "Формат (json, csv, xlsx); также учитывается заголовок Accept"
+// @Success 200 {array} model.CityStatisticsReport
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 500 {object} ErrorInternal
+// @Router /api/reports/cities-statistics [get]
+func (h *Handler) GetCitiesStatisticsReport(c echo.Context) error {
+	format, err := parseExportFormat(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	var country *string
+	if countryStr := c.QueryParam("country"); countryStr != "" {
+		country = &countryStr
+	}
+
+	if format != "" {
+		return streamExport(c, format, "cities-statistics", func(fn func(model.CityStatisticsReport) error) error {
+			return h.service.StreamCitiesStatisticsReport(c.Request().Context(), country, fn)
+		})
+	}
+
+	reports, err := h.service.GetCitiesStatisticsReport(c.Request().Context(), country)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorInternal{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, reports)
+}
+
 // @Summary Получить отчет о производительности хостов
+// @Description Данные берутся из материализованного представления и актуальны на момент из заголовка X-Stale-As-Of
 // @Tags functions
-// @Produce json
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param format query string false "Формат (json, csv, xlsx); также учитывается заголовок Accept"
 // @Success 200 {array} model.HostPerformanceReport
+// @Header 200 {string} X-Stale-As-Of "Время последнего обновления представления (RFC3339)"
+// @Failure 400 {object} ErrorBadRequest
 // @Failure 500 {object} ErrorInternal
 // @Router /api/reports/hosts-performance [get]
 func (h *Handler) GetHostsPerformanceReport(c echo.Context) error {
+	format, err := parseExportFormat(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	// время обновления читается до данных, чтобы X-Stale-As-Of не оказался новее самих строк
+	if err := h.setStaleAsOfHeader(c, model.ReportViewHostsPerformance); err != nil {
+		return reportError(c, err)
+	}
+
+	if format != "" {
+		return streamExport(c, format, "hosts-performance", func(fn func(model.HostPerformanceReport) error) error {
+			return h.service.StreamHostsPerformanceReport(c.Request().Context(), fn)
+		})
+	}
+
 	reports, err := h.service.GetHostsPerformanceReport(c.Request().Context())
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, ErrorInternal{
@@ -150,11 +235,37 @@ func (h *Handler) GetHostsPerformanceReport(c echo.Context) error {
 	return c.JSON(http.StatusOK, reports)
 }
 
-// @Summary Получить отчет по бронированиям
+// @Summary Обновить материализованные представления отчетов
+// @Description Повторное обновление раньше чем через минуту после предыдущего или во время идущего отклоняется
 // @Tags functions
 // @Produce json
+// @Success 200 {object} StatusOK
+// @Failure 429 {object} ErrorBadRequest
+// @Failure 500 {object} ErrorInternal
+// @Router /api/reports/refresh [post]
+func (h *Handler) RefreshReports(c echo.Context) error {
+	if err := h.service.RefreshReports(c.Request().Context()); err != nil {
+		if strings.Contains(err.Error(), "refreshed recently") || strings.Contains(err.Error(), "already in progress") {
+			return c.JSON(http.StatusTooManyRequests, ErrorBadRequest{
+				Error: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorInternal{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, StatusOK{
+		Message: "reports refreshed",
+	})
+}
+
+// @Summary Получить отчет по бронированиям
+// @Tags functions
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Param start_date query string false "Start Date" format(date-time) example(2025-01-01T00:00:00Z)
 // @Param end_date query string false "End Date" format(date-time) example(2025-12-31T23:59:59Z)
+// @Param format query string false "Формат (json, csv, xlsx); также учитывается заголовок Accept"
 // @Success 200 {array} model.BookingReport
 // @Failure 400 {object} ErrorBadRequest
 // @Failure 500 {object} ErrorInternal
@@ -182,6 +293,19 @@ func (h *Handler) GetBookingsReport(c echo.Context) error {
 		endDate = &parsed
 	}
 
+	format, err := parseExportFormat(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	if format != "" {
+		return streamExport(c, format, "bookings", func(fn func(model.BookingReport) error) error {
+			return h.service.StreamBookingsReport(c.Request().Context(), startDate, endDate, fn)
+		})
+	}
+
 	reports, err := h.service.GetBookingsReport(c.Request().Context(), startDate, endDate)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, ErrorInternal{
@@ -194,9 +318,10 @@ func (h *Handler) GetBookingsReport(c echo.Context) error {
 
 // @Summary Получить сводный отчет по платежам
 // @Tags functions
-// @Produce json
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
 // @Param start_date query string false "Start Date" format(date-time) example(2025-01-01T00:00:00Z)
 // @Param end_date query string false "End Date" format(date-time) example(2025-12-31T23:59:59Z)
+// @Param format query string false "Формат (json, csv, xlsx); также учитывается заголовок Accept"
 // @Success 200 {array} model.PaymentSummaryReport
 // @Failure 400 {object} ErrorBadRequest
 // @Failure 500 {object} ErrorInternal
@@ -224,6 +349,19 @@ func (h *Handler) GetPaymentsSummaryReport(c echo.Context) error {
 		endDate = &parsed
 	}
 
+	format, err := parseExportFormat(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	if format != "" {
+		return streamExport(c, format, "payments-summary", func(fn func(model.PaymentSummaryReport) error) error {
+			return h.service.StreamPaymentsSummaryReport(c.Request().Context(), startDate, endDate, fn)
+		})
+	}
+
 	reports, err := h.service.GetPaymentsSummaryReport(c.Request().Context(), startDate, endDate)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, ErrorInternal{
@@ -233,3 +371,369 @@ func (h *Handler) GetPaymentsSummaryReport(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, reports)
 }
+
+// @Summary Получить отчет по загрузке объявлений: доля занятых ночей, ADR и RevPAR
+// @Description Брони, пересекающие границы периода, учитываются ночами внутри периода. Без дат берутся последние 30 дней
+// @Tags functions
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param start_date query string false "Start Date" format(date-time) example(2025-01-01T00:00:00Z)
+// @Param end_date query string false "End Date" format(date-time) example(2025-12-31T23:59:59Z)
+// @Param format query string false "Формат (json, csv, xlsx); также учитывается заголовок Accept"
+// @Success 200 {array} model.ListingOccupancyReport
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 500 {object} ErrorInternal
+// @Router /api/reports/listings-occupancy [get]
+func (h *Handler) GetListingsOccupancyReport(c echo.Context) error {
+	startDate, err := parseTimeParam(c, "start_date")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	endDate, err := parseTimeParam(c, "end_date")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	format, err := parseExportFormat(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	if format != "" {
+		return streamExport(c, format, "listings-occupancy", func(fn func(model.ListingOccupancyReport) error) error {
+			return h.service.StreamListingsOccupancyReport(c.Request().Context(), startDate, endDate, fn)
+		})
+	}
+
+	reports, err := h.service.GetListingsOccupancyReport(c.Request().Context(), startDate, endDate)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, reports)
+}
+
+// @Summary Получить отчет по загрузке объявлений хостов: доля занятых ночей, ADR и RevPAR
+// @Description Без дат берутся последние 30 дней
+// @Tags functions
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param start_date query string false "Start Date" format(date-time) example(2025-01-01T00:00:00Z)
+// @Param end_date query string false "End Date" format(date-time) example(2025-12-31T23:59:59Z)
+// @Param format query string false "Формат (json, csv, xlsx); также учитывается заголовок Accept"
+// @Success 200 {array} model.HostOccupancyReport
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 500 {object} ErrorInternal
+// @Router /api/reports/hosts-occupancy [get]
+func (h *Handler) GetHostsOccupancyReport(c echo.Context) error {
+	startDate, err := parseTimeParam(c, "start_date")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	endDate, err := parseTimeParam(c, "end_date")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	format, err := parseExportFormat(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	if format != "" {
+		return streamExport(c, format, "hosts-occupancy", func(fn func(model.HostOccupancyReport) error) error {
+			return h.service.StreamHostsOccupancyReport(c.Request().Context(), startDate, endDate, fn)
+		})
+	}
+
+	reports, err := h.service.GetHostsOccupancyReport(c.Request().Context(), startDate, endDate)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, reports)
+}
+
+func reportError(c echo.Context, err error) error {
+	if strings.Contains(err.Error(), "invalid report period") || strings.Contains(err.Error(), "invalid timeseries report") {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, ErrorInternal{
+		Error: err.Error(),
+	})
+}
+
+// @Summary Получить временной ряд по выручке, возвратам или бронированиям
+// @Description Пустые интервалы заполняются нулями. revenue и refunds считаются по дате платежа, bookings - по дате заезда
+// @Tags functions
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param metric query string false "Метрика (revenue, refunds, bookings)" default(revenue)
+// @Param granularity query string false "Интервал (day, week, month)" default(day)
+// @Param from query string false "Начало периода" format(date-time) example(2025-01-01T00:00:00Z)
+// @Param to query string false "Конец периода" format(date-time) example(2025-12-31T23:59:59Z)
+// @Param host_id query int false "Host ID"
+// @Param listing_id query int false "Listing ID"
+// @Param format query string false "Формат (json, csv, xlsx); также учитывается заголовок Accept"
+// @Success 200 {object} model.TimeseriesReport
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 500 {object} ErrorInternal
+// @Router /api/reports/timeseries [get]
+func (h *Handler) GetTimeseriesReport(c echo.Context) error {
+	filter := model.TimeseriesFilter{
+		Metric:      strings.ToLower(c.QueryParam("metric")),
+		Granularity: strings.ToLower(c.QueryParam("granularity")),
+	}
+
+	var err error
+	if filter.From, err = parseTimeParam(c, "from"); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+	if filter.To, err = parseTimeParam(c, "to"); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+	if filter.HostID, err = parseIntParam(c, "host_id"); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+	if filter.ListingID, err = parseIntParam(c, "listing_id"); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	format, err := parseExportFormat(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	if format != "" {
+		name := "timeseries-" + cmp.Or(filter.Metric, model.TimeseriesMetricRevenue)
+		return streamExport(c, format, name, func(fn func(model.TimeseriesPoint) error) error {
+			return h.service.StreamTimeseriesReport(c.Request().Context(), filter, fn)
+		})
+	}
+
+	report, err := h.service.GetTimeseriesReport(c.Request().Context(), filter)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+func (h *Handler) setStaleAsOfHeader(c echo.Context, viewName string) error {
+	staleAsOf, err := h.service.GetReportStaleAsOf(c.Request().Context(), viewName)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("X-Stale-As-Of", staleAsOf.Format(time.RFC3339))
+	return nil
+}
+
+// @Summary Получить когортный отчет по возвращаемости гостей
+// @Description Гости группируются по месяцу первого бронирования. Для каждого следующего месяца -
+// @Description доля гостей когорты, забронировавших снова, и накопленные траты когорты.
+// @Description При выгрузке в файл возвращаются плоские ячейки матрицы
+// @Tags functions
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param start_date query string false "Первая когорта не раньше" format(date-time) example(2025-01-01T00:00:00Z)
+// @Param end_date query string false "Последняя когорта не позже" format(date-time) example(2025-12-31T23:59:59Z)
+// @Param format query string false "Формат (json, csv, xlsx); также учитывается заголовок Accept"
+// @Success 200 {object} model.GuestCohortReport
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 500 {object} ErrorInternal
+// @Router /api/reports/guest-cohorts [get]
+func (h *Handler) GetGuestCohortReport(c echo.Context) error {
+	startDate, err := parseTimeParam(c, "start_date")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	endDate, err := parseTimeParam(c, "end_date")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	format, err := parseExportFormat(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	if format != "" {
+		cells, err := h.service.GetGuestCohortCells(c.Request().Context(), startDate, endDate)
+		if err != nil {
+			return reportError(c, err)
+		}
+		return exportRows(c, format, "guest-cohorts", cells)
+	}
+
+	report, err := h.service.GetGuestCohortReport(c.Request().Context(), startDate, endDate)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// @Summary Получить отчет по отменам бронирований для объявлений
+// @Description Отмены восстанавливаются из удалений броней в audit_log. Доля отмен считается от всех броней с заездом в периоде
+// @Tags functions
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param start_date query string false "Заезд не раньше" format(date-time) example(2025-01-01T00:00:00Z)
+// @Param end_date query string false "Заезд не позже" format(date-time) example(2025-12-31T23:59:59Z)
+// @Param format query string false "Формат (json, csv, xlsx); также учитывается заголовок Accept"
+// @Success 200 {array} model.ListingCancellationReport
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 500 {object} ErrorInternal
+// @Router /api/reports/listings-cancellations [get]
+func (h *Handler) GetListingsCancellationReport(c echo.Context) error {
+	startDate, err := parseTimeParam(c, "start_date")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	endDate, err := parseTimeParam(c, "end_date")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	format, err := parseExportFormat(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	reports, err := h.service.GetListingsCancellationReport(c.Request().Context(), startDate, endDate)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	if format != "" {
+		return exportRows(c, format, "listings-cancellations", reports)
+	}
+
+	return c.JSON(http.StatusOK, reports)
+}
+
+// @Summary Получить отчет по отменам бронирований для хостов
+// @Description Отмены восстанавливаются из удалений броней в audit_log. Доля отмен считается от всех броней с заездом в периоде
+// @Tags functions
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param start_date query string false "Заезд не раньше" format(date-time) example(2025-01-01T00:00:00Z)
+// @Param end_date query string false "Заезд не позже" format(date-time) example(2025-12-31T23:59:59Z)
+// @Param format query string false "Формат (json, csv, xlsx); также учитывается заголовок Accept"
+// @Success 200 {array} model.HostCancellationReport
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 500 {object} ErrorInternal
+// @Router /api/reports/hosts-cancellations [get]
+func (h *Handler) GetHostsCancellationReport(c echo.Context) error {
+	startDate, err := parseTimeParam(c, "start_date")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	endDate, err := parseTimeParam(c, "end_date")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	format, err := parseExportFormat(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	reports, err := h.service.GetHostsCancellationReport(c.Request().Context(), startDate, endDate)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	if format != "" {
+		return exportRows(c, format, "hosts-cancellations", reports)
+	}
+
+	return c.JSON(http.StatusOK, reports)
+}
+
+// @Summary Получить отчет по неуспешным платежам в разрезе способа оплаты
+// @Description Доля ошибок считается от платежей в статусах completed и failed
+// @Tags functions
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param start_date query string false "Start Date" format(date-time) example(2025-01-01T00:00:00Z)
+// @Param end_date query string false "End Date" format(date-time) example(2025-12-31T23:59:59Z)
+// @Param format query string false "Формат (json, csv, xlsx); также учитывается заголовок Accept"
+// @Success 200 {array} model.PaymentFailureReport
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 500 {object} ErrorInternal
+// @Router /api/reports/payment-failures [get]
+func (h *Handler) GetPaymentFailuresReport(c echo.Context) error {
+	startDate, err := parseTimeParam(c, "start_date")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	endDate, err := parseTimeParam(c, "end_date")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	format, err := parseExportFormat(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	reports, err := h.service.GetPaymentFailuresReport(c.Request().Context(), startDate, endDate)
+	if err != nil {
+		return reportError(c, err)
+	}
+
+	if format != "" {
+		return exportRows(c, format, "payment-failures", reports)
+	}
+
+	return c.JSON(http.StatusOK, reports)
+}
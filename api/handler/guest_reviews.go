@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Создать отзыв хоста о госте
+// @Tags guest-reviews
+// @Accept json
+// @Produce json
+// @Param review body GuestReviewCreate true "Данные отзыва"
+// @Success 201 {object} model.GuestReview
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 403 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/guest-reviews [post]
+func (h *Handler) CreateGuestReview(c echo.Context) error {
+	var reviewCreate GuestReviewCreate
+	if err := c.Bind(&reviewCreate); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid request body",
+		})
+	}
+
+	review := model.GuestReview{
+		BookingID: reviewCreate.BookingID,
+		HostID:    reviewCreate.HostID,
+		Text:      reviewCreate.Text,
+		Score:     reviewCreate.Score,
+	}
+
+	if err := h.service.CreateGuestReview(c.Request().Context(), &review); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, ErrorNotFound{
+				Error: err.Error(),
+			})
+		}
+		if strings.Contains(err.Error(), "only the listing host") {
+			return c.JSON(http.StatusForbidden, ErrorBadRequest{
+				Error: err.Error(),
+			})
+		}
+		if strings.Contains(err.Error(), "review window") || strings.Contains(err.Error(), "invalid review") ||
+			strings.Contains(err.Error(), "rejected by moderation") || strings.Contains(err.Error(), "already exists") {
+			return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+				Error: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorInternal{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, review)
+}
+
+// @Summary Получить отзыв хоста о госте по ID
+// @Tags guest-reviews
+// @Produce json
+// @Param id path int true "Guest review ID"
+// @Success 200 {object} model.GuestReview
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/guest-reviews/{id} [get]
+func (h *Handler) GetGuestReviewByID(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid guest review id",
+		})
+	}
+
+	review, err := h.service.GetGuestReviewByID(c.Request().Context(), id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, ErrorNotFound{
+				Error: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorInternal{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, review)
+}
+
+// @Summary Удалить отзыв хоста о госте
+// @Tags guest-reviews
+// @Produce json
+// @Param id path int true "Guest review ID"
+// @Success 200 {object} StatusOK
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/guest-reviews/{id} [delete]
+func (h *Handler) DeleteGuestReview(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid guest review id",
+		})
+	}
+
+	if err := h.service.DeleteGuestReview(c.Request().Context(), id); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, ErrorNotFound{
+				Error: err.Error(),
+			})
+		}
+		if strings.Contains(err.Error(), "review window") {
+			return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+				Error: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorInternal{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, StatusOK{
+		Message: "guest review deleted successfully",
+	})
+}
+
+// @Summary Получить репутацию гостя
+// @Tags guest-reviews
+// @Produce json
+// @Param user_id path int true "Guest ID"
+// @Success 200 {object} model.GuestReputation
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/users/{user_id}/reputation [get]
+func (h *Handler) GetGuestReputation(c echo.Context) error {
+	userIDStr := c.Param("user_id")
+	userID, err := strconv.Atoi(userIDStr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid user id",
+		})
+	}
+
+	reputation, err := h.service.GetGuestReputation(c.Request().Context(), userID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, ErrorNotFound{
+				Error: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorInternal{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, reputation)
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/Rissochek/db-cw/internal/model"
@@ -29,6 +30,8 @@ type Service interface {
 	UpdateListing(ctx context.Context, listing *model.Listing) error
 	DeleteListing(ctx context.Context, id int) error
 	CreateListings(ctx context.Context, listings []model.Listing) error
+	GetListings(ctx context.Context, filter model.ListingsSearchFilter) ([]model.Listing, error)
+	SearchListings(ctx context.Context, filter model.ListingsSearchFilter, locale string) (*model.ListingsSearchResult, error)
 
 	CreateBooking(ctx context.Context, booking *model.Booking) error
 	GetBookingByID(ctx context.Context, bookingID int) (*model.Booking, error)
@@ -41,22 +44,56 @@ type Service interface {
 	UpdateReview(ctx context.Context, review *model.Review) error
 	DeleteReview(ctx context.Context, id int) error
 	CreateReviews(ctx context.Context, reviews []model.Review) error
+	GetReviewsModerationQueue(ctx context.Context, status string, limit, offset int) ([]model.Review, error)
+	ModerateReview(ctx context.Context, id int, status string) error
+	RespondToReview(ctx context.Context, id int, hostID int, response string) (*model.Review, error)
+	GetListingReviews(ctx context.Context, listingID int, sort, cursor string, limit int) (*model.ReviewsPage, error)
+	GetUserReviews(ctx context.Context, userID int, sort, cursor string, limit int) (*model.ReviewsPage, error)
+
+	CreateGuestReview(ctx context.Context, review *model.GuestReview) error
+	GetGuestReviewByID(ctx context.Context, id int) (*model.GuestReview, error)
+	DeleteGuestReview(ctx context.Context, id int) error
+	GetGuestReputation(ctx context.Context, guestID int) (*model.GuestReputation, error)
 
 	CreateAmenity(ctx context.Context, amenity *model.Amenity) error
-	GetAmenityByID(ctx context.Context, id int) (*model.Amenity, error)
-	GetAllAmenities(ctx context.Context) ([]model.Amenity, error)
+	GetAmenityByID(ctx context.Context, id int, locale string) (*model.Amenity, error)
+	GetAllAmenities(ctx context.Context, category *string, locale string) ([]model.Amenity, error)
+	GetAmenityCategories(ctx context.Context, locale string) ([]model.AmenityCategory, error)
 	UpdateAmenity(ctx context.Context, amenity *model.Amenity) error
 	DeleteAmenity(ctx context.Context, id int) error
 	AddAmenityToListing(ctx context.Context, listingID int, amenityID int) error
 	RemoveAmenityFromListing(ctx context.Context, listingID int, amenityID int) error
-	GetAmenitiesByListingID(ctx context.Context, listingID int) ([]model.Amenity, error)
+	GetAmenitiesByListingID(ctx context.Context, listingID int, locale string) ([]model.Amenity, error)
+	ReplaceListingAmenities(ctx context.Context, listingID int, amenityIDs []int, locale string) ([]model.Amenity, error)
 
 	CreateFavorite(ctx context.Context, favorite *model.Favorite) error
 	GetFavoriteByID(ctx context.Context, id int) (*model.Favorite, error)
-	GetFavoritesByUserID(ctx context.Context, userID int) ([]model.Favorite, error)
+	GetFavoritesByUserID(ctx context.Context, userID int, withCards bool) ([]model.Favorite, error)
 	DeleteFavorite(ctx context.Context, id int) error
 	DeleteFavoriteByUserAndListing(ctx context.Context, userID int, listingID int) error
 
+	CreateWishlist(ctx context.Context, wishlist *model.Wishlist) error
+	GetWishlistsByUserID(ctx context.Context, userID int) ([]model.Wishlist, error)
+	GetWishlistByID(ctx context.Context, id int) (*model.Wishlist, error)
+	GetSharedWishlist(ctx context.Context, token string) (*model.Wishlist, error)
+	UpdateWishlist(ctx context.Context, wishlist *model.Wishlist) error
+	DeleteWishlist(ctx context.Context, id int) error
+	AddWishlistItem(ctx context.Context, favorite *model.Favorite) error
+	UpdateWishlistItem(ctx context.Context, favorite *model.Favorite) error
+	RemoveWishlistItem(ctx context.Context, wishlistID int, listingID int) error
+	ShareWishlist(ctx context.Context, id int) (*model.Wishlist, error)
+	UnshareWishlist(ctx context.Context, id int) error
+
+	GetUserNotifications(ctx context.Context, userID int, unreadOnly bool, limit, offset int) ([]model.Notification, error)
+	MarkNotificationRead(ctx context.Context, id int) error
+
+	StartConversation(ctx context.Context, conversation *model.Conversation) error
+	GetUserConversations(ctx context.Context, userID int, limit, offset int) ([]model.Conversation, error)
+	GetConversation(ctx context.Context, id int, userID int) (*model.Conversation, error)
+	GetConversationMessages(ctx context.Context, id int, userID int, limit, offset int) ([]model.Message, error)
+	SendMessage(ctx context.Context, message *model.Message) error
+	MarkConversationRead(ctx context.Context, id int, userID int) (int, error)
+
 	CreatePayment(ctx context.Context, payment *model.Payment) error
 	GetPaymentByID(ctx context.Context, paymentID int) (*model.Payment, error)
 	GetPaymentsByBookingID(ctx context.Context, bookingID int) ([]model.Payment, error)
@@ -64,10 +101,12 @@ type Service interface {
 	DeletePayment(ctx context.Context, paymentID int) error
 
 	CreateImage(ctx context.Context, image *model.Image) error
+	UploadImage(ctx context.Context, image *model.Image, size int64, body io.Reader) error
 	GetImageByID(ctx context.Context, imageID int) (*model.Image, error)
 	GetImagesByListingID(ctx context.Context, listingID int) ([]model.Image, error)
 	UpdateImage(ctx context.Context, image *model.Image) error
 	DeleteImage(ctx context.Context, imageID int) error
+	ReorderImages(ctx context.Context, listingID int, imageIDs []int) ([]model.Image, error)
 
 	GetHostTotalRevenue(ctx context.Context, hostID int) (float64, error)
 	GetGuestTotalSpent(ctx context.Context, guestID int) (float64, error)
@@ -75,11 +114,40 @@ type Service interface {
 	GetListingActiveBookingsCount(ctx context.Context, listingID int) (int, error)
 
 	GetListingsStatisticsReport(ctx context.Context) ([]model.ListingStatisticsReport, error)
+	GetCitiesStatisticsReport(ctx context.Context, country *string) ([]model.CityStatisticsReport, error)
 	GetHostsPerformanceReport(ctx context.Context) ([]model.HostPerformanceReport, error)
+	RefreshReports(ctx context.Context) error
+	GetReportStaleAsOf(ctx context.Context, viewName string) (time.Time, error)
+	StreamListingsStatisticsReport(ctx context.Context, fn func(model.ListingStatisticsReport) error) error
+	StreamHostsPerformanceReport(ctx context.Context, fn func(model.HostPerformanceReport) error) error
+	StreamBookingsReport(ctx context.Context, startDate, endDate *time.Time, fn func(model.BookingReport) error) error
+	StreamListingsOccupancyReport(ctx context.Context, startDate, endDate *time.Time, fn func(model.ListingOccupancyReport) error) error
+	StreamCitiesStatisticsReport(ctx context.Context, country *string, fn func(model.CityStatisticsReport) error) error
+	StreamPaymentsSummaryReport(ctx context.Context, startDate, endDate *time.Time, fn func(model.PaymentSummaryReport) error) error
+	StreamHostsOccupancyReport(ctx context.Context, startDate, endDate *time.Time, fn func(model.HostOccupancyReport) error) error
+	StreamTimeseriesReport(ctx context.Context, filter model.TimeseriesFilter, fn func(model.TimeseriesPoint) error) error
 	GetBookingsReport(ctx context.Context, startDate, endDate *time.Time) ([]model.BookingReport, error)
 	GetPaymentsSummaryReport(ctx context.Context, startDate, endDate *time.Time) ([]model.PaymentSummaryReport, error)
+	GetListingsOccupancyReport(ctx context.Context, startDate, endDate *time.Time) ([]model.ListingOccupancyReport, error)
+	GetHostsOccupancyReport(ctx context.Context, startDate, endDate *time.Time) ([]model.HostOccupancyReport, error)
+	GetTimeseriesReport(ctx context.Context, filter model.TimeseriesFilter) (*model.TimeseriesReport, error)
+	GetGuestCohortCells(ctx context.Context, startDate, endDate *time.Time) ([]model.GuestCohortCell, error)
+	GetListingsCancellationReport(ctx context.Context, startDate, endDate *time.Time) ([]model.ListingCancellationReport, error)
+	GetHostsCancellationReport(ctx context.Context, startDate, endDate *time.Time) ([]model.HostCancellationReport, error)
+	GetPaymentFailuresReport(ctx context.Context, startDate, endDate *time.Time) ([]model.PaymentFailureReport, error)
+	GetGuestCohortReport(ctx context.Context, startDate, endDate *time.Time) (*model.GuestCohortReport, error)
+
+	GetHostDashboard(ctx context.Context, hostID int) (*model.HostDashboard, error)
+	GetGuestTrips(ctx context.Context, guestID int) (*model.GuestTrips, error)
+	GetListingBenchmark(ctx context.Context, listingID int, radiusKm *float64) (*model.ListingBenchmark, error)
 
 	CreateBookingWithPayment(ctx context.Context, listingID, guestID int, inDate, outDate time.Time, paymentMethod string) (*model.CreateBookingWithPaymentResult, error)
 	ConfirmPayment(ctx context.Context, paymentID int, transactionID *string) error
 	CancelBookingWithRefund(ctx context.Context, bookingID int) error
+
+	ReconcilePayments(ctx context.Context, fix bool) (*model.PaymentReconciliationReport, error)
+
+	GetListingsAnalytics(ctx context.Context, filter model.AnalyticsFilter) (*model.ListingsAnalyticsPage, error)
+	GetHostsAnalytics(ctx context.Context, filter model.AnalyticsFilter) (*model.HostsAnalyticsPage, error)
+	GetBookingsAnalytics(ctx context.Context, filter model.AnalyticsFilter) (*model.BookingsAnalyticsPage, error)
 }
@@ -47,6 +47,85 @@ func (h *Handler) CreateImage(c echo.Context) error {
 	return c.JSON(http.StatusCreated, image)
 }
 
+// @Summary Загрузить изображение объявления
+// @Tags images
+// @Accept multipart/form-data
+// @Produce json
+// @Param listing_id path int true "Listing ID"
+// @Param file formData file true "Файл изображения (jpeg, png, webp)"
+// @Param is_primary formData bool false "Основное изображение"
+// @Param order_index formData int false "Порядковый номер"
+// @Success 201 {object} model.Image
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/listings/{listing_id}/images [post]
+func (h *Handler) UploadImage(c echo.Context) error {
+	listingIDStr := c.Param("listing_id")
+	listingID, err := strconv.Atoi(listingIDStr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid listing id",
+		})
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "file is required",
+		})
+	}
+
+	image := model.Image{
+		ListingID: listingID,
+	}
+
+	if isPrimaryStr := c.FormValue("is_primary"); isPrimaryStr != "" {
+		image.IsPrimary, err = strconv.ParseBool(isPrimaryStr)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+				Error: "invalid is_primary",
+			})
+		}
+	}
+
+	if orderIndexStr := c.FormValue("order_index"); orderIndexStr != "" {
+		orderIndex, err := strconv.Atoi(orderIndexStr)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+				Error: "invalid order_index",
+			})
+		}
+		image.OrderIndex = orderIndex
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "failed to read file",
+		})
+	}
+	defer file.Close()
+
+	if err := h.service.UploadImage(c.Request().Context(), &image, fileHeader.Size, file); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, ErrorNotFound{
+				Error: err.Error(),
+			})
+		}
+		if strings.Contains(err.Error(), "invalid image") {
+			return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+				Error: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorInternal{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, image)
+}
+
 // @Summary Получить изображение по ID
 // @Tags images
 // @Produce json
@@ -107,6 +186,53 @@ func (h *Handler) GetImagesByListingID(c echo.Context) error {
 	return c.JSON(http.StatusOK, images)
 }
 
+// @Summary Изменить порядок изображений объявления
+// @Tags images
+// @Accept json
+// @Produce json
+// @Param listing_id path int true "Listing ID"
+// @Param order body ImagesOrderUpdate true "Полный список ID изображений в новом порядке"
+// @Success 200 {array} model.Image
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/listings/{listing_id}/images/order [put]
+func (h *Handler) ReorderImages(c echo.Context) error {
+	listingIDStr := c.Param("listing_id")
+	listingID, err := strconv.Atoi(listingIDStr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid listing id",
+		})
+	}
+
+	var orderUpdate ImagesOrderUpdate
+	if err := c.Bind(&orderUpdate); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid request body",
+		})
+	}
+
+	images, err := h.service.ReorderImages(c.Request().Context(), listingID, orderUpdate.ImageIDs)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, ErrorNotFound{
+				Error: err.Error(),
+			})
+		}
+		if strings.Contains(err.Error(), "invalid image order") {
+			return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+				Error: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorInternal{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, images)
+}
+
 // @Summary Обновить изображение
 // @Tags images
 // @Accept json
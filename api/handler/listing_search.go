@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Поиск объявлений с фасетами по удобствам
+// @Tags listings
+// @Produce json
+// @Param q query string false "Полнотекстовый запрос по заголовку, описанию, адресу и отзывам"
+// @Param min_price query number false "Минимальная цена за ночь"
+// @Param max_price query number false "Максимальная цена за ночь"
+// @Param min_rooms query int false "Минимум комнат"
+// @Param min_beds query int false "Минимум кроватей"
+// @Param available query bool false "Только доступные"
+// @Param amenities query string false "ID удобств через запятую, нужны все"
+// @Param city query string false "Город"
+// @Param region query string false "Регион"
+// @Param country query string false "Страна"
+// @Param lat query number false "Широта точки поиска"
+// @Param lng query number false "Долгота точки поиска"
+// @Param radius_km query number false "Радиус поиска в км (по умолчанию 10, максимум 500)"
+// @Param min_lat query number false "Южная граница области карты"
+// @Param min_lng query number false "Западная граница области карты (больше max_lng, если область пересекает 180-й меридиан)"
+// @Param max_lat query number false "Северная граница области карты"
+// @Param max_lng query number false "Восточная граница области карты"
+// @Param limit query int false "Размер страницы (по умолчанию 20, максимум 100)"
+// @Param offset query int false "Смещение"
+// @Param lang query string false "Язык подписей (ru, en)"
+// @Success 200 {object} model.ListingsSearchResult
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 500 {object} ErrorInternal
+// @Router /api/listings/search [get]
+func (h *Handler) SearchListings(c echo.Context) error {
+	filter, err := parseListingsSearchFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	result, err := h.service.SearchListings(c.Request().Context(), filter, parseLocale(c))
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid search filter") {
+			return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+				Error: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorInternal{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// @Summary Получить объявления, в том числе рядом с точкой или в области карты
+// @Description С lat и lng возвращает объявления в радиусе radius_km, ближайшие первыми
+// @Tags listings
+// @Produce json
+// @Param q query string false "Полнотекстовый запрос по заголовку, описанию, адресу и отзывам"
+// @Param lat query number false "Широта точки поиска"
+// @Param lng query number false "Долгота точки поиска"
+// @Param radius_km query number false "Радиус поиска в км (по умолчанию 10, максимум 500)"
+// @Param min_lat query number false "Южная граница области карты"
+// @Param min_lng query number false "Западная граница области карты (больше max_lng, если область пересекает 180-й меридиан)"
+// @Param max_lat query number false "Северная граница области карты"
+// @Param max_lng query number false "Восточная граница области карты"
+// @Param min_price query number false "Минимальная цена за ночь"
+// @Param max_price query number false "Максимальная цена за ночь"
+// @Param available query bool false "Только доступные"
+// @Param city query string false "Город"
+// @Param region query string false "Регион"
+// @Param country query string false "Страна"
+// @Param limit query int false "Размер страницы (по умолчанию 20, максимум 100)"
+// @Param offset query int false "Смещение"
+// @Success 200 {array} model.Listing
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 500 {object} ErrorInternal
+// @Router /api/listings [get]
+func (h *Handler) GetListings(c echo.Context) error {
+	filter, err := parseListingsSearchFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	listings, err := h.service.GetListings(c.Request().Context(), filter)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid search filter") {
+			return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+				Error: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorInternal{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, listings)
+}
+
+func parseListingsSearchFilter(c echo.Context) (model.ListingsSearchFilter, error) {
+	var filter model.ListingsSearchFilter
+	var err error
+
+	filter.Limit, filter.Offset, err = parsePagination(c)
+	if err != nil {
+		return filter, err
+	}
+
+	if minPriceStr := c.QueryParam("min_price"); minPriceStr != "" {
+		minPrice, err := strconv.ParseFloat(minPriceStr, 64)
+		if err != nil || minPrice < 0 {
+			return filter, errors.New("invalid min_price")
+		}
+		filter.MinPrice = &minPrice
+	}
+
+	if maxPriceStr := c.QueryParam("max_price"); maxPriceStr != "" {
+		maxPrice, err := strconv.ParseFloat(maxPriceStr, 64)
+		if err != nil || maxPrice < 0 {
+			return filter, errors.New("invalid max_price")
+		}
+		filter.MaxPrice = &maxPrice
+	}
+
+	if minRoomsStr := c.QueryParam("min_rooms"); minRoomsStr != "" {
+		minRooms, err := strconv.Atoi(minRoomsStr)
+		if err != nil || minRooms < 0 {
+			return filter, errors.New("invalid min_rooms")
+		}
+		filter.MinRooms = &minRooms
+	}
+
+	if minBedsStr := c.QueryParam("min_beds"); minBedsStr != "" {
+		minBeds, err := strconv.Atoi(minBedsStr)
+		if err != nil || minBeds < 0 {
+			return filter, errors.New("invalid min_beds")
+		}
+		filter.MinBeds = &minBeds
+	}
+
+	if availableStr := c.QueryParam("available"); availableStr != "" {
+		filter.AvailableOnly, err = strconv.ParseBool(availableStr)
+		if err != nil {
+			return filter, errors.New("invalid available")
+		}
+	}
+
+	filter.AmenityIDs, err = parseIntList(c.QueryParam("amenities"))
+	if err != nil {
+		return filter, errors.New("invalid amenities")
+	}
+
+	filter.Query = parseStringParam(c, "q")
+	filter.City = parseStringParam(c, "city")
+	filter.Region = parseStringParam(c, "region")
+	filter.Country = parseStringParam(c, "country")
+
+	if filter.Latitude, err = parseFloatParam(c, "lat"); err != nil {
+		return filter, err
+	}
+	if filter.Longitude, err = parseFloatParam(c, "lng"); err != nil {
+		return filter, err
+	}
+	if filter.RadiusKm, err = parseFloatParam(c, "radius_km"); err != nil {
+		return filter, err
+	}
+
+	filter.Bounds, err = parseGeoBounds(c)
+	if err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+func parseStringParam(c echo.Context, name string) *string {
+	value := strings.TrimSpace(c.QueryParam(name))
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func parseFloatParam(c echo.Context, name string) (*float64, error) {
+	valueStr := c.QueryParam(name)
+	if valueStr == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return nil, errors.New("invalid " + name)
+	}
+
+	return &value, nil
+}
+
+// parseGeoBounds читает прямоугольник карты; границы задаются все четыре или ни одной
+func parseGeoBounds(c echo.Context) (*model.GeoBounds, error) {
+	names := []string{"min_lat", "min_lng", "max_lat", "max_lng"}
+	values := make([]*float64, len(names))
+	set := 0
+	for i, name := range names {
+		value, err := parseFloatParam(c, name)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			set++
+		}
+		values[i] = value
+	}
+
+	if set == 0 {
+		return nil, nil
+	}
+	if set != len(names) {
+		return nil, errors.New("invalid bounding box: min_lat, min_lng, max_lat and max_lng are required")
+	}
+
+	return &model.GeoBounds{
+		MinLatitude:  *values[0],
+		MinLongitude: *values[1],
+		MaxLatitude:  *values[2],
+		MaxLongitude: *values[3],
+	}, nil
+}
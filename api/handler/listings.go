@@ -27,6 +27,11 @@ func (h *Handler) CreateListing(c echo.Context) error {
 	}
 
 	if err := h.service.CreateListing(c.Request().Context(), &listing); err != nil {
+		if strings.Contains(err.Error(), "invalid listing") {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": err.Error(),
 		})
@@ -103,6 +108,11 @@ func (h *Handler) UpdateListing(c echo.Context) error {
 				"error": err.Error(),
 			})
 		}
+		if strings.Contains(err.Error(), "invalid listing") {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": err.Error(),
 		})
@@ -165,14 +175,28 @@ func (h *Handler) BatchImportListings(c echo.Context) error {
 	listings := make([]model.Listing, len(listingsCreate))
 	for i := range listingsCreate {
 		listings[i].HostID = listingsCreate[i].HostID
+		listings[i].Title = listingsCreate[i].Title
+		listings[i].Description = listingsCreate[i].Description
 		listings[i].Address = listingsCreate[i].Address
+		listings[i].Street = listingsCreate[i].Street
+		listings[i].City = listingsCreate[i].City
+		listings[i].Region = listingsCreate[i].Region
+		listings[i].PostalCode = listingsCreate[i].PostalCode
+		listings[i].Country = listingsCreate[i].Country
 		listings[i].PricePerNight = listingsCreate[i].PricePerNight
 		listings[i].RoomsNumber = listingsCreate[i].RoomsNumber
 		listings[i].BedsNumber = listingsCreate[i].BedsNumber
+		listings[i].Latitude = listingsCreate[i].Latitude
+		listings[i].Longitude = listingsCreate[i].Longitude
 		listings[i].IsAvailable = true
 	}
 
 	if err := h.service.CreateListings(c.Request().Context(), listings); err != nil {
+		if strings.Contains(err.Error(), "invalid listing") {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": err.Error(),
 		})
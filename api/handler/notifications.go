@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Получить уведомления пользователя
+// @Tags notifications
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Param unread query bool false "Только непрочитанные"
+// @Param limit query int false "Размер страницы (по умолчанию 20, максимум 100)"
+// @Param offset query int false "Смещение"
+// @Success 200 {array} model.Notification
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/users/{user_id}/notifications [get]
+func (h *Handler) GetUserNotifications(c echo.Context) error {
+	userIDStr := c.Param("user_id")
+	userID, err := strconv.Atoi(userIDStr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid user id",
+		})
+	}
+
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	var unreadOnly bool
+	if unreadStr := c.QueryParam("unread"); unreadStr != "" {
+		unreadOnly, err = strconv.ParseBool(unreadStr)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+				Error: "invalid unread",
+			})
+		}
+	}
+
+	notifications, err := h.service.GetUserNotifications(c.Request().Context(), userID, unreadOnly, limit, offset)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, ErrorNotFound{
+				Error: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorInternal{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, notifications)
+}
+
+// @Summary Отметить уведомление прочитанным
+// @Tags notifications
+// @Produce json
+// @Param id path int true "Notification ID"
+// @Success 200 {object} StatusOK
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/notifications/{id}/read [put]
+func (h *Handler) MarkNotificationRead(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid notification id",
+		})
+	}
+
+	if err := h.service.MarkNotificationRead(c.Request().Context(), id); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, ErrorNotFound{
+				Error: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorInternal{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, StatusOK{
+		Message: "marked as read",
+	})
+}
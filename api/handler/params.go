@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// parseLocale берет язык из ?lang=, иначе из первого значения Accept-Language
+func parseLocale(c echo.Context) string {
+	if lang := c.QueryParam("lang"); lang != "" {
+		return strings.ToLower(lang)
+	}
+
+	acceptLanguage := c.Request().Header.Get("Accept-Language")
+	if acceptLanguage == "" {
+		return ""
+	}
+
+	lang, _, _ := strings.Cut(acceptLanguage, ",")
+	lang, _, _ = strings.Cut(lang, ";")
+	lang, _, _ = strings.Cut(strings.TrimSpace(lang), "-")
+	return strings.ToLower(lang)
+}
+
+func parseIntList(value string) ([]int, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(value, ",")
+	result := make([]int, 0, len(parts))
+	for _, part := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, n)
+	}
+
+	return result, nil
+}
+
+func parseLimit(c echo.Context) (int, error) {
+	limitStr := c.QueryParam("limit")
+	if limitStr == "" {
+		return defaultPageLimit, nil
+	}
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit <= 0 {
+		return 0, errors.New("invalid limit")
+	}
+
+	return min(limit, maxPageLimit), nil
+}
+
+func parsePagination(c echo.Context) (limit, offset int, err error) {
+	limit, err = parseLimit(c)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if offsetStr := c.QueryParam("offset"); offsetStr != "" {
+		offset, err = strconv.Atoi(offsetStr)
+		if err != nil || offset < 0 {
+			return 0, 0, errors.New("invalid offset")
+		}
+	}
+
+	return limit, offset, nil
+}
+
+func parseIntParam(c echo.Context, name string) (*int, error) {
+	valueStr := c.QueryParam(name)
+	if valueStr == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.Atoi(valueStr)
+	if err != nil {
+		return nil, errors.New("invalid " + name)
+	}
+
+	return &value, nil
+}
+
+func parseTimeParam(c echo.Context, name string) (*time.Time, error) {
+	valueStr := c.QueryParam(name)
+	if valueStr == "" {
+		return nil, nil
+	}
+
+	value, err := time.Parse(time.RFC3339, valueStr)
+	if err != nil {
+		return nil, errors.New("invalid " + name + " format, use RFC3339")
+	}
+
+	return &value, nil
+}
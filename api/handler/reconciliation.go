@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Сверка бронирований и платежей
+// @Tags reconciliation
+// @Produce json
+// @Success 200 {object} model.PaymentReconciliationReport
+// @Failure 500 {object} ErrorInternal
+// @Router /api/reconciliation/payments [get]
+func (h *Handler) GetPaymentsReconciliation(c echo.Context) error {
+	report, err := h.service.ReconcilePayments(c.Request().Context(), false)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorInternal{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
+
+// @Summary Сверка бронирований и платежей с исправлением безопасных расхождений
+// @Tags reconciliation
+// @Produce json
+// @Success 200 {object} model.PaymentReconciliationReport
+// @Failure 500 {object} ErrorInternal
+// @Router /api/reconciliation/payments/fix [post]
+func (h *Handler) FixPaymentsReconciliation(c echo.Context) error {
+	report, err := h.service.ReconcilePayments(c.Request().Context(), true)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, ErrorInternal{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, report)
+}
@@ -16,6 +16,7 @@ import (
 // @Param review body ReviewCreate true "Данные отзыва"
 // @Success 201 {object} model.Review
 // @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
 // @Failure 500 {object} ErrorInternal
 // @Router /api/reviews [post]
 func (h *Handler) CreateReview(c echo.Context) error {
@@ -27,6 +28,16 @@ func (h *Handler) CreateReview(c echo.Context) error {
 	}
 
 	if err := h.service.CreateReview(c.Request().Context(), &review); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		if strings.Contains(err.Error(), "review window") || strings.Contains(err.Error(), "invalid review") {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": err.Error(),
 		})
@@ -103,6 +114,11 @@ func (h *Handler) UpdateReview(c echo.Context) error {
 				"error": err.Error(),
 			})
 		}
+		if strings.Contains(err.Error(), "review window") || strings.Contains(err.Error(), "invalid review") {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": err.Error(),
 		})
@@ -135,6 +151,11 @@ func (h *Handler) DeleteReview(c echo.Context) error {
 				"error": err.Error(),
 			})
 		}
+		if strings.Contains(err.Error(), "review window") {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": err.Error(),
 		})
@@ -167,6 +188,10 @@ func (h *Handler) BatchImportReviews(c echo.Context) error {
 		reviews[i].BookingID = reviewsCreate[i].BookingID
 		// reviews[i].UserID = reviewsCreate[i].UserID
 		reviews[i].Score = reviewsCreate[i].Score
+		reviews[i].Cleanliness = reviewsCreate[i].Cleanliness
+		reviews[i].Accuracy = reviewsCreate[i].Accuracy
+		reviews[i].Location = reviewsCreate[i].Location
+		reviews[i].Value = reviewsCreate[i].Value
 		if reviewsCreate[i].Text != "" {
 			reviews[i].Text = reviewsCreate[i].Text
 		}
@@ -182,3 +207,231 @@ func (h *Handler) BatchImportReviews(c echo.Context) error {
 		"created": len(reviews),
 	})
 }
+
+// @Summary Ответ хоста на отзыв
+// @Tags reviews
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param response body ReviewHostResponseCreate true "Ответ хоста"
+// @Success 200 {object} model.Review
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 403 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/reviews/{id}/response [post]
+func (h *Handler) RespondToReview(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid review id",
+		})
+	}
+
+	var req ReviewHostResponseCreate
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid request body",
+		})
+	}
+
+	review, err := h.service.RespondToReview(c.Request().Context(), id, req.HostID, req.Text)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, ErrorNotFound{
+				Error: err.Error(),
+			})
+		}
+		if strings.Contains(err.Error(), "only the listing host") {
+			return c.JSON(http.StatusForbidden, ErrorBadRequest{
+				Error: err.Error(),
+			})
+		}
+		if strings.Contains(err.Error(), "must not be empty") || strings.Contains(err.Error(), "rejected by moderation") ||
+			strings.Contains(err.Error(), "not revealed") {
+			return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+				Error: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorInternal{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, review)
+}
+
+// @Summary Очередь модерации отзывов
+// @Tags moderation
+// @Produce json
+// @Param status query string false "Статус отзыва (pending, published, hidden)" default(pending)
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {array} model.Review
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 500 {object} ErrorInternal
+// @Router /api/admin/reviews [get]
+func (h *Handler) GetReviewsModerationQueue(c echo.Context) error {
+	status := c.QueryParam("status")
+	if status == "" {
+		status = model.ReviewStatusPending
+	}
+
+	limit, offset, err := parsePagination(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	reviews, err := h.service.GetReviewsModerationQueue(c.Request().Context(), status, limit, offset)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid review status") {
+			return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+				Error: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorInternal{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, reviews)
+}
+
+// @Summary Изменить статус модерации отзыва
+// @Tags moderation
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param status body ReviewModerationUpdate true "Новый статус"
+// @Success 200 {object} StatusOK
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/admin/reviews/{id}/status [put]
+func (h *Handler) ModerateReview(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.Atoi(idStr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid review id",
+		})
+	}
+
+	var req ReviewModerationUpdate
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid request body",
+		})
+	}
+
+	if err := h.service.ModerateReview(c.Request().Context(), id, req.Status); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, ErrorNotFound{
+				Error: err.Error(),
+			})
+		}
+		if strings.Contains(err.Error(), "invalid review status") {
+			return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+				Error: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorInternal{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, StatusOK{
+		Message: "review status updated successfully",
+	})
+}
+
+// @Summary Получить отзывы объявления
+// @Tags reviews
+// @Produce json
+// @Param listing_id path int true "Listing ID"
+// @Param sort query string false "Сортировка (newest, score)" default(newest)
+// @Param cursor query string false "Курсор следующей страницы"
+// @Param limit query int false "Limit" default(20)
+// @Success 200 {object} model.ReviewsPage
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/listings/{listing_id}/reviews [get]
+func (h *Handler) GetListingReviews(c echo.Context) error {
+	listingIDStr := c.Param("listing_id")
+	listingID, err := strconv.Atoi(listingIDStr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid listing id",
+		})
+	}
+
+	limit, err := parseLimit(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	page, err := h.service.GetListingReviews(c.Request().Context(), listingID, c.QueryParam("sort"), c.QueryParam("cursor"), limit)
+	if err != nil {
+		return reviewsPageError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+// @Summary Получить отзывы пользователя
+// @Tags reviews
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Param sort query string false "Сортировка (newest, score)" default(newest)
+// @Param cursor query string false "Курсор следующей страницы"
+// @Param limit query int false "Limit" default(20)
+// @Success 200 {object} model.ReviewsPage
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/users/{user_id}/reviews [get]
+func (h *Handler) GetUserReviews(c echo.Context) error {
+	userIDStr := c.Param("user_id")
+	userID, err := strconv.Atoi(userIDStr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid user id",
+		})
+	}
+
+	limit, err := parseLimit(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+
+	page, err := h.service.GetUserReviews(c.Request().Context(), userID, c.QueryParam("sort"), c.QueryParam("cursor"), limit)
+	if err != nil {
+		return reviewsPageError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+func reviewsPageError(c echo.Context, err error) error {
+	if strings.Contains(err.Error(), "not found") {
+		return c.JSON(http.StatusNotFound, ErrorNotFound{
+			Error: err.Error(),
+		})
+	}
+	if strings.Contains(err.Error(), "invalid") {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, ErrorInternal{
+		Error: err.Error(),
+	})
+}
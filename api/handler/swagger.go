@@ -14,6 +14,10 @@ type ErrorNotFound struct {
 	Error string `json:"error" example:"not found"`
 }
 
+type ErrorForbidden struct {
+	Error string `json:"error" example:"user is not a participant of the conversation"`
+}
+
 type StatusOK struct {
 	Message string `json:"message" example:"deleted successfully"`
 }
@@ -40,18 +44,31 @@ type UserReturn struct {
 }
 
 type ListingCreate struct {
-	HostID        int     `json:"host_id" db:"host_id"`
-	Address       string  `json:"address" db:"address"`
-	PricePerNight float64 `json:"price_per_night" db:"price_per_night"`
-	RoomsNumber   int     `json:"rooms_number" db:"rooms_number"`
-	BedsNumber    int     `json:"beds_number" db:"beds_number"`
+	HostID        int      `json:"host_id" db:"host_id"`
+	Title         string   `json:"title" db:"title" example:"Уютная студия у парка"`
+	Description   string   `json:"description" db:"description"`
+	Address       string   `json:"address" db:"address"`
+	Street        *string  `json:"street,omitempty" db:"street" example:"Tverskaya St 7"`
+	City          *string  `json:"city,omitempty" db:"city" example:"Moscow"`
+	Region        *string  `json:"region,omitempty" db:"region"`
+	PostalCode    *string  `json:"postal_code,omitempty" db:"postal_code" example:"125009"`
+	Country       *string  `json:"country,omitempty" db:"country" example:"Russia"`
+	PricePerNight float64  `json:"price_per_night" db:"price_per_night"`
+	RoomsNumber   int      `json:"rooms_number" db:"rooms_number"`
+	BedsNumber    int      `json:"beds_number" db:"beds_number"`
+	Latitude      *float64 `json:"latitude,omitempty" db:"latitude" example:"55.7558"`
+	Longitude     *float64 `json:"longitude,omitempty" db:"longitude" example:"37.6173"`
 }
 
 type ListingUpdate struct {
-	PricePerNight float64 `json:"price_per_night" db:"price_per_night"`
-	IsAvailable   bool    `json:"is_available" db:"is_available"`
-	RoomsNumber   int     `json:"rooms_number" db:"rooms_number"`
-	BedsNumber    int     `json:"beds_number" db:"beds_number"`
+	Title         string   `json:"title" db:"title" example:"Уютная студия у парка"`
+	Description   string   `json:"description" db:"description"`
+	PricePerNight float64  `json:"price_per_night" db:"price_per_night"`
+	IsAvailable   bool     `json:"is_available" db:"is_available"`
+	RoomsNumber   int      `json:"rooms_number" db:"rooms_number"`
+	BedsNumber    int      `json:"beds_number" db:"beds_number"`
+	Latitude      *float64 `json:"latitude,omitempty" db:"latitude" example:"55.7558"`
+	Longitude     *float64 `json:"longitude,omitempty" db:"longitude" example:"37.6173"`
 }
 
 type BookingCreate struct {
@@ -68,27 +85,99 @@ type BookingUpdate struct {
 }
 
 type ReviewCreate struct {
-	BookingID int    `json:"booking_id" db:"booking_id"`
-	Text      string `json:"text" db:"text"`
-	Score     int    `json:"score" db:"score"`
+	BookingID   int    `json:"booking_id" db:"booking_id"`
+	Text        string `json:"text" db:"text"`
+	Score       int    `json:"score" db:"score"`
+	Cleanliness *int   `json:"cleanliness_score,omitempty" db:"cleanliness_score"`
+	Accuracy    *int   `json:"accuracy_score,omitempty" db:"accuracy_score"`
+	Location    *int   `json:"location_score,omitempty" db:"location_score"`
+	Value       *int   `json:"value_score,omitempty" db:"value_score"`
 }
 
 type ReviewUpdate struct {
-	Text  string `json:"text" db:"text"`
-	Score int    `json:"score" db:"score"`
+	Text        string `json:"text" db:"text"`
+	Score       int    `json:"score" db:"score"`
+	Cleanliness *int   `json:"cleanliness_score,omitempty" db:"cleanliness_score"`
+	Accuracy    *int   `json:"accuracy_score,omitempty" db:"accuracy_score"`
+	Location    *int   `json:"location_score,omitempty" db:"location_score"`
+	Value       *int   `json:"value_score,omitempty" db:"value_score"`
+}
+
+type ReviewModerationUpdate struct {
+	Status string `json:"status" db:"status" example:"published"`
+}
+
+type ReviewHostResponseCreate struct {
+	HostID int    `json:"host_id" db:"host_id"`
+	Text   string `json:"text" db:"host_response"`
+}
+
+type GuestReviewCreate struct {
+	BookingID int    `json:"booking_id" db:"booking_id"`
+	HostID    int    `json:"host_id" db:"host_id"`
+	Text      string `json:"text" db:"text"`
+	Score     int    `json:"score" db:"score"`
 }
 
 type AmenityCreate struct {
-	Name string `json:"name" db:"name"`
+	Name     string            `json:"name" db:"name"`
+	Category *string           `json:"category" db:"category" example:"kitchen"`
+	IconKey  *string           `json:"icon_key" db:"icon_key" example:"microwave"`
+	Labels   map[string]string `json:"labels" db:"labels"`
 }
 
 type AmenityUpdate struct {
-	Name string `json:"name" db:"name"`
+	Name     string            `json:"name" db:"name"`
+	Category *string           `json:"category" db:"category" example:"kitchen"`
+	IconKey  *string           `json:"icon_key" db:"icon_key" example:"microwave"`
+	Labels   map[string]string `json:"labels" db:"labels"`
+}
+
+type ListingAmenitiesUpdate struct {
+	AmenityIDs []int `json:"amenity_ids" example:"1,2,5"`
 }
 
 type FavoriteCreate struct {
-	UserID    int `json:"user_id" db:"user_id"`
-	ListingID int `json:"listing_id" db:"listing_id"`
+	UserID     int     `json:"user_id" db:"user_id"`
+	ListingID  int     `json:"listing_id" db:"listing_id"`
+	WishlistID int     `json:"wishlist_id" db:"wishlist_id"`
+	Note       *string `json:"note" db:"note"`
+}
+
+type WishlistCreate struct {
+	Name string `json:"name" db:"name" example:"Летняя поездка"`
+}
+
+type WishlistUpdate struct {
+	Name string `json:"name" db:"name" example:"Летняя поездка"`
+}
+
+type WishlistItemCreate struct {
+	ListingID int     `json:"listing_id" db:"listing_id"`
+	Note      *string `json:"note" db:"note"`
+}
+
+type WishlistItemUpdate struct {
+	Note *string `json:"note" db:"note"`
+}
+
+type ConversationCreate struct {
+	ListingID int  `json:"listing_id" db:"listing_id"`
+	GuestID   int  `json:"guest_id" db:"guest_id"`
+	BookingID *int `json:"booking_id,omitempty" db:"booking_id"`
+}
+
+type MessageCreate struct {
+	SenderID int    `json:"sender_id" db:"sender_id"`
+	Text     string `json:"text" db:"text" example:"Здравствуйте! Можно заехать пораньше?"`
+}
+
+type ConversationReadUpdate struct {
+	UserID int `json:"user_id" db:"user_id"`
+}
+
+type ConversationReadResult struct {
+	MarkedCount int `json:"marked_count"`
 }
 
 type PaymentCreate struct {
@@ -115,6 +204,10 @@ type ImageUpdate struct {
 	OrderIndex int    `json:"order_index" db:"order_index"`
 }
 
+type ImagesOrderUpdate struct {
+	ImageIDs []int `json:"image_ids" example:"3,1,2"`
+}
+
 type BookingWithPaymentCreate struct {
 	ListingID     int    `json:"listing_id" db:"listing_id"`
 	GuestID       int    `json:"guest_id" db:"guest_id"`
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Получить поездки гостя
+// @Description Предстоящие, текущие и прошлые бронирования с карточками объявлений и статусом оплаты,
+// @Description общая сумма трат и завершенные поездки, на которые еще можно оставить отзыв
+// @Tags users
+// @Produce json
+// @Param id path int true "User ID"
+// @Success 200 {object} model.GuestTrips
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/users/{id}/trips [get]
+func (h *Handler) GetGuestTrips(c echo.Context) error {
+	guestID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid user id",
+		})
+	}
+
+	trips, err := h.service.GetGuestTrips(c.Request().Context(), guestID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return c.JSON(http.StatusNotFound, ErrorNotFound{
+				Error: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, ErrorInternal{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, trips)
+}
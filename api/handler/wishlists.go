@@ -0,0 +1,369 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"github.com/labstack/echo/v4"
+)
+
+// @Summary Создать список избранного
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Param wishlist body WishlistCreate true "Данные списка"
+// @Success 201 {object} model.Wishlist
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/users/{user_id}/wishlists [post]
+func (h *Handler) CreateWishlist(c echo.Context) error {
+	userIDStr := c.Param("user_id")
+	userID, err := strconv.Atoi(userIDStr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid user id",
+		})
+	}
+
+	var wishlistCreate WishlistCreate
+	if err := c.Bind(&wishlistCreate); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid request body",
+		})
+	}
+
+	wishlist := model.Wishlist{
+		UserID: userID,
+		Name:   wishlistCreate.Name,
+	}
+
+	if err := h.service.CreateWishlist(c.Request().Context(), &wishlist); err != nil {
+		return wishlistError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, wishlist)
+}
+
+// @Summary Получить списки избранного пользователя
+// @Tags wishlists
+// @Produce json
+// @Param user_id path int true "User ID"
+// @Success 200 {array} model.Wishlist
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/users/{user_id}/wishlists [get]
+func (h *Handler) GetWishlistsByUserID(c echo.Context) error {
+	userIDStr := c.Param("user_id")
+	userID, err := strconv.Atoi(userIDStr)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid user id",
+		})
+	}
+
+	wishlists, err := h.service.GetWishlistsByUserID(c.Request().Context(), userID)
+	if err != nil {
+		return wishlistError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, wishlists)
+}
+
+// @Summary Получить список избранного с карточками объявлений
+// @Tags wishlists
+// @Produce json
+// @Param id path int true "Wishlist ID"
+// @Success 200 {object} model.Wishlist
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/wishlists/{id} [get]
+func (h *Handler) GetWishlistByID(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid wishlist id",
+		})
+	}
+
+	wishlist, err := h.service.GetWishlistByID(c.Request().Context(), id)
+	if err != nil {
+		return wishlistError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, wishlist)
+}
+
+// @Summary Открыть список избранного по ссылке (только чтение)
+// @Tags wishlists
+// @Produce json
+// @Param token path string true "Share token"
+// @Success 200 {object} model.Wishlist
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/shared/wishlists/{token} [get]
+func (h *Handler) GetSharedWishlist(c echo.Context) error {
+	wishlist, err := h.service.GetSharedWishlist(c.Request().Context(), c.Param("token"))
+	if err != nil {
+		return wishlistError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, wishlist)
+}
+
+// @Summary Переименовать список избранного
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param id path int true "Wishlist ID"
+// @Param wishlist body WishlistUpdate true "Данные списка"
+// @Success 200 {object} model.Wishlist
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/wishlists/{id} [put]
+func (h *Handler) UpdateWishlist(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid wishlist id",
+		})
+	}
+
+	var wishlistUpdate WishlistUpdate
+	if err := c.Bind(&wishlistUpdate); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid request body",
+		})
+	}
+
+	wishlist := model.Wishlist{
+		ID:   id,
+		Name: wishlistUpdate.Name,
+	}
+
+	if err := h.service.UpdateWishlist(c.Request().Context(), &wishlist); err != nil {
+		return wishlistError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, wishlist)
+}
+
+// @Summary Удалить список избранного
+// @Tags wishlists
+// @Produce json
+// @Param id path int true "Wishlist ID"
+// @Success 200 {object} StatusOK
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/wishlists/{id} [delete]
+func (h *Handler) DeleteWishlist(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid wishlist id",
+		})
+	}
+
+	if err := h.service.DeleteWishlist(c.Request().Context(), id); err != nil {
+		return wishlistError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, StatusOK{
+		Message: "deleted successfully",
+	})
+}
+
+// @Summary Добавить объявление в список избранного
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param id path int true "Wishlist ID"
+// @Param item body WishlistItemCreate true "Объявление и заметка"
+// @Success 201 {object} model.Favorite
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/wishlists/{id}/items [post]
+func (h *Handler) AddWishlistItem(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid wishlist id",
+		})
+	}
+
+	var itemCreate WishlistItemCreate
+	if err := c.Bind(&itemCreate); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid request body",
+		})
+	}
+
+	favorite := model.Favorite{
+		WishlistID: id,
+		ListingID:  itemCreate.ListingID,
+		Note:       itemCreate.Note,
+	}
+
+	if err := h.service.AddWishlistItem(c.Request().Context(), &favorite); err != nil {
+		return wishlistError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, favorite)
+}
+
+// @Summary Изменить заметку к объявлению в списке
+// @Tags wishlists
+// @Accept json
+// @Produce json
+// @Param id path int true "Wishlist ID"
+// @Param listing_id path int true "Listing ID"
+// @Param item body WishlistItemUpdate true "Заметка"
+// @Success 200 {object} model.Favorite
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/wishlists/{id}/items/{listing_id} [put]
+func (h *Handler) UpdateWishlistItem(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid wishlist id",
+		})
+	}
+
+	listingID, err := strconv.Atoi(c.Param("listing_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid listing id",
+		})
+	}
+
+	var itemUpdate WishlistItemUpdate
+	if err := c.Bind(&itemUpdate); err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid request body",
+		})
+	}
+
+	favorite := model.Favorite{
+		WishlistID: id,
+		ListingID:  listingID,
+		Note:       itemUpdate.Note,
+	}
+
+	if err := h.service.UpdateWishlistItem(c.Request().Context(), &favorite); err != nil {
+		return wishlistError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, favorite)
+}
+
+// @Summary Убрать объявление из списка избранного
+// @Tags wishlists
+// @Produce json
+// @Param id path int true "Wishlist ID"
+// @Param listing_id path int true "Listing ID"
+// @Success 200 {object} StatusOK
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/wishlists/{id}/items/{listing_id} [delete]
+func (h *Handler) RemoveWishlistItem(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid wishlist id",
+		})
+	}
+
+	listingID, err := strconv.Atoi(c.Param("listing_id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid listing id",
+		})
+	}
+
+	if err := h.service.RemoveWishlistItem(c.Request().Context(), id, listingID); err != nil {
+		return wishlistError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, StatusOK{
+		Message: "deleted successfully",
+	})
+}
+
+// @Summary Открыть доступ к списку по ссылке
+// @Tags wishlists
+// @Produce json
+// @Param id path int true "Wishlist ID"
+// @Success 200 {object} model.Wishlist
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/wishlists/{id}/share [post]
+func (h *Handler) ShareWishlist(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid wishlist id",
+		})
+	}
+
+	wishlist, err := h.service.ShareWishlist(c.Request().Context(), id)
+	if err != nil {
+		return wishlistError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, wishlist)
+}
+
+// @Summary Закрыть доступ к списку по ссылке
+// @Tags wishlists
+// @Produce json
+// @Param id path int true "Wishlist ID"
+// @Success 200 {object} StatusOK
+// @Failure 400 {object} ErrorBadRequest
+// @Failure 404 {object} ErrorNotFound
+// @Failure 500 {object} ErrorInternal
+// @Router /api/wishlists/{id}/share [delete]
+func (h *Handler) UnshareWishlist(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: "invalid wishlist id",
+		})
+	}
+
+	if err := h.service.UnshareWishlist(c.Request().Context(), id); err != nil {
+		return wishlistError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, StatusOK{
+		Message: "share link revoked",
+	})
+}
+
+func wishlistError(c echo.Context, err error) error {
+	if strings.Contains(err.Error(), "not found") {
+		return c.JSON(http.StatusNotFound, ErrorNotFound{
+			Error: err.Error(),
+		})
+	}
+	if strings.Contains(err.Error(), "invalid wishlist") || strings.Contains(err.Error(), "already exists") ||
+		strings.Contains(err.Error(), "cannot delete default wishlist") {
+		return c.JSON(http.StatusBadRequest, ErrorBadRequest{
+			Error: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, ErrorInternal{
+		Error: err.Error(),
+	})
+}
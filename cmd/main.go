@@ -1,8 +1,12 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/Rissochek/db-cw/internal/app"
 	"go.uber.org/zap"
@@ -22,7 +26,26 @@ func main() {
 	zap.ReplaceGlobals(logger)
 	defer logger.Sync()
 
-	if err := app.InitApp().SetupRoutes().Start(":8080"); err != nil && !errors.Is(err, http.ErrServerClosed) {
-		zap.S().Errorf("failed to start server: %v", err)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	application := app.InitApp(ctx)
+	e := application.SetupRoutes()
+
+	go func() {
+		if err := e.Start(":8080"); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			zap.S().Errorf("failed to start server: %v", err)
+			stop()
+		}
+	}()
+
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		zap.S().Errorf("failed to shutdown server: %v", err)
 	}
+
+	application.Wait()
 }
@@ -15,100 +15,46 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/amenities": {
+        "/api/admin/reviews": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "amenities"
-                ],
-                "summary": "Получить все удобства",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Amenity"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorInternal"
-                        }
-                    }
-                }
-            },
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "amenities"
+                    "moderation"
                 ],
-                "summary": "Создать удобство",
+                "summary": "Очередь модерации отзывов",
                 "parameters": [
                     {
-                        "description": "Данные удобства",
-                        "name": "amenity",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.AmenityCreate"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/model.Amenity"
-                        }
+                        "type": "string",
+                        "default": "pending",
+                        "description": "Статус отзыва (pending, published, hidden)",
+                        "name": "status",
+                        "in": "query"
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorBadRequest"
-                        }
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorInternal"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/amenities/{id}": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "amenities"
-                ],
-                "summary": "Получить удобство по ID",
-                "parameters": [
                     {
                         "type": "integer",
-                        "description": "Amenity ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Amenity"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Review"
+                            }
                         }
                     },
                     "400": {
@@ -117,12 +63,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorNotFound"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -130,7 +70,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/api/admin/reviews/{id}/status": {
             "put": {
                 "consumes": [
                     "application/json"
@@ -139,24 +81,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "amenities"
+                    "moderation"
                 ],
-                "summary": "Обновить удобство",
+                "summary": "Изменить статус модерации отзыва",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Amenity ID",
+                        "description": "Review ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Данные удобства",
-                        "name": "amenity",
+                        "description": "Новый статус",
+                        "name": "status",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.AmenityUpdate"
+                            "$ref": "#/definitions/handler.ReviewModerationUpdate"
                         }
                     }
                 ],
@@ -164,7 +106,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Amenity"
+                            "$ref": "#/definitions/handler.StatusOK"
                         }
                     },
                     "400": {
@@ -186,41 +128,39 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/amenities": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "amenities"
                 ],
-                "summary": "Удалить удобство",
+                "summary": "Получить все удобства",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Amenity ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Ключ категории",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Язык подписи (ru, en)",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.StatusOK"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorBadRequest"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorNotFound"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Amenity"
+                            }
                         }
                     },
                     "500": {
@@ -230,9 +170,7 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/bookings": {
+            },
             "post": {
                 "consumes": [
                     "application/json"
@@ -241,17 +179,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "amenities"
                 ],
-                "summary": "Создать бронирование",
+                "summary": "Создать удобство",
                 "parameters": [
                     {
-                        "description": "Данные бронирования",
-                        "name": "booking",
+                        "description": "Данные удобства",
+                        "name": "amenity",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.BookingCreate"
+                            "$ref": "#/definitions/handler.AmenityCreate"
                         }
                     }
                 ],
@@ -259,58 +197,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.Booking"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorBadRequest"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorInternal"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/bookings/batch": {
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "bookings"
-                ],
-                "summary": "Batch импорт бронирований",
-                "parameters": [
-                    {
-                        "description": "Массив бронирований",
-                        "name": "bookings",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handler.BookingCreate"
-                            }
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Количество созданных бронирований",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "integer"
-                            }
+                            "$ref": "#/definitions/model.Amenity"
                         }
                     },
                     "400": {
@@ -328,22 +215,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/bookings/{booking_id}/payments": {
+        "/api/amenities/categories": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
+                    "amenities"
                 ],
-                "summary": "Получить платежи по booking ID",
+                "summary": "Получить категории удобств",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Booking ID",
-                        "name": "booking_id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Язык подписи (ru, en)",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -352,16 +238,10 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.Payment"
+                                "$ref": "#/definitions/model.AmenityCategory"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorBadRequest"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -371,29 +251,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/bookings/{id}": {
+        "/api/amenities/{id}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "amenities"
                 ],
-                "summary": "Получить бронирование по ID",
+                "summary": "Получить удобство по ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Booking ID",
+                        "description": "Amenity ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Язык подписи (ru, en)",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Booking"
+                            "$ref": "#/definitions/model.Amenity"
                         }
                     },
                     "400": {
@@ -424,24 +310,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "amenities"
                 ],
-                "summary": "Обновить бронирование",
+                "summary": "Обновить удобство",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Booking ID",
+                        "description": "Amenity ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Данные бронирования",
-                        "name": "booking",
+                        "description": "Данные удобства",
+                        "name": "amenity",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.BookingUpdate"
+                            "$ref": "#/definitions/handler.AmenityUpdate"
                         }
                     }
                 ],
@@ -449,7 +335,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Booking"
+                            "$ref": "#/definitions/model.Amenity"
                         }
                     },
                     "400": {
@@ -477,13 +363,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "bookings"
+                    "amenities"
                 ],
-                "summary": "Удалить бронирование",
+                "summary": "Удалить удобство",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Booking ID",
+                        "description": "Amenity ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -517,34 +403,94 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/favorites": {
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/analytics/bookings": {
+            "get": {
+                "description": "Бронирование с несколькими платежами дает несколько строк",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "favorites"
+                    "analytics"
                 ],
-                "summary": "Добавить объявление в избранное",
+                "summary": "Аналитика по бронированиям и платежам (представление bookings_payments_analytics)",
                 "parameters": [
                     {
-                        "description": "Данные избранного",
-                        "name": "favorite",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.FavoriteCreate"
-                        }
+                        "type": "integer",
+                        "description": "Host ID",
+                        "name": "host_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Guest ID",
+                        "name": "guest_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Listing ID",
+                        "name": "listing_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Статус бронирования (completed, active, upcoming)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Статус платежа (pending, completed, failed, refunded)",
+                        "name": "payment_status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Заезд не раньше",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "Выезд не позже",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "in_date",
+                        "description": "Поле сортировки (booking_id, in_date, out_date, duration_days, total_price, payment_amount, paid_at, review_score)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Направление сортировки (asc, desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Favorite"
+                            "$ref": "#/definitions/model.BookingsAnalyticsPage"
                         }
                     },
                     "400": {
@@ -562,29 +508,53 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/favorites/{id}": {
+        "/api/analytics/hosts": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "favorites"
+                    "analytics"
                 ],
-                "summary": "Получить избранное по ID",
+                "summary": "Аналитика по хостам (представление hosts_analytics)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Favorite ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Host ID",
+                        "name": "host_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "total_revenue",
+                        "description": "Поле сортировки (host_id, total_listings, total_bookings, active_bookings, average_rating, total_reviews, total_revenue, avg_booking_revenue, failed_payments_count)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Направление сортировки (asc, desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Favorite"
+                            "$ref": "#/definitions/model.HostsAnalyticsPage"
                         }
                     },
                     "400": {
@@ -593,12 +563,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorNotFound"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -606,29 +570,61 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/analytics/listings": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "favorites"
+                    "analytics"
                 ],
-                "summary": "Удалить избранное по ID",
+                "summary": "Аналитика по объявлениям (представление listings_summary)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Favorite ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Host ID",
+                        "name": "host_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Доступность объявления",
+                        "name": "available",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "total_revenue",
+                        "description": "Поле сортировки (listing_id, price_per_night, average_rating, reviews_count, bookings_count, total_revenue, avg_payment_amount, active_bookings_count, favorites_count)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Направление сортировки (asc, desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.StatusOK"
+                            "$ref": "#/definitions/model.ListingsAnalyticsPage"
                         }
                     },
                     "400": {
@@ -637,12 +633,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorNotFound"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -652,33 +642,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/functions/guests/{guest_id}/total-spent": {
-            "get": {
+        "/api/bookings": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "functions"
+                    "bookings"
                 ],
-                "summary": "Получить общую сумму потраченную гостем",
+                "summary": "Создать бронирование",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Guest ID",
-                        "name": "guest_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Данные бронирования",
+                        "name": "booking",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.BookingCreate"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "number",
-                                "format": "float64"
-                            }
+                            "$ref": "#/definitions/model.Booking"
                         }
                     },
                     "400": {
@@ -696,32 +687,39 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/functions/hosts/{host_id}/average-rating": {
-            "get": {
+        "/api/bookings/batch": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "functions"
+                    "bookings"
                 ],
-                "summary": "Получить средний рейтинг хоста",
+                "summary": "Batch импорт бронирований",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Host ID",
-                        "name": "host_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Массив бронирований",
+                        "name": "bookings",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handler.BookingCreate"
+                            }
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Количество созданных бронирований",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
-                                "type": "number",
-                                "format": "float64"
+                                "type": "integer"
                             }
                         }
                     },
@@ -740,20 +738,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/functions/hosts/{host_id}/revenue": {
+        "/api/bookings/{booking_id}/payments": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "functions"
+                    "payments"
                 ],
-                "summary": "Получить общую выручку хоста",
+                "summary": "Получить платежи по booking ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Host ID",
-                        "name": "host_id",
+                        "description": "Booking ID",
+                        "name": "booking_id",
                         "in": "path",
                         "required": true
                     }
@@ -762,10 +760,9 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "number",
-                                "format": "float64"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Payment"
                             }
                         }
                     },
@@ -784,20 +781,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/functions/listings/{listing_id}/active-bookings": {
+        "/api/bookings/{id}": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "functions"
+                    "bookings"
                 ],
-                "summary": "Получить количество активных бронирований объявления",
+                "summary": "Получить бронирование по ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Listing ID",
-                        "name": "listing_id",
+                        "description": "Booking ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -806,10 +803,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "integer"
-                            }
+                            "$ref": "#/definitions/model.Booking"
                         }
                     },
                     "400": {
@@ -818,6 +812,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -825,10 +825,8 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/images": {
-            "post": {
+            },
+            "put": {
                 "consumes": [
                     "application/json"
                 ],
@@ -836,25 +834,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "images"
+                    "bookings"
                 ],
-                "summary": "Создать изображение",
+                "summary": "Обновить бронирование",
                 "parameters": [
                     {
-                        "description": "Данные изображения",
-                        "name": "image",
+                        "type": "integer",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные бронирования",
+                        "name": "booking",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.ImageCreate"
+                            "$ref": "#/definitions/handler.BookingUpdate"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Image"
+                            "$ref": "#/definitions/model.Booking"
                         }
                     },
                     "400": {
@@ -863,6 +868,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -870,21 +881,19 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/images/{id}": {
-            "get": {
+            },
+            "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "images"
+                    "bookings"
                 ],
-                "summary": "Получить изображение по ID",
+                "summary": "Удалить бронирование",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Image ID",
+                        "description": "Booking ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -894,7 +903,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Image"
+                            "$ref": "#/definitions/handler.StatusOK"
                         }
                     },
                     "400": {
@@ -916,8 +925,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/conversations": {
+            "post": {
+                "description": "Если переписка по объявлению, гостю и бронированию уже есть, возвращается она",
                 "consumes": [
                     "application/json"
                 ],
@@ -925,32 +937,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "images"
+                    "conversations"
                 ],
-                "summary": "Обновить изображение",
+                "summary": "Начать переписку гостя с хозяином",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Image ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Данные изображения",
-                        "name": "image",
+                        "description": "Объявление, гость и необязательное бронирование",
+                        "name": "conversation",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.ImageUpdate"
+                            "$ref": "#/definitions/handler.ConversationCreate"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.Image"
+                            "$ref": "#/definitions/model.Conversation"
                         }
                     },
                     "400": {
@@ -972,29 +977,39 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/conversations/{id}": {
+            "get": {
+                "description": "Временная заглушка до появления аутентификации: участник определяется по переданному user_id, которому сервер доверяет без проверки",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "images"
+                    "conversations"
                 ],
-                "summary": "Удалить изображение",
+                "summary": "Получить переписку",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Image ID",
+                        "description": "Conversation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID участника переписки (до появления аутентификации не проверяется)",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.StatusOK"
+                            "$ref": "#/definitions/model.Conversation"
                         }
                     },
                     "400": {
@@ -1003,6 +1018,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorForbidden"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1018,34 +1039,52 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/listings": {
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/conversations/{id}/messages": {
+            "get": {
+                "description": "Сообщения отдаются от новых к старым.\nВременная заглушка до появления аутентификации: участник определяется по переданному user_id, которому сервер доверяет без проверки",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "listings"
+                    "conversations"
                 ],
-                "summary": "Создать объявление",
+                "summary": "Получить сообщения переписки",
                 "parameters": [
                     {
-                        "description": "Данные объявления",
-                        "name": "listing",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.ListingCreate"
-                        }
+                        "type": "integer",
+                        "description": "Conversation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID участника переписки (до появления аутентификации не проверяется)",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Listing"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Message"
+                            }
                         }
                     },
                     "400": {
@@ -1054,6 +1093,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1061,10 +1112,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/listings/batch": {
+            },
             "post": {
+                "description": "Временная заглушка до появления аутентификации: участник определяется по переданному sender_id, которому сервер доверяет без проверки",
                 "consumes": [
                     "application/json"
                 ],
@@ -1072,31 +1122,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "listings"
+                    "conversations"
                 ],
-                "summary": "Batch импорт объявлений",
+                "summary": "Отправить сообщение",
                 "parameters": [
                     {
-                        "description": "Массив объявлений",
-                        "name": "listings",
+                        "type": "integer",
+                        "description": "Conversation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Отправитель и текст",
+                        "name": "message",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handler.ListingCreate"
-                            }
+                            "$ref": "#/definitions/handler.MessageCreate"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Количество созданных объявлений",
+                        "description": "Created",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "integer"
-                            }
+                            "$ref": "#/definitions/model.Message"
                         }
                     },
                     "400": {
@@ -1105,6 +1156,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorForbidden"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1114,29 +1177,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/listings/{id}": {
-            "get": {
+        "/api/conversations/{id}/read": {
+            "put": {
+                "description": "Отмечает прочитанными все входящие сообщения пользователя в переписке.\nВременная заглушка до появления аутентификации: участник определяется по переданному user_id, которому сервер доверяет без проверки",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "listings"
+                    "conversations"
                 ],
-                "summary": "Получить объявление по ID",
+                "summary": "Отметить переписку прочитанной",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Listing ID",
+                        "description": "Conversation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "ID участника переписки",
+                        "name": "reader",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ConversationReadUpdate"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Listing"
+                            "$ref": "#/definitions/handler.ConversationReadResult"
                         }
                     },
                     "400": {
@@ -1145,6 +1221,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorForbidden"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1158,8 +1240,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/favorites": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -1167,32 +1251,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "listings"
+                    "favorites"
                 ],
-                "summary": "Обновить объявление",
+                "summary": "Добавить объявление в избранное",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Listing ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Данные объявления",
-                        "name": "listing",
+                        "description": "Данные избранного; без wishlist_id объявление попадает в список по умолчанию",
+                        "name": "favorite",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.ListingUpdate"
+                            "$ref": "#/definitions/handler.FavoriteCreate"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.Listing"
+                            "$ref": "#/definitions/model.Favorite"
                         }
                     },
                     "400": {
@@ -1201,10 +1278,10 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorNotFound"
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
                     },
                     "500": {
@@ -1214,19 +1291,21 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/favorites/{id}": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "listings"
+                    "favorites"
                 ],
-                "summary": "Удалить объявление",
+                "summary": "Получить избранное по ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Listing ID",
+                        "description": "Favorite ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1236,7 +1315,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.StatusOK"
+                            "$ref": "#/definitions/model.Favorite"
                         }
                     },
                     "400": {
@@ -1258,22 +1337,20 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/listings/{listing_id}/amenities": {
-            "get": {
+            },
+            "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "amenities"
+                    "favorites"
                 ],
-                "summary": "Получить удобства объявления",
+                "summary": "Удалить избранное по ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Listing ID",
-                        "name": "listing_id",
+                        "description": "Favorite ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1282,10 +1359,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Amenity"
-                            }
+                            "$ref": "#/definitions/handler.StatusOK"
                         }
                     },
                     "400": {
@@ -1294,6 +1368,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1303,39 +1383,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/listings/{listing_id}/amenities/{amenity_id}": {
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/functions/guests/{guest_id}/total-spent": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "amenities"
+                    "functions"
                 ],
-                "summary": "Добавить удобство к объявлению",
+                "summary": "Получить общую сумму потраченную гостем",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Listing ID",
-                        "name": "listing_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Amenity ID",
-                        "name": "amenity_id",
+                        "description": "Guest ID",
+                        "name": "guest_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.ListingAmenity"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "number",
+                                "format": "float64"
+                            }
                         }
                     },
                     "400": {
@@ -1344,12 +1418,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorNotFound"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1357,27 +1425,22 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/functions/hosts/{host_id}/average-rating": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "amenities"
+                    "functions"
                 ],
-                "summary": "Удалить удобство из объявления",
+                "summary": "Получить средний рейтинг хоста",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Listing ID",
-                        "name": "listing_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Amenity ID",
-                        "name": "amenity_id",
+                        "description": "Host ID",
+                        "name": "host_id",
                         "in": "path",
                         "required": true
                     }
@@ -1386,7 +1449,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.StatusOK"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "number",
+                                "format": "float64"
+                            }
                         }
                     },
                     "400": {
@@ -1395,12 +1462,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorNotFound"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1410,20 +1471,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/listings/{listing_id}/images": {
+        "/api/functions/hosts/{host_id}/revenue": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "images"
+                    "functions"
                 ],
-                "summary": "Получить изображения по listing ID",
+                "summary": "Получить общую выручку хоста",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Listing ID",
-                        "name": "listing_id",
+                        "description": "Host ID",
+                        "name": "host_id",
                         "in": "path",
                         "required": true
                     }
@@ -1432,9 +1493,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Image"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "number",
+                                "format": "float64"
                             }
                         }
                     },
@@ -1453,34 +1515,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/payments": {
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/functions/listings/{listing_id}/active-bookings": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
+                    "functions"
                 ],
-                "summary": "Создать платеж",
+                "summary": "Получить количество активных бронирований объявления",
                 "parameters": [
                     {
-                        "description": "Данные платежа",
-                        "name": "payment",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.PaymentCreate"
-                        }
+                        "type": "integer",
+                        "description": "Listing ID",
+                        "name": "listing_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Payment"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
                         }
                     },
                     "400": {
@@ -1498,29 +1558,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/payments/{id}": {
-            "get": {
+        "/api/guest-reviews": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
+                    "guest-reviews"
                 ],
-                "summary": "Получить платеж по ID",
+                "summary": "Создать отзыв хоста о госте",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Payment ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Данные отзыва",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.GuestReviewCreate"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.Payment"
+                            "$ref": "#/definitions/model.GuestReview"
                         }
                     },
                     "400": {
@@ -1529,6 +1594,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -1542,41 +1613,31 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/api/guest-reviews/{id}": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
+                    "guest-reviews"
                 ],
-                "summary": "Обновить платеж",
+                "summary": "Получить отзыв хоста о госте по ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Payment ID",
+                        "description": "Guest review ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Данные платежа",
-                        "name": "payment",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.PaymentUpdate"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Payment"
+                            "$ref": "#/definitions/model.GuestReview"
                         }
                     },
                     "400": {
@@ -1604,13 +1665,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "payments"
+                    "guest-reviews"
                 ],
-                "summary": "Удалить платеж",
+                "summary": "Удалить отзыв хоста о госте",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Payment ID",
+                        "description": "Guest review ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1644,19 +1705,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/procedures/bookings/{id}/cancel-with-refund": {
-            "post": {
+        "/api/hosts/{id}/dashboard": {
+            "get": {
+                "description": "Выручка (всего и за 30 дней), средний рейтинг, ожидающие платежи, загрузка за 30 дней,\nзаезды и выезды на ближайшие 7 дней и показатели по каждому объявлению",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "procedures"
+                    "hosts"
                 ],
-                "summary": "Отменить бронирование с возвратом через процедуру",
+                "summary": "Получить кабинет хоста",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Booking ID",
+                        "description": "Host ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1666,7 +1728,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.StatusOK"
+                            "$ref": "#/definitions/model.HostDashboard"
                         }
                     },
                     "400": {
@@ -1690,7 +1752,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/procedures/create-booking-with-payment": {
+        "/api/images": {
             "post": {
                 "consumes": [
                     "application/json"
@@ -1699,17 +1761,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "procedures"
+                    "images"
                 ],
-                "summary": "Создать бронирование с платежом через процедуру",
+                "summary": "Создать изображение",
                 "parameters": [
                     {
-                        "description": "Данные бронирования",
-                        "name": "request",
+                        "description": "Данные изображения",
+                        "name": "image",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.BookingWithPaymentCreate"
+                            "$ref": "#/definitions/handler.ImageCreate"
                         }
                     }
                 ],
@@ -1717,7 +1779,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.CreateBookingWithPaymentResult"
+                            "$ref": "#/definitions/model.Image"
                         }
                     },
                     "400": {
@@ -1735,41 +1797,29 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/procedures/payments/{id}/confirm": {
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/images/{id}": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "procedures"
+                    "images"
                 ],
-                "summary": "Подтвердить платеж через процедуру",
+                "summary": "Получить изображение по ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Payment ID",
+                        "description": "Image ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Данные подтверждения",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.PaymentConfirmRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.StatusOK"
+                            "$ref": "#/definitions/model.Image"
                         }
                     },
                     "400": {
@@ -1791,43 +1841,41 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/reports/bookings": {
-            "get": {
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "functions"
+                    "images"
                 ],
-                "summary": "Получить отчет по бронированиям",
+                "summary": "Обновить изображение",
                 "parameters": [
                     {
-                        "type": "string",
-                        "format": "date-time",
-                        "example": "2025-01-01T00:00:00Z",
-                        "description": "Start Date",
-                        "name": "start_date",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "Image ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "format": "date-time",
-                        "example": "2025-12-31T23:59:59Z",
-                        "description": "End Date",
-                        "name": "end_date",
-                        "in": "query"
+                        "description": "Данные изображения",
+                        "name": "image",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ImageUpdate"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.BookingReport"
-                            }
+                            "$ref": "#/definitions/model.Image"
                         }
                     },
                     "400": {
@@ -1836,6 +1884,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1843,53 +1897,41 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/reports/hosts-performance": {
-            "get": {
+            },
+            "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "functions"
+                    "images"
+                ],
+                "summary": "Удалить изображение",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Image ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Получить отчет о производительности хостов",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.HostPerformanceReport"
-                            }
+                            "$ref": "#/definitions/handler.StatusOK"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/handler.ErrorInternal"
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
-                    }
-                }
-            }
-        },
-        "/api/reports/listings-statistics": {
-            "get": {
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "functions"
-                ],
-                "summary": "Получить статистический отчет по объявлениям",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.ListingStatisticsReport"
-                            }
+                            "$ref": "#/definitions/handler.ErrorNotFound"
                         }
                     },
                     "500": {
@@ -1901,30 +1943,111 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/reports/payments-summary": {
+        "/api/listings": {
             "get": {
+                "description": "С lat и lng возвращает объявления в радиусе radius_km, ближайшие первыми",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "functions"
+                    "listings"
                 ],
-                "summary": "Получить сводный отчет по платежам",
+                "summary": "Получить объявления, в том числе рядом с точкой или в области карты",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "date-time",
-                        "example": "2025-01-01T00:00:00Z",
-                        "description": "Start Date",
-                        "name": "start_date",
+                        "description": "Полнотекстовый запрос по заголовку, описанию, адресу и отзывам",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Широта точки поиска",
+                        "name": "lat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Долгота точки поиска",
+                        "name": "lng",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Радиус поиска в км (по умолчанию 10, максимум 500)",
+                        "name": "radius_km",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Южная граница области карты",
+                        "name": "min_lat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Западная граница области карты (больше max_lng, если область пересекает 180-й меридиан)",
+                        "name": "min_lng",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Северная граница области карты",
+                        "name": "max_lat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Восточная граница области карты",
+                        "name": "max_lng",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Минимальная цена за ночь",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Максимальная цена за ночь",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только доступные",
+                        "name": "available",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "format": "date-time",
-                        "example": "2025-12-31T23:59:59Z",
-                        "description": "End Date",
-                        "name": "end_date",
+                        "description": "Город",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Регион",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Страна",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
                         "in": "query"
                     }
                 ],
@@ -1934,7 +2057,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.PaymentSummaryReport"
+                                "$ref": "#/definitions/model.Listing"
                             }
                         }
                     },
@@ -1951,9 +2074,7 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/reviews": {
+            },
             "post": {
                 "consumes": [
                     "application/json"
@@ -1962,17 +2083,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reviews"
+                    "listings"
                 ],
-                "summary": "Создать отзыв",
+                "summary": "Создать объявление",
                 "parameters": [
                     {
-                        "description": "Данные отзыва",
-                        "name": "review",
+                        "description": "Данные объявления",
+                        "name": "listing",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.ReviewCreate"
+                            "$ref": "#/definitions/handler.ListingCreate"
                         }
                     }
                 ],
@@ -1980,7 +2101,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/model.Review"
+                            "$ref": "#/definitions/model.Listing"
                         }
                     },
                     "400": {
@@ -1998,7 +2119,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/reviews/batch": {
+        "/api/listings/batch": {
             "post": {
                 "consumes": [
                     "application/json"
@@ -2007,26 +2128,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reviews"
+                    "listings"
                 ],
-                "summary": "Batch импорт отзывов",
+                "summary": "Batch импорт объявлений",
                 "parameters": [
                     {
-                        "description": "Массив отзывов",
-                        "name": "reviews",
+                        "description": "Массив объявлений",
+                        "name": "listings",
                         "in": "body",
                         "required": true,
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/handler.ReviewCreate"
+                                "$ref": "#/definitions/handler.ListingCreate"
                             }
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Количество созданных отзывов",
+                        "description": "Количество созданных объявлений",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -2049,29 +2170,142 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/reviews/{id}": {
+        "/api/listings/search": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reviews"
+                    "listings"
                 ],
-                "summary": "Получить отзыв по ID",
+                "summary": "Поиск объявлений с фасетами по удобствам",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Полнотекстовый запрос по заголовку, описанию, адресу и отзывам",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Минимальная цена за ночь",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Максимальная цена за ночь",
+                        "name": "max_price",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Review ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Минимум комнат",
+                        "name": "min_rooms",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Минимум кроватей",
+                        "name": "min_beds",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только доступные",
+                        "name": "available",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID удобств через запятую, нужны все",
+                        "name": "amenities",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Город",
+                        "name": "city",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Регион",
+                        "name": "region",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Страна",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Широта точки поиска",
+                        "name": "lat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Долгота точки поиска",
+                        "name": "lng",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Радиус поиска в км (по умолчанию 10, максимум 500)",
+                        "name": "radius_km",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Южная граница области карты",
+                        "name": "min_lat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Западная граница области карты (больше max_lng, если область пересекает 180-й меридиан)",
+                        "name": "min_lng",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Северная граница области карты",
+                        "name": "max_lat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Восточная граница области карты",
+                        "name": "max_lng",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Язык подписей (ru, en)",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Review"
+                            "$ref": "#/definitions/model.ListingsSearchResult"
                         }
                     },
                     "400": {
@@ -2080,12 +2314,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorNotFound"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2093,41 +2321,31 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/api/listings/{id}": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reviews"
+                    "listings"
                 ],
-                "summary": "Обновить отзыв",
+                "summary": "Получить объявление по ID",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Review ID",
+                        "description": "Listing ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Данные отзыва",
-                        "name": "review",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.ReviewUpdate"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Review"
+                            "$ref": "#/definitions/model.Listing"
                         }
                     },
                     "400": {
@@ -2150,28 +2368,40 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "reviews"
+                    "listings"
                 ],
-                "summary": "Удалить отзыв",
+                "summary": "Обновить объявление",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Review ID",
+                        "description": "Listing ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Данные объявления",
+                        "name": "listing",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ListingUpdate"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.StatusOK"
+                            "$ref": "#/definitions/model.Listing"
                         }
                     },
                     "400": {
@@ -2193,36 +2423,29 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/users": {
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
+            },
+            "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "listings"
                 ],
-                "summary": "Создать пользователя",
+                "summary": "Удалить объявление",
                 "parameters": [
                     {
-                        "description": "Данные пользователя",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/handler.UserCreate"
-                        }
+                        "type": "integer",
+                        "description": "Listing ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.UserReturn"
+                            "$ref": "#/definitions/handler.StatusOK"
                         }
                     },
                     "400": {
@@ -2231,6 +2454,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2240,40 +2469,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users/batch": {
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
+        "/api/listings/{id}/benchmark": {
+            "get": {
+                "description": "Похожие - с тем же числом комнат и кроватей, общими удобствами и в радиусе radius_km\n(или в том же городе, если нет координат). Если поблизости меньше 5 похожих, место не учитывается.\nВозвращает перцентили цены, рейтинга, числа бронирований и загрузки за 90 дней и рекомендуемый диапазон цены",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "listings"
                 ],
-                "summary": "Batch импорт пользователей",
+                "summary": "Сравнить объявление с похожими",
                 "parameters": [
                     {
-                        "description": "Массив пользователей",
-                        "name": "users",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/handler.UserCreate"
-                            }
-                        }
+                        "type": "integer",
+                        "description": "Listing ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Радиус поиска похожих в км (по умолчанию 25, максимум 500)",
+                        "name": "radius_km",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Количество созданных пользователей",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "integer"
-                            }
+                            "$ref": "#/definitions/model.ListingBenchmark"
                         }
                     },
                     "400": {
@@ -2282,6 +2507,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2291,29 +2522,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users/{id}": {
+        "/api/listings/{listing_id}/amenities": {
             "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "amenities"
                 ],
-                "summary": "Получить пользователя по ID",
+                "summary": "Получить удобства объявления",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Listing ID",
+                        "name": "listing_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Язык подписи (ru, en)",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.UserReturn"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Amenity"
+                            }
                         }
                     },
                     "400": {
@@ -2322,12 +2562,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorNotFound"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2344,32 +2578,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "amenities"
                 ],
-                "summary": "Обновить пользователя",
+                "summary": "Заменить набор удобств объявления",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Listing ID",
+                        "name": "listing_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Данные пользователя",
-                        "name": "user",
+                        "description": "Полный список ID удобств",
+                        "name": "amenities",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handler.UserUpdate"
+                            "$ref": "#/definitions/handler.ListingAmenitiesUpdate"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Язык подписи (ru, en)",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.UserReturn"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Amenity"
+                            }
                         }
                     },
                     "400": {
@@ -2391,29 +2634,41 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/listings/{listing_id}/amenities/{amenity_id}": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "amenities"
                 ],
-                "summary": "Удалить пользователя",
+                "summary": "Добавить удобство к объявлению",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Listing ID",
+                        "name": "listing_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Amenity ID",
+                        "name": "amenity_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/handler.StatusOK"
+                            "$ref": "#/definitions/model.ListingAmenity"
                         }
                     },
                     "400": {
@@ -2435,22 +2690,27 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/users/{user_id}/favorites": {
-            "get": {
+            },
+            "delete": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "favorites"
+                    "amenities"
                 ],
-                "summary": "Получить избранное пользователя",
+                "summary": "Удалить удобство из объявления",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
+                        "description": "Listing ID",
+                        "name": "listing_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Amenity ID",
+                        "name": "amenity_id",
                         "in": "path",
                         "required": true
                     }
@@ -2459,10 +2719,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Favorite"
-                            }
+                            "$ref": "#/definitions/handler.StatusOK"
                         }
                     },
                     "400": {
@@ -2471,6 +2728,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2480,23 +2743,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/users/{user_id}/favorites/{listing_id}": {
-            "delete": {
+        "/api/listings/{listing_id}/images": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "favorites"
+                    "images"
                 ],
-                "summary": "Удалить избранное по user_id и listing_id",
+                "summary": "Получить изображения по listing ID",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "type": "integer",
                         "description": "Listing ID",
@@ -2509,7 +2765,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handler.StatusOK"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Image"
+                            }
                         }
                     },
                     "400": {
@@ -2518,12 +2777,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.ErrorBadRequest"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/handler.ErrorNotFound"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2531,36 +2784,4116 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "handler.AmenityCreate": {
-            "type": "object",
-            "properties": {
-                "name": {
-                    "type": "string"
-                }
-            }
-        },
-        "handler.AmenityUpdate": {
-            "type": "object",
-            "properties": {
-                "name": {
-                    "type": "string"
-                }
+            },
+            "post": {
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "images"
+                ],
+                "summary": "Загрузить изображение объявления",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Listing ID",
+                        "name": "listing_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл изображения (jpeg, png, webp)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Основное изображение",
+                        "name": "is_primary",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Порядковый номер",
+                        "name": "order_index",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Image"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/listings/{listing_id}/images/order": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "images"
+                ],
+                "summary": "Изменить порядок изображений объявления",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Listing ID",
+                        "name": "listing_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Полный список ID изображений в новом порядке",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ImagesOrderUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Image"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/listings/{listing_id}/reviews": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Получить отзывы объявления",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Listing ID",
+                        "name": "listing_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "newest",
+                        "description": "Сортировка (newest, score)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReviewsPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/notifications/{id}/read": {
+            "put": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Отметить уведомление прочитанным",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.StatusOK"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/payments": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Создать платеж",
+                "parameters": [
+                    {
+                        "description": "Данные платежа",
+                        "name": "payment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PaymentCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/payments/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Получить платеж по ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Обновить платеж",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные платежа",
+                        "name": "payment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PaymentUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Payment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payments"
+                ],
+                "summary": "Удалить платеж",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.StatusOK"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/procedures/bookings/{id}/cancel-with-refund": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "procedures"
+                ],
+                "summary": "Отменить бронирование с возвратом через процедуру",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Booking ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.StatusOK"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/procedures/create-booking-with-payment": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "procedures"
+                ],
+                "summary": "Создать бронирование с платежом через процедуру",
+                "parameters": [
+                    {
+                        "description": "Данные бронирования",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.BookingWithPaymentCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateBookingWithPaymentResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/procedures/payments/{id}/confirm": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "procedures"
+                ],
+                "summary": "Подтвердить платеж через процедуру",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Payment ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные подтверждения",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.PaymentConfirmRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.StatusOK"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reconciliation/payments": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reconciliation"
+                ],
+                "summary": "Сверка бронирований и платежей",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaymentReconciliationReport"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reconciliation/payments/fix": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reconciliation"
+                ],
+                "summary": "Сверка бронирований и платежей с исправлением безопасных расхождений",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.PaymentReconciliationReport"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reports/bookings": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "functions"
+                ],
+                "summary": "Получить отчет по бронированиям",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start Date",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End Date",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Формат (json, csv, xlsx); также учитывается заголовок Accept",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.BookingReport"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reports/cities-statistics": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "functions"
+                ],
+                "summary": "Получить отчет по городам: бронирования, выручка и средняя оценка",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Страна",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Формат (json, csv, xlsx); также учитывается заголовок Accept",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.CityStatisticsReport"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reports/guest-cohorts": {
+            "get": {
+                "description": "Гости группируются по месяцу первого бронирования. Для каждого следующего месяца -\nдоля гостей когорты, забронировавших снова, и накопленные траты когорты.\nПри выгрузке в файл возвращаются плоские ячейки матрицы",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "functions"
+                ],
+                "summary": "Получить когортный отчет по возвращаемости гостей",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Первая когорта не раньше",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "Последняя когорта не позже",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Формат (json, csv, xlsx); также учитывается заголовок Accept",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GuestCohortReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reports/hosts-cancellations": {
+            "get": {
+                "description": "Отмены восстанавливаются из удалений броней в audit_log. Доля отмен считается от всех броней с заездом в периоде",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "functions"
+                ],
+                "summary": "Получить отчет по отменам бронирований для хостов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Заезд не раньше",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "Заезд не позже",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Формат (json, csv, xlsx); также учитывается заголовок Accept",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.HostCancellationReport"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reports/hosts-occupancy": {
+            "get": {
+                "description": "Без дат берутся последние 30 дней",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "functions"
+                ],
+                "summary": "Получить отчет по загрузке объявлений хостов: доля занятых ночей, ADR и RevPAR",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start Date",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End Date",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Формат (json, csv, xlsx); также учитывается заголовок Accept",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.HostOccupancyReport"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reports/hosts-performance": {
+            "get": {
+                "description": "Данные берутся из материализованного представления и актуальны на момент из заголовка X-Stale-As-Of",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "functions"
+                ],
+                "summary": "Получить отчет о производительности хостов",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Формат (json, csv, xlsx); также учитывается заголовок Accept",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.HostPerformanceReport"
+                            }
+                        },
+                        "headers": {
+                            "X-Stale-As-Of": {
+                                "type": "string",
+                                "description": "Время последнего обновления представления (RFC3339)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reports/listings-cancellations": {
+            "get": {
+                "description": "Отмены восстанавливаются из удалений броней в audit_log. Доля отмен считается от всех броней с заездом в периоде",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "functions"
+                ],
+                "summary": "Получить отчет по отменам бронирований для объявлений",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Заезд не раньше",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "Заезд не позже",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Формат (json, csv, xlsx); также учитывается заголовок Accept",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ListingCancellationReport"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reports/listings-occupancy": {
+            "get": {
+                "description": "Брони, пересекающие границы периода, учитываются ночами внутри периода. Без дат берутся последние 30 дней",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "functions"
+                ],
+                "summary": "Получить отчет по загрузке объявлений: доля занятых ночей, ADR и RevPAR",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start Date",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End Date",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Формат (json, csv, xlsx); также учитывается заголовок Accept",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ListingOccupancyReport"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reports/listings-statistics": {
+            "get": {
+                "description": "Данные берутся из материализованного представления и актуальны на момент из заголовка X-Stale-As-Of",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "functions"
+                ],
+                "summary": "Получить статистический отчет по объявлениям",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Формат (json, csv, xlsx); также учитывается заголовок Accept",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.ListingStatisticsReport"
+                            }
+                        },
+                        "headers": {
+                            "X-Stale-As-Of": {
+                                "type": "string",
+                                "description": "Время последнего обновления представления (RFC3339)"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reports/payment-failures": {
+            "get": {
+                "description": "Доля ошибок считается от платежей в статусах completed и failed",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "functions"
+                ],
+                "summary": "Получить отчет по неуспешным платежам в разрезе способа оплаты",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start Date",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End Date",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Формат (json, csv, xlsx); также учитывается заголовок Accept",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.PaymentFailureReport"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reports/payments-summary": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "functions"
+                ],
+                "summary": "Получить сводный отчет по платежам",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Start Date",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "End Date",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Формат (json, csv, xlsx); также учитывается заголовок Accept",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.PaymentSummaryReport"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reports/refresh": {
+            "post": {
+                "description": "Повторное обновление раньше чем через минуту после предыдущего или во время идущего отклоняется",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "functions"
+                ],
+                "summary": "Обновить материализованные представления отчетов",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.StatusOK"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reports/timeseries": {
+            "get": {
+                "description": "Пустые интервалы заполняются нулями. revenue и refunds считаются по дате платежа, bookings - по дате заезда",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "functions"
+                ],
+                "summary": "Получить временной ряд по выручке, возвратам или бронированиям",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "revenue",
+                        "description": "Метрика (revenue, refunds, bookings)",
+                        "name": "metric",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "day",
+                        "description": "Интервал (day, week, month)",
+                        "name": "granularity",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-01-01T00:00:00Z",
+                        "description": "Начало периода",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "example": "2025-12-31T23:59:59Z",
+                        "description": "Конец периода",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Host ID",
+                        "name": "host_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Listing ID",
+                        "name": "listing_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Формат (json, csv, xlsx); также учитывается заголовок Accept",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.TimeseriesReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reviews": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Создать отзыв",
+                "parameters": [
+                    {
+                        "description": "Данные отзыва",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ReviewCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reviews/batch": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Batch импорт отзывов",
+                "parameters": [
+                    {
+                        "description": "Массив отзывов",
+                        "name": "reviews",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handler.ReviewCreate"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Количество созданных отзывов",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reviews/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Получить отзыв по ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Обновить отзыв",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные отзыва",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ReviewUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Удалить отзыв",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.StatusOK"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/reviews/{id}/response": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Ответ хоста на отзыв",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ответ хоста",
+                        "name": "response",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.ReviewHostResponseCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/shared/wishlists/{token}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlists"
+                ],
+                "summary": "Открыть список избранного по ссылке (только чтение)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Wishlist"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Создать пользователя",
+                "parameters": [
+                    {
+                        "description": "Данные пользователя",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UserCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/handler.UserReturn"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/batch": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Batch импорт пользователей",
+                "parameters": [
+                    {
+                        "description": "Массив пользователей",
+                        "name": "users",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handler.UserCreate"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Количество созданных пользователей",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Получить пользователя по ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.UserReturn"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Обновить пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные пользователя",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.UserUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.UserReturn"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Удалить пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.StatusOK"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}/trips": {
+            "get": {
+                "description": "Предстоящие, текущие и прошлые бронирования с карточками объявлений и статусом оплаты,\nобщая сумма трат и завершенные поездки, на которые еще можно оставить отзыв",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Получить поездки гостя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GuestTrips"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{user_id}/conversations": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "conversations"
+                ],
+                "summary": "Получить переписки пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Conversation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{user_id}/favorites": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Получить избранное пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Добавить карточки объявлений (адрес, цена, рейтинг, фото)",
+                        "name": "cards",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Favorite"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{user_id}/favorites/{listing_id}": {
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "favorites"
+                ],
+                "summary": "Удалить избранное по user_id и listing_id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Listing ID",
+                        "name": "listing_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.StatusOK"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{user_id}/notifications": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notifications"
+                ],
+                "summary": "Получить уведомления пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Только непрочитанные",
+                        "name": "unread",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 20, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Notification"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{user_id}/reputation": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "guest-reviews"
+                ],
+                "summary": "Получить репутацию гостя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Guest ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.GuestReputation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{user_id}/reviews": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reviews"
+                ],
+                "summary": "Получить отзывы пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "newest",
+                        "description": "Сортировка (newest, score)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.ReviewsPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{user_id}/wishlists": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlists"
+                ],
+                "summary": "Получить списки избранного пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Wishlist"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlists"
+                ],
+                "summary": "Создать список избранного",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные списка",
+                        "name": "wishlist",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.WishlistCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Wishlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/wishlists/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlists"
+                ],
+                "summary": "Получить список избранного с карточками объявлений",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Wishlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Wishlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlists"
+                ],
+                "summary": "Переименовать список избранного",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Wishlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные списка",
+                        "name": "wishlist",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.WishlistUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Wishlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlists"
+                ],
+                "summary": "Удалить список избранного",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Wishlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.StatusOK"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/wishlists/{id}/items": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlists"
+                ],
+                "summary": "Добавить объявление в список избранного",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Wishlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Объявление и заметка",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.WishlistItemCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/model.Favorite"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/wishlists/{id}/items/{listing_id}": {
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlists"
+                ],
+                "summary": "Изменить заметку к объявлению в списке",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Wishlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Listing ID",
+                        "name": "listing_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Заметка",
+                        "name": "item",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handler.WishlistItemUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Favorite"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlists"
+                ],
+                "summary": "Убрать объявление из списка избранного",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Wishlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Listing ID",
+                        "name": "listing_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.StatusOK"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/wishlists/{id}/share": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlists"
+                ],
+                "summary": "Открыть доступ к списку по ссылке",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Wishlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Wishlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "wishlists"
+                ],
+                "summary": "Закрыть доступ к списку по ссылке",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Wishlist ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handler.StatusOK"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorBadRequest"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorNotFound"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.ErrorInternal"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handler.AmenityCreate": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "kitchen"
+                },
+                "icon_key": {
+                    "type": "string",
+                    "example": "microwave"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.AmenityUpdate": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string",
+                    "example": "kitchen"
+                },
+                "icon_key": {
+                    "type": "string",
+                    "example": "microwave"
+                },
+                "labels": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.BookingCreate": {
+            "type": "object",
+            "properties": {
+                "guest_id": {
+                    "type": "integer"
+                },
+                "in_date": {
+                    "type": "string",
+                    "example": "2025-12-12T14:00:00+03:00"
+                },
+                "listing_id": {
+                    "type": "integer"
+                },
+                "out_date": {
+                    "type": "string",
+                    "example": "2025-12-15T14:00:00+03:00"
+                }
+            }
+        },
+        "handler.BookingUpdate": {
+            "type": "object",
+            "properties": {
+                "in_date": {
+                    "type": "string",
+                    "example": "2025-12-12T14:00:00+03:00"
+                },
+                "is_paid": {
+                    "type": "boolean"
+                },
+                "out_date": {
+                    "type": "string",
+                    "example": "2025-12-16T14:00:00+03:00"
+                }
+            }
+        },
+        "handler.BookingWithPaymentCreate": {
+            "type": "object",
+            "properties": {
+                "guest_id": {
+                    "type": "integer"
+                },
+                "in_date": {
+                    "type": "string",
+                    "example": "2025-12-12T14:00:00+03:00"
+                },
+                "listing_id": {
+                    "type": "integer"
+                },
+                "out_date": {
+                    "type": "string",
+                    "example": "2025-12-15T14:00:00+03:00"
+                },
+                "payment_method": {
+                    "type": "string",
+                    "example": "card"
+                }
+            }
+        },
+        "handler.ConversationCreate": {
+            "type": "object",
+            "properties": {
+                "booking_id": {
+                    "type": "integer"
+                },
+                "guest_id": {
+                    "type": "integer"
+                },
+                "listing_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.ConversationReadResult": {
+            "type": "object",
+            "properties": {
+                "marked_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.ConversationReadUpdate": {
+            "type": "object",
+            "properties": {
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.ErrorBadRequest": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "invalid request body"
+                }
+            }
+        },
+        "handler.ErrorForbidden": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "user is not a participant of the conversation"
+                }
+            }
+        },
+        "handler.ErrorInternal": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "some internal error"
+                }
+            }
+        },
+        "handler.ErrorNotFound": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "not found"
+                }
+            }
+        },
+        "handler.FavoriteCreate": {
+            "type": "object",
+            "properties": {
+                "listing_id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "wishlist_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.GuestReviewCreate": {
+            "type": "object",
+            "properties": {
+                "booking_id": {
+                    "type": "integer"
+                },
+                "host_id": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ImageCreate": {
+            "type": "object",
+            "properties": {
+                "image_url": {
+                    "type": "string"
+                },
+                "is_primary": {
+                    "type": "boolean"
+                },
+                "listing_id": {
+                    "type": "integer"
+                },
+                "order_index": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.ImageUpdate": {
+            "type": "object",
+            "properties": {
+                "image_url": {
+                    "type": "string"
+                },
+                "is_primary": {
+                    "type": "boolean"
+                },
+                "order_index": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.ImagesOrderUpdate": {
+            "type": "object",
+            "properties": {
+                "image_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        3,
+                        1,
+                        2
+                    ]
+                }
+            }
+        },
+        "handler.ListingAmenitiesUpdate": {
+            "type": "object",
+            "properties": {
+                "amenity_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    },
+                    "example": [
+                        1,
+                        2,
+                        5
+                    ]
+                }
+            }
+        },
+        "handler.ListingCreate": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "beds_number": {
+                    "type": "integer"
+                },
+                "city": {
+                    "type": "string",
+                    "example": "Moscow"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "Russia"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "host_id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number",
+                    "example": 55.7558
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 37.6173
+                },
+                "postal_code": {
+                    "type": "string",
+                    "example": "125009"
+                },
+                "price_per_night": {
+                    "type": "number"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "rooms_number": {
+                    "type": "integer"
+                },
+                "street": {
+                    "type": "string",
+                    "example": "Tverskaya St 7"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Уютная студия у парка"
+                }
+            }
+        },
+        "handler.ListingUpdate": {
+            "type": "object",
+            "properties": {
+                "beds_number": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "is_available": {
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number",
+                    "example": 55.7558
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 37.6173
+                },
+                "price_per_night": {
+                    "type": "number"
+                },
+                "rooms_number": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Уютная студия у парка"
+                }
+            }
+        },
+        "handler.MessageCreate": {
+            "type": "object",
+            "properties": {
+                "sender_id": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Здравствуйте! Можно заехать пораньше?"
+                }
+            }
+        },
+        "handler.PaymentConfirmRequest": {
+            "type": "object",
+            "properties": {
+                "transaction_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.PaymentCreate": {
+            "type": "object",
+            "properties": {
+                "booking_id": {
+                    "type": "integer"
+                },
+                "payment_method": {
+                    "type": "string",
+                    "example": "card"
+                },
+                "payment_status": {
+                    "type": "string",
+                    "example": "completed"
+                }
+            }
+        },
+        "handler.PaymentUpdate": {
+            "type": "object",
+            "properties": {
+                "payment_method": {
+                    "type": "string"
+                },
+                "payment_status": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ReviewCreate": {
+            "type": "object",
+            "properties": {
+                "accuracy_score": {
+                    "type": "integer"
+                },
+                "booking_id": {
+                    "type": "integer"
+                },
+                "cleanliness_score": {
+                    "type": "integer"
+                },
+                "location_score": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "value_score": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.ReviewHostResponseCreate": {
+            "type": "object",
+            "properties": {
+                "host_id": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.ReviewModerationUpdate": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "published"
+                }
+            }
+        },
+        "handler.ReviewUpdate": {
+            "type": "object",
+            "properties": {
+                "accuracy_score": {
+                    "type": "integer"
+                },
+                "cleanliness_score": {
+                    "type": "integer"
+                },
+                "location_score": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "value_score": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handler.StatusOK": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "deleted successfully"
+                }
+            }
+        },
+        "handler.UserCreate": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "second_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.UserReturn": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "second_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.UserUpdate": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "second_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.WishlistCreate": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Летняя поездка"
+                }
+            }
+        },
+        "handler.WishlistItemCreate": {
+            "type": "object",
+            "properties": {
+                "listing_id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.WishlistItemUpdate": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "handler.WishlistUpdate": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Летняя поездка"
+                }
+            }
+        },
+        "model.Amenity": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "icon_key": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "labels": {
+                    "$ref": "#/definitions/model.Labels"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AmenityCategory": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "labels": {
+                    "$ref": "#/definitions/model.Labels"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sort_order": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AmenityFacet": {
+            "type": "object",
+            "properties": {
+                "amenity_id": {
+                    "type": "integer"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "icon_key": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "listings_count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BenchmarkPercentiles": {
+            "type": "object",
+            "properties": {
+                "average_rating": {
+                    "type": "number"
+                },
+                "bookings_count": {
+                    "type": "number"
+                },
+                "occupancy_rate": {
+                    "type": "number"
+                },
+                "price_per_night": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.Booking": {
+            "type": "object",
+            "properties": {
+                "guest_id": {
+                    "type": "integer"
+                },
+                "host_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "in_date": {
+                    "type": "string"
+                },
+                "is_paid": {
+                    "type": "boolean"
+                },
+                "listing_id": {
+                    "type": "integer"
+                },
+                "out_date": {
+                    "type": "string"
+                },
+                "total_price": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.BookingPaymentAnalytics": {
+            "type": "object",
+            "properties": {
+                "booking_id": {
+                    "type": "integer"
+                },
+                "booking_status": {
+                    "type": "string"
+                },
+                "duration_days": {
+                    "type": "integer"
+                },
+                "guest_id": {
+                    "type": "integer"
+                },
+                "guest_name": {
+                    "type": "string"
+                },
+                "host_id": {
+                    "type": "integer"
+                },
+                "host_name": {
+                    "type": "string"
+                },
+                "in_date": {
+                    "type": "string"
+                },
+                "is_paid": {
+                    "type": "boolean"
+                },
+                "listing_address": {
+                    "type": "string"
+                },
+                "listing_id": {
+                    "type": "integer"
+                },
+                "out_date": {
+                    "type": "string"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "payment_amount": {
+                    "type": "number"
+                },
+                "payment_id": {
+                    "type": "integer"
+                },
+                "payment_method": {
+                    "type": "string"
+                },
+                "payment_status": {
+                    "type": "string"
+                },
+                "price_per_night": {
+                    "type": "number"
+                },
+                "review_id": {
+                    "type": "integer"
+                },
+                "review_score": {
+                    "type": "integer"
+                },
+                "review_text": {
+                    "type": "string"
+                },
+                "total_price": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.BookingReport": {
+            "type": "object",
+            "properties": {
+                "booking_id": {
+                    "type": "integer"
+                },
+                "duration_days": {
+                    "type": "integer"
+                },
+                "guest_id": {
+                    "type": "integer"
+                },
+                "guest_name": {
+                    "type": "string"
+                },
+                "host_id": {
+                    "type": "integer"
+                },
+                "host_name": {
+                    "type": "string"
+                },
+                "in_date": {
+                    "type": "string",
+                    "example": "2025-12-12T14:00:00+03:00"
+                },
+                "is_paid": {
+                    "type": "boolean"
+                },
+                "listing_address": {
+                    "type": "string"
+                },
+                "listing_id": {
+                    "type": "integer"
+                },
+                "out_date": {
+                    "type": "string",
+                    "example": "2025-12-15T14:00:00+03:00"
+                },
+                "payment_amount": {
+                    "type": "number"
+                },
+                "payment_status": {
+                    "type": "string"
+                },
+                "review_score": {
+                    "type": "integer"
+                },
+                "total_price": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.BookingsAnalyticsPage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.BookingPaymentAnalytics"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CityStatisticsReport": {
+            "type": "object",
+            "properties": {
+                "average_rating": {
+                    "type": "number"
+                },
+                "bookings_count": {
+                    "type": "integer"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "listings_count": {
+                    "type": "integer"
+                },
+                "reviews_count": {
+                    "type": "integer"
+                },
+                "total_revenue": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.Conversation": {
+            "type": "object",
+            "properties": {
+                "booking_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "guest_id": {
+                    "type": "integer"
+                },
+                "host_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_message_at": {
+                    "type": "string"
+                },
+                "last_message_sender_id": {
+                    "type": "integer"
+                },
+                "last_message_text": {
+                    "type": "string"
+                },
+                "listing_id": {
+                    "type": "integer"
+                },
+                "unread_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.CreateBookingWithPaymentResult": {
+            "type": "object",
+            "properties": {
+                "booking_id": {
+                    "type": "integer"
+                },
+                "payment_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Favorite": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "listing": {
+                    "$ref": "#/definitions/model.ListingCard"
+                },
+                "listing_id": {
+                    "type": "integer"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "wishlist_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.GuestCohort": {
+            "type": "object",
+            "properties": {
+                "active_guests": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "cohort_month": {
+                    "type": "string",
+                    "example": "2025-01"
+                },
+                "cohort_size": {
+                    "type": "integer"
+                },
+                "cumulative_spend": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "retention_rates": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "model.GuestCohortReport": {
+            "type": "object",
+            "properties": {
+                "cohorts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.GuestCohort"
+                    }
+                },
+                "month_offsets": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "model.GuestReputation": {
+            "type": "object",
+            "properties": {
+                "average_rating": {
+                    "type": "number"
+                },
+                "guest_id": {
+                    "type": "integer"
+                },
+                "reviews_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.GuestReview": {
+            "type": "object",
+            "properties": {
+                "booking_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "guest_id": {
+                    "type": "integer"
+                },
+                "host_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "revealed": {
+                    "type": "boolean"
+                },
+                "score": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.GuestTrips": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Trip"
+                    }
+                },
+                "guest_id": {
+                    "type": "integer"
+                },
+                "past": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Trip"
+                    }
+                },
+                "pending_reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Trip"
+                    }
+                },
+                "total_spent": {
+                    "type": "number"
+                },
+                "upcoming": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Trip"
+                    }
+                }
+            }
+        },
+        "model.HostAnalytics": {
+            "type": "object",
+            "properties": {
+                "active_bookings": {
+                    "type": "integer"
+                },
+                "average_rating": {
+                    "type": "number"
+                },
+                "avg_booking_revenue": {
+                    "type": "number"
+                },
+                "completed_payments_count": {
+                    "type": "integer"
+                },
+                "failed_payments_count": {
+                    "type": "integer"
+                },
+                "host_email": {
+                    "type": "string"
+                },
+                "host_id": {
+                    "type": "integer"
+                },
+                "host_name": {
+                    "type": "string"
+                },
+                "pending_payments_count": {
+                    "type": "integer"
+                },
+                "total_bookings": {
+                    "type": "integer"
+                },
+                "total_listings": {
+                    "type": "integer"
+                },
+                "total_revenue": {
+                    "type": "number"
+                },
+                "total_reviews": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.HostCancellationReport": {
+            "type": "object",
+            "properties": {
+                "avg_lead_time_days": {
+                    "type": "number"
+                },
+                "bookings_count": {
+                    "type": "integer"
+                },
+                "cancellation_rate": {
+                    "type": "number"
+                },
+                "cancellations_count": {
+                    "type": "integer"
+                },
+                "host_id": {
+                    "type": "integer"
+                },
+                "host_name": {
+                    "type": "string"
+                },
+                "refunded_amount": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.HostDashboard": {
+            "type": "object",
+            "properties": {
+                "available_nights": {
+                    "type": "integer"
+                },
+                "average_rating": {
+                    "type": "number"
+                },
+                "booked_nights": {
+                    "type": "integer"
+                },
+                "host_id": {
+                    "type": "integer"
+                },
+                "listings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.HostDashboardListing"
+                    }
+                },
+                "occupancy_rate": {
+                    "type": "number"
+                },
+                "pending_payments_amount": {
+                    "type": "number"
+                },
+                "pending_payments_count": {
+                    "type": "integer"
+                },
+                "period_days": {
+                    "type": "integer"
+                },
+                "period_revenue": {
+                    "type": "number"
+                },
+                "total_revenue": {
+                    "type": "number"
+                },
+                "upcoming_check_ins": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.HostDashboardMovement"
+                    }
+                },
+                "upcoming_check_outs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.HostDashboardMovement"
+                    }
+                }
+            }
+        },
+        "model.HostDashboardListing": {
+            "type": "object",
+            "properties": {
+                "active_bookings": {
+                    "type": "integer"
+                },
+                "address": {
+                    "type": "string"
+                },
+                "available_nights": {
+                    "type": "integer"
+                },
+                "average_rating": {
+                    "type": "number"
+                },
+                "booked_nights": {
+                    "type": "integer"
+                },
+                "is_available": {
+                    "type": "boolean"
+                },
+                "listing_id": {
+                    "type": "integer"
+                },
+                "occupancy_rate": {
+                    "type": "number"
+                },
+                "period_revenue": {
+                    "type": "number"
+                },
+                "price_per_night": {
+                    "type": "number"
+                },
+                "reviews_count": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "total_revenue": {
+                    "type": "number"
+                }
             }
         },
-        "handler.BookingCreate": {
+        "model.HostDashboardMovement": {
             "type": "object",
             "properties": {
+                "booking_id": {
+                    "type": "integer"
+                },
                 "guest_id": {
                     "type": "integer"
                 },
+                "guest_name": {
+                    "type": "string"
+                },
                 "in_date": {
                     "type": "string",
                     "example": "2025-12-12T14:00:00+03:00"
                 },
+                "listing_address": {
+                    "type": "string"
+                },
                 "listing_id": {
                     "type": "integer"
                 },
@@ -2570,204 +6903,573 @@ const docTemplate = `{
                 }
             }
         },
-        "handler.BookingUpdate": {
+        "model.HostOccupancyReport": {
             "type": "object",
             "properties": {
-                "in_date": {
-                    "type": "string",
-                    "example": "2025-12-12T14:00:00+03:00"
+                "adr": {
+                    "type": "number"
                 },
-                "is_paid": {
-                    "type": "boolean"
+                "available_nights": {
+                    "type": "integer"
                 },
-                "out_date": {
-                    "type": "string",
-                    "example": "2025-12-16T14:00:00+03:00"
+                "booked_nights": {
+                    "type": "integer"
+                },
+                "host_id": {
+                    "type": "integer"
+                },
+                "host_name": {
+                    "type": "string"
+                },
+                "listings_count": {
+                    "type": "integer"
+                },
+                "occupancy_rate": {
+                    "type": "number"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "revpar": {
+                    "type": "number"
                 }
             }
         },
-        "handler.BookingWithPaymentCreate": {
+        "model.HostPerformanceReport": {
             "type": "object",
             "properties": {
-                "guest_id": {
+                "average_rating": {
+                    "type": "number"
+                },
+                "avg_accuracy": {
+                    "type": "number"
+                },
+                "avg_cleanliness": {
+                    "type": "number"
+                },
+                "avg_location": {
+                    "type": "number"
+                },
+                "avg_value": {
+                    "type": "number"
+                },
+                "completed_payments_count": {
                     "type": "integer"
                 },
-                "in_date": {
-                    "type": "string",
-                    "example": "2025-12-12T14:00:00+03:00"
+                "host_email": {
+                    "type": "string"
+                },
+                "host_id": {
+                    "type": "integer"
+                },
+                "host_name": {
+                    "type": "string"
+                },
+                "listings_count": {
+                    "type": "integer"
+                },
+                "total_bookings": {
+                    "type": "integer"
+                },
+                "total_revenue": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.HostsAnalyticsPage": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.HostAnalytics"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Image": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "image_id": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "is_primary": {
+                    "type": "boolean"
                 },
                 "listing_id": {
                     "type": "integer"
                 },
-                "out_date": {
-                    "type": "string",
-                    "example": "2025-12-15T14:00:00+03:00"
+                "order_index": {
+                    "type": "integer"
                 },
-                "payment_method": {
-                    "type": "string",
-                    "example": "card"
+                "processed_at": {
+                    "type": "string"
+                },
+                "processing_status": {
+                    "type": "string"
+                },
+                "size_bytes": {
+                    "type": "integer"
+                },
+                "uploaded_at": {
+                    "type": "string"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ImageVariant"
+                    }
                 }
             }
         },
-        "handler.ErrorBadRequest": {
+        "model.ImageVariant": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "invalid request body"
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "height": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "size_bytes": {
+                    "type": "integer"
+                },
+                "variant": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.ErrorInternal": {
+        "model.Labels": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "string"
+            }
+        },
+        "model.Listing": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "some internal error"
+                "address": {
+                    "type": "string"
+                },
+                "beds_number": {
+                    "type": "integer"
+                },
+                "city": {
+                    "type": "string"
+                },
+                "country": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "distance_km": {
+                    "type": "number"
+                },
+                "host_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_available": {
+                    "type": "boolean"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "postal_code": {
+                    "type": "string"
+                },
+                "price_per_night": {
+                    "type": "number"
+                },
+                "region": {
+                    "type": "string"
+                },
+                "rooms_number": {
+                    "type": "integer"
+                },
+                "search_rank": {
+                    "type": "number"
+                },
+                "snippet": {
+                    "type": "string"
+                },
+                "street": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
                 }
             }
         },
-        "handler.ErrorNotFound": {
+        "model.ListingAmenity": {
             "type": "object",
             "properties": {
-                "error": {
-                    "type": "string",
-                    "example": "not found"
+                "amenity_id": {
+                    "type": "integer"
+                },
+                "listing_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ListingBenchmark": {
+            "type": "object",
+            "properties": {
+                "comparables": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ListingComparable"
+                    }
+                },
+                "comparables_count": {
+                    "type": "integer"
+                },
+                "listing": {
+                    "$ref": "#/definitions/model.ListingComparable"
+                },
+                "listing_id": {
+                    "type": "integer"
+                },
+                "location_filtered": {
+                    "type": "boolean"
+                },
+                "percentiles": {
+                    "$ref": "#/definitions/model.BenchmarkPercentiles"
+                },
+                "period_days": {
+                    "type": "integer"
+                },
+                "radius_km": {
+                    "type": "number"
+                },
+                "suggested_price": {
+                    "$ref": "#/definitions/model.PriceRange"
+                }
+            }
+        },
+        "model.ListingCancellationReport": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "avg_lead_time_days": {
+                    "type": "number"
+                },
+                "bookings_count": {
+                    "type": "integer"
+                },
+                "cancellation_rate": {
+                    "type": "number"
+                },
+                "cancellations_count": {
+                    "type": "integer"
+                },
+                "host_id": {
+                    "type": "integer"
+                },
+                "listing_id": {
+                    "type": "integer"
+                },
+                "refunded_amount": {
+                    "type": "number"
                 }
             }
         },
-        "handler.FavoriteCreate": {
+        "model.ListingCard": {
             "type": "object",
             "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "average_rating": {
+                    "type": "number"
+                },
+                "is_available": {
+                    "type": "boolean"
+                },
                 "listing_id": {
                     "type": "integer"
                 },
-                "user_id": {
+                "price_per_night": {
+                    "type": "number"
+                },
+                "primary_image_url": {
+                    "type": "string"
+                },
+                "reviews_count": {
                     "type": "integer"
                 }
             }
         },
-        "handler.ImageCreate": {
+        "model.ListingComparable": {
             "type": "object",
             "properties": {
-                "image_url": {
-                    "type": "string"
+                "amenity_similarity": {
+                    "type": "number"
                 },
-                "is_primary": {
-                    "type": "boolean"
+                "available_nights": {
+                    "type": "integer"
+                },
+                "average_rating": {
+                    "type": "number"
+                },
+                "booked_nights": {
+                    "type": "integer"
+                },
+                "bookings_count": {
+                    "type": "integer"
+                },
+                "distance_km": {
+                    "type": "number"
                 },
                 "listing_id": {
                     "type": "integer"
                 },
-                "order_index": {
+                "occupancy_rate": {
+                    "type": "number"
+                },
+                "price_per_night": {
+                    "type": "number"
+                },
+                "reviews_count": {
+                    "type": "integer"
+                },
+                "shared_amenities": {
                     "type": "integer"
                 }
             }
         },
-        "handler.ImageUpdate": {
+        "model.ListingOccupancyReport": {
             "type": "object",
             "properties": {
-                "image_url": {
+                "address": {
                     "type": "string"
                 },
-                "is_primary": {
-                    "type": "boolean"
+                "adr": {
+                    "type": "number"
                 },
-                "order_index": {
+                "available_nights": {
+                    "type": "integer"
+                },
+                "booked_nights": {
+                    "type": "integer"
+                },
+                "host_id": {
+                    "type": "integer"
+                },
+                "host_name": {
+                    "type": "string"
+                },
+                "listing_id": {
                     "type": "integer"
+                },
+                "occupancy_rate": {
+                    "type": "number"
+                },
+                "revenue": {
+                    "type": "number"
+                },
+                "revpar": {
+                    "type": "number"
                 }
             }
         },
-        "handler.ListingCreate": {
+        "model.ListingStatisticsReport": {
             "type": "object",
             "properties": {
                 "address": {
                     "type": "string"
                 },
-                "beds_number": {
+                "average_rating": {
+                    "type": "number"
+                },
+                "avg_accuracy": {
+                    "type": "number"
+                },
+                "avg_cleanliness": {
+                    "type": "number"
+                },
+                "avg_location": {
+                    "type": "number"
+                },
+                "avg_value": {
+                    "type": "number"
+                },
+                "bookings_count": {
                     "type": "integer"
                 },
                 "host_id": {
                     "type": "integer"
                 },
+                "host_name": {
+                    "type": "string"
+                },
+                "is_available": {
+                    "type": "boolean"
+                },
+                "listing_id": {
+                    "type": "integer"
+                },
                 "price_per_night": {
                     "type": "number"
                 },
-                "rooms_number": {
+                "reviews_count": {
                     "type": "integer"
+                },
+                "total_revenue": {
+                    "type": "number"
                 }
             }
         },
-        "handler.ListingUpdate": {
+        "model.ListingSummary": {
             "type": "object",
             "properties": {
+                "active_bookings_count": {
+                    "type": "integer"
+                },
+                "address": {
+                    "type": "string"
+                },
+                "average_rating": {
+                    "type": "number"
+                },
+                "avg_accuracy": {
+                    "type": "number"
+                },
+                "avg_cleanliness": {
+                    "type": "number"
+                },
+                "avg_location": {
+                    "type": "number"
+                },
+                "avg_payment_amount": {
+                    "type": "number"
+                },
+                "avg_value": {
+                    "type": "number"
+                },
                 "beds_number": {
                     "type": "integer"
                 },
+                "bookings_count": {
+                    "type": "integer"
+                },
+                "favorites_count": {
+                    "type": "integer"
+                },
+                "host_id": {
+                    "type": "integer"
+                },
+                "host_name": {
+                    "type": "string"
+                },
                 "is_available": {
                     "type": "boolean"
                 },
+                "listing_id": {
+                    "type": "integer"
+                },
                 "price_per_night": {
                     "type": "number"
                 },
+                "reviews_count": {
+                    "type": "integer"
+                },
                 "rooms_number": {
                     "type": "integer"
+                },
+                "total_revenue": {
+                    "type": "number"
                 }
             }
         },
-        "handler.PaymentConfirmRequest": {
-            "type": "object",
-            "properties": {
-                "transaction_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "handler.PaymentCreate": {
+        "model.ListingsAnalyticsPage": {
             "type": "object",
             "properties": {
-                "booking_id": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ListingSummary"
+                    }
+                },
+                "limit": {
                     "type": "integer"
                 },
-                "payment_method": {
-                    "type": "string",
-                    "example": "card"
+                "offset": {
+                    "type": "integer"
                 },
-                "payment_status": {
-                    "type": "string",
-                    "example": "completed"
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.PaymentUpdate": {
+        "model.ListingsSearchResult": {
             "type": "object",
             "properties": {
-                "payment_method": {
-                    "type": "string"
+                "amenity_facets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AmenityFacet"
+                    }
                 },
-                "payment_status": {
-                    "type": "string"
+                "listings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Listing"
+                    }
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.ReviewCreate": {
+        "model.Message": {
             "type": "object",
             "properties": {
-                "booking_id": {
+                "conversation_id": {
                     "type": "integer"
                 },
-                "score": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "text": {
+                "read_at": {
                     "type": "string"
-                }
-            }
-        },
-        "handler.ReviewUpdate": {
-            "type": "object",
-            "properties": {
-                "score": {
+                },
+                "sender_id": {
                     "type": "integer"
                 },
                 "text": {
@@ -2775,372 +7477,402 @@ const docTemplate = `{
                 }
             }
         },
-        "handler.StatusOK": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "deleted successfully"
-                }
-            }
-        },
-        "handler.UserCreate": {
+        "model.Notification": {
             "type": "object",
             "properties": {
-                "email": {
+                "created_at": {
                     "type": "string"
                 },
-                "first_name": {
+                "delivered_at": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "second_name": {
-                    "type": "string"
-                }
-            }
-        },
-        "handler.UserReturn": {
-            "type": "object",
-            "properties": {
-                "email": {
-                    "type": "string"
+                "is_read": {
+                    "type": "boolean"
                 },
-                "first_name": {
+                "listing_id": {
+                    "type": "integer"
+                },
+                "message": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "new_price": {
+                    "type": "number"
                 },
-                "second_name": {
+                "old_price": {
+                    "type": "number"
+                },
+                "type": {
                     "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "handler.UserUpdate": {
+        "model.Payment": {
             "type": "object",
             "properties": {
-                "email": {
-                    "type": "string"
+                "amount": {
+                    "type": "number"
                 },
-                "first_name": {
+                "booking_id": {
+                    "type": "integer"
+                },
+                "paid_at": {
                     "type": "string"
                 },
-                "password": {
+                "payment_id": {
+                    "type": "integer"
+                },
+                "payment_method": {
                     "type": "string"
                 },
-                "second_name": {
+                "payment_status": {
                     "type": "string"
-                }
-            }
-        },
-        "model.Amenity": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "integer"
                 },
-                "name": {
+                "transaction_id": {
                     "type": "string"
                 }
             }
         },
-        "model.Booking": {
+        "model.PaymentDiscrepancy": {
             "type": "object",
             "properties": {
-                "guest_id": {
-                    "type": "integer"
+                "actual_amount": {
+                    "type": "number"
                 },
-                "host_id": {
+                "booking_id": {
                     "type": "integer"
                 },
-                "id": {
-                    "type": "integer"
+                "expected_amount": {
+                    "type": "number"
                 },
-                "in_date": {
-                    "type": "string"
+                "fixable": {
+                    "type": "boolean"
                 },
-                "is_paid": {
+                "fixed": {
                     "type": "boolean"
                 },
-                "listing_id": {
+                "payment_id": {
                     "type": "integer"
                 },
-                "out_date": {
-                    "type": "string"
+                "payments_count": {
+                    "type": "integer"
                 },
-                "total_price": {
-                    "type": "number"
+                "type": {
+                    "type": "string"
                 }
             }
         },
-        "model.BookingReport": {
+        "model.PaymentFailureReport": {
             "type": "object",
             "properties": {
-                "booking_id": {
+                "completed_count": {
                     "type": "integer"
                 },
-                "duration_days": {
+                "failed_count": {
                     "type": "integer"
                 },
-                "guest_id": {
-                    "type": "integer"
+                "failure_rate": {
+                    "type": "number"
                 },
-                "guest_name": {
+                "payment_method": {
                     "type": "string"
                 },
-                "host_id": {
+                "payments_count": {
                     "type": "integer"
                 },
-                "host_name": {
-                    "type": "string"
+                "pending_count": {
+                    "type": "integer"
                 },
-                "in_date": {
-                    "type": "string",
-                    "example": "2025-12-12T14:00:00+03:00"
+                "refunded_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PaymentReconciliationReport": {
+            "type": "object",
+            "properties": {
+                "counts_by_type": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
                 },
-                "is_paid": {
-                    "type": "boolean"
+                "discrepancies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.PaymentDiscrepancy"
+                    }
                 },
-                "listing_address": {
+                "fixed_count": {
+                    "type": "integer"
+                },
+                "generated_at": {
                     "type": "string"
                 },
-                "listing_id": {
+                "total_count": {
                     "type": "integer"
+                }
+            }
+        },
+        "model.PaymentSummaryReport": {
+            "type": "object",
+            "properties": {
+                "average_amount": {
+                    "type": "number"
                 },
-                "out_date": {
-                    "type": "string",
-                    "example": "2025-12-15T14:00:00+03:00"
+                "max_amount": {
+                    "type": "number"
                 },
-                "payment_amount": {
+                "min_amount": {
                     "type": "number"
                 },
-                "payment_status": {
+                "payment_method": {
                     "type": "string"
                 },
-                "review_score": {
-                    "type": "integer"
+                "payment_status": {
+                    "type": "string"
                 },
-                "total_price": {
+                "total_amount": {
                     "type": "number"
+                },
+                "transactions_count": {
+                    "type": "integer"
                 }
             }
         },
-        "model.CreateBookingWithPaymentResult": {
+        "model.PriceRange": {
             "type": "object",
             "properties": {
-                "booking_id": {
-                    "type": "integer"
+                "max": {
+                    "type": "number"
                 },
-                "payment_id": {
-                    "type": "integer"
+                "median": {
+                    "type": "number"
+                },
+                "min": {
+                    "type": "number"
                 }
             }
         },
-        "model.Favorite": {
+        "model.Review": {
             "type": "object",
             "properties": {
-                "id": {
+                "accuracy_score": {
                     "type": "integer"
                 },
-                "listing_id": {
+                "booking_id": {
                     "type": "integer"
                 },
-                "user_id": {
+                "cleanliness_score": {
                     "type": "integer"
-                }
-            }
-        },
-        "model.HostPerformanceReport": {
-            "type": "object",
-            "properties": {
-                "average_rating": {
-                    "type": "number"
                 },
-                "completed_payments_count": {
-                    "type": "integer"
+                "created_at": {
+                    "type": "string"
                 },
-                "host_email": {
+                "flag_reason": {
                     "type": "string"
                 },
-                "host_id": {
-                    "type": "integer"
+                "host_response": {
+                    "type": "string"
                 },
-                "host_name": {
+                "host_response_at": {
                     "type": "string"
                 },
-                "listings_count": {
+                "id": {
                     "type": "integer"
                 },
-                "total_bookings": {
+                "location_score": {
                     "type": "integer"
                 },
-                "total_revenue": {
-                    "type": "number"
-                }
-            }
-        },
-        "model.Image": {
-            "type": "object",
-            "properties": {
-                "image_id": {
+                "revealed": {
+                    "type": "boolean"
+                },
+                "score": {
                     "type": "integer"
                 },
-                "image_url": {
+                "status": {
                     "type": "string"
                 },
-                "is_primary": {
-                    "type": "boolean"
+                "text": {
+                    "type": "string"
                 },
-                "listing_id": {
-                    "type": "integer"
+                "updated_at": {
+                    "type": "string"
                 },
-                "order_index": {
+                "user_id": {
                     "type": "integer"
                 },
-                "uploaded_at": {
-                    "type": "string"
+                "value_score": {
+                    "type": "integer"
                 }
             }
         },
-        "model.Listing": {
+        "model.ReviewsPage": {
             "type": "object",
             "properties": {
-                "address": {
+                "next_cursor": {
                     "type": "string"
                 },
-                "beds_number": {
-                    "type": "integer"
-                },
-                "host_id": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "is_available": {
-                    "type": "boolean"
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Review"
+                    }
                 },
-                "price_per_night": {
+                "summary": {
+                    "$ref": "#/definitions/model.ReviewsSummary"
+                }
+            }
+        },
+        "model.ReviewsSummary": {
+            "type": "object",
+            "properties": {
+                "average": {
                     "type": "number"
                 },
-                "rooms_number": {
+                "count": {
                     "type": "integer"
+                },
+                "histogram": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
                 }
             }
         },
-        "model.ListingAmenity": {
+        "model.TimeseriesPoint": {
             "type": "object",
             "properties": {
-                "amenity_id": {
-                    "type": "integer"
+                "amount": {
+                    "type": "number"
                 },
-                "listing_id": {
+                "bucket": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "events_count": {
                     "type": "integer"
                 }
             }
         },
-        "model.ListingStatisticsReport": {
+        "model.TimeseriesReport": {
             "type": "object",
             "properties": {
-                "address": {
+                "from": {
                     "type": "string"
                 },
-                "average_rating": {
-                    "type": "number"
-                },
-                "bookings_count": {
-                    "type": "integer"
+                "granularity": {
+                    "type": "string"
                 },
                 "host_id": {
                     "type": "integer"
                 },
-                "host_name": {
-                    "type": "string"
-                },
-                "is_available": {
-                    "type": "boolean"
-                },
                 "listing_id": {
                     "type": "integer"
                 },
-                "price_per_night": {
-                    "type": "number"
+                "metric": {
+                    "type": "string"
                 },
-                "reviews_count": {
-                    "type": "integer"
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TimeseriesPoint"
+                    }
                 },
-                "total_revenue": {
-                    "type": "number"
+                "to": {
+                    "type": "string"
                 }
             }
         },
-        "model.Payment": {
+        "model.Trip": {
             "type": "object",
             "properties": {
-                "amount": {
-                    "type": "number"
-                },
                 "booking_id": {
                     "type": "integer"
                 },
-                "paid_at": {
-                    "type": "string"
+                "duration_days": {
+                    "type": "integer"
                 },
-                "payment_id": {
+                "host_id": {
                     "type": "integer"
                 },
-                "payment_method": {
+                "host_name": {
                     "type": "string"
                 },
-                "payment_status": {
-                    "type": "string"
+                "in_date": {
+                    "type": "string",
+                    "example": "2025-12-12T14:00:00+03:00"
                 },
-                "transaction_id": {
-                    "type": "string"
-                }
-            }
-        },
-        "model.PaymentSummaryReport": {
-            "type": "object",
-            "properties": {
-                "average_amount": {
-                    "type": "number"
+                "is_paid": {
+                    "type": "boolean"
                 },
-                "max_amount": {
-                    "type": "number"
+                "listing": {
+                    "$ref": "#/definitions/model.ListingCard"
                 },
-                "min_amount": {
-                    "type": "number"
+                "listing_id": {
+                    "type": "integer"
                 },
-                "payment_method": {
+                "out_date": {
+                    "type": "string",
+                    "example": "2025-12-15T14:00:00+03:00"
+                },
+                "paid_at": {
                     "type": "string"
                 },
+                "payment_amount": {
+                    "type": "number"
+                },
                 "payment_status": {
                     "type": "string"
                 },
-                "total_amount": {
-                    "type": "number"
+                "review_deadline": {
+                    "type": "string"
                 },
-                "transactions_count": {
+                "review_id": {
                     "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_price": {
+                    "type": "number"
                 }
             }
         },
-        "model.Review": {
+        "model.Wishlist": {
             "type": "object",
             "properties": {
-                "booking_id": {
-                    "type": "integer"
+                "created_at": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "score": {
+                "is_default": {
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Favorite"
+                    }
+                },
+                "items_count": {
                     "type": "integer"
                 },
-                "text": {
+                "name": {
+                    "type": "string"
+                },
+                "share_token": {
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 },
                 "user_id": {
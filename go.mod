@@ -11,6 +11,7 @@ require (
 	github.com/swaggo/echo-swagger v1.4.1
 	go.uber.org/zap v1.27.1
 	golang.org/x/crypto v0.46.0
+	golang.org/x/image v0.34.0
 )
 
 require (
@@ -1,19 +1,38 @@
 package app
 
 import (
+	"sync"
+
 	"github.com/labstack/echo/v4"
 )
 
 type App struct {
-	handler Handler
+	handler    Handler
+	uploadsDir string
+	workers    sync.WaitGroup
 }
 
-func NewApp(handler Handler) *App {
+func NewApp(handler Handler, uploadsDir string) *App {
 	return &App{
-		handler: handler,
+		handler:    handler,
+		uploadsDir: uploadsDir,
 	}
 }
 
+// runWorker запускает фоновую задачу; задачи должны завершаться по отмене контекста приложения
+func (app *App) runWorker(worker func()) {
+	app.workers.Add(1)
+	go func() {
+		defer app.workers.Done()
+		worker()
+	}()
+}
+
+// Wait дожидается завершения фоновых задач после отмены контекста приложения
+func (app *App) Wait() {
+	app.workers.Wait()
+}
+
 type Handler interface {
 	CreateUser(c echo.Context) error
 	BatchImportUsers(c echo.Context) error
@@ -24,6 +43,8 @@ type Handler interface {
 	CreateListing(c echo.Context) error
 	BatchImportListings(c echo.Context) error
 	GetListingByID(c echo.Context) error
+	GetListings(c echo.Context) error
+	SearchListings(c echo.Context) error
 	UpdateListing(c echo.Context) error
 	DeleteListing(c echo.Context) error
 
@@ -38,15 +59,27 @@ type Handler interface {
 	GetReviewByID(c echo.Context) error
 	UpdateReview(c echo.Context) error
 	DeleteReview(c echo.Context) error
+	RespondToReview(c echo.Context) error
+	GetListingReviews(c echo.Context) error
+	GetUserReviews(c echo.Context) error
+	GetReviewsModerationQueue(c echo.Context) error
+	ModerateReview(c echo.Context) error
+
+	CreateGuestReview(c echo.Context) error
+	GetGuestReviewByID(c echo.Context) error
+	DeleteGuestReview(c echo.Context) error
+	GetGuestReputation(c echo.Context) error
 
 	CreateAmenity(c echo.Context) error
 	GetAmenityByID(c echo.Context) error
 	GetAllAmenities(c echo.Context) error
+	GetAmenityCategories(c echo.Context) error
 	UpdateAmenity(c echo.Context) error
 	DeleteAmenity(c echo.Context) error
 	AddAmenityToListing(c echo.Context) error
 	RemoveAmenityFromListing(c echo.Context) error
 	GetAmenitiesByListingID(c echo.Context) error
+	ReplaceListingAmenities(c echo.Context) error
 
 	CreateFavorite(c echo.Context) error
 	GetFavoriteByID(c echo.Context) error
@@ -54,6 +87,28 @@ type Handler interface {
 	DeleteFavorite(c echo.Context) error
 	DeleteFavoriteByUserAndListing(c echo.Context) error
 
+	CreateWishlist(c echo.Context) error
+	GetWishlistsByUserID(c echo.Context) error
+	GetWishlistByID(c echo.Context) error
+	GetSharedWishlist(c echo.Context) error
+	UpdateWishlist(c echo.Context) error
+	DeleteWishlist(c echo.Context) error
+	AddWishlistItem(c echo.Context) error
+	UpdateWishlistItem(c echo.Context) error
+	RemoveWishlistItem(c echo.Context) error
+	ShareWishlist(c echo.Context) error
+	UnshareWishlist(c echo.Context) error
+
+	GetUserNotifications(c echo.Context) error
+	MarkNotificationRead(c echo.Context) error
+
+	StartConversation(c echo.Context) error
+	GetUserConversations(c echo.Context) error
+	GetConversation(c echo.Context) error
+	GetConversationMessages(c echo.Context) error
+	SendMessage(c echo.Context) error
+	MarkConversationRead(c echo.Context) error
+
 	CreatePayment(c echo.Context) error
 	GetPaymentByID(c echo.Context) error
 	GetPaymentsByBookingID(c echo.Context) error
@@ -61,10 +116,12 @@ type Handler interface {
 	DeletePayment(c echo.Context) error
 
 	CreateImage(c echo.Context) error
+	UploadImage(c echo.Context) error
 	GetImageByID(c echo.Context) error
 	GetImagesByListingID(c echo.Context) error
 	UpdateImage(c echo.Context) error
 	DeleteImage(c echo.Context) error
+	ReorderImages(c echo.Context) error
 
 	GetHostTotalRevenue(c echo.Context) error
 	GetGuestTotalSpent(c echo.Context) error
@@ -72,11 +129,31 @@ type Handler interface {
 	GetListingActiveBookingsCount(c echo.Context) error
 
 	GetListingsStatisticsReport(c echo.Context) error
+	GetCitiesStatisticsReport(c echo.Context) error
 	GetHostsPerformanceReport(c echo.Context) error
+	RefreshReports(c echo.Context) error
 	GetBookingsReport(c echo.Context) error
 	GetPaymentsSummaryReport(c echo.Context) error
+	GetListingsOccupancyReport(c echo.Context) error
+	GetHostsOccupancyReport(c echo.Context) error
+	GetTimeseriesReport(c echo.Context) error
+	GetGuestCohortReport(c echo.Context) error
+	GetListingsCancellationReport(c echo.Context) error
+	GetHostsCancellationReport(c echo.Context) error
+	GetPaymentFailuresReport(c echo.Context) error
+
+	GetHostDashboard(c echo.Context) error
+	GetGuestTrips(c echo.Context) error
+	GetListingBenchmark(c echo.Context) error
 
 	CreateBookingWithPayment(c echo.Context) error
 	ConfirmPayment(c echo.Context) error
 	CancelBookingWithRefund(c echo.Context) error
+
+	GetPaymentsReconciliation(c echo.Context) error
+	FixPaymentsReconciliation(c echo.Context) error
+
+	GetListingsAnalytics(c echo.Context) error
+	GetHostsAnalytics(c echo.Context) error
+	GetBookingsAnalytics(c echo.Context) error
 }
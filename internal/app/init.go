@@ -8,8 +8,12 @@ import (
 	"github.com/Rissochek/db-cw/api/handler"
 	_ "github.com/Rissochek/db-cw/docs"
 	"github.com/Rissochek/db-cw/internal/faking"
+	"github.com/Rissochek/db-cw/internal/geo"
+	"github.com/Rissochek/db-cw/internal/moderation"
+	"github.com/Rissochek/db-cw/internal/notify"
 	"github.com/Rissochek/db-cw/internal/repository/postgres"
 	"github.com/Rissochek/db-cw/internal/service"
+	"github.com/Rissochek/db-cw/internal/storage"
 	"github.com/Rissochek/db-cw/internal/utils"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -22,7 +26,8 @@ var (
 	migrationsPath = "./internal/migrations"
 )
 
-func InitApp() *App {
+// InitApp собирает приложение; фоновые задачи работают до отмены ctx
+func InitApp(ctx context.Context) *App {
 	utils.LoadEnvFile()
 	isGenEnv := utils.GetKeyFromEnv("IS_GENERATING")
 	isGenBool, err := strconv.ParseBool(isGenEnv)
@@ -30,7 +35,7 @@ func InitApp() *App {
 		zap.S().Panicf("Failed to parse IS_GENERATING env key %v", err)
 	}
 	
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	initCtx, cancel := context.WithTimeout(ctx, time.Minute)
 	defer cancel()
 
 	faker := faking.NewDataFaker(seed)
@@ -40,15 +45,53 @@ func InitApp() *App {
 	repo := postgres.NewPostgres(conn)
 	repo.RunMigrations(migrationsPath)
 
-	service := service.NewService(faker, repo)
+	reviewFilter := moderation.NewDefaultFilter()
+
+	var blobStore service.BlobStore
+	var uploadsDir string
+	switch utils.GetKeyFromEnvOrDefault("STORAGE_BACKEND", "local") {
+	case "s3":
+		blobStore = storage.NewS3StoreFromEnv()
+	default:
+		localStore := storage.NewLocalStoreFromEnv()
+		uploadsDir = localStore.Dir()
+		blobStore = localStore
+	}
+
+	var notificationChannel service.NotificationChannel
+	switch utils.GetKeyFromEnvOrDefault("NOTIFICATION_CHANNEL", "log") {
+	case "email":
+		notificationChannel = notify.NewEmailChannelFromEnv()
+	default:
+		notificationChannel = notify.NewLogChannel()
+	}
+
+	geocoder := geo.NewFixtureGeocoder()
+
+	reportsRefreshInterval, err := time.ParseDuration(utils.GetKeyFromEnvOrDefault("REPORTS_REFRESH_INTERVAL", "5m"))
+	if err != nil || reportsRefreshInterval <= 0 {
+		zap.S().Panicf("Failed to parse REPORTS_REFRESH_INTERVAL env key %v", err)
+	}
+
+	service := service.NewService(faker, repo, reviewFilter, blobStore, notificationChannel, geocoder)
+
+	if err := service.LoadReviewSettings(initCtx); err != nil {
+		zap.S().Panicf("Failed to load review settings %v", err)
+	}
 
 	if isGenBool {
-		service.FillDatabase(ctx, seed)
+		service.FillDatabase(initCtx, seed)
 	}
 
 	handler := handler.NewHandler(service)
 
-	app := NewApp(handler)
+	app := NewApp(handler, uploadsDir)
+
+	app.runWorker(func() { service.BackfillListingCoordinates(ctx) })
+	app.runWorker(func() { service.RunImageWorker(ctx) })
+	app.runWorker(func() { service.RunReviewRevealRefresher(ctx) })
+	app.runWorker(func() { service.RunNotificationWorker(ctx) })
+	app.runWorker(func() { service.RunReportsRefresher(ctx, reportsRefreshInterval) })
 
 	return app
 }
@@ -60,6 +103,10 @@ func (app *App) SetupRoutes() *echo.Echo {
 
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
+	if app.uploadsDir != "" {
+		e.Static(storage.LocalPublicPath, app.uploadsDir)
+	}
+
 	api := e.Group("/api")
 
 	api.POST("/users", app.handler.CreateUser)
@@ -67,12 +114,16 @@ func (app *App) SetupRoutes() *echo.Echo {
 	api.GET("/users/:id", app.handler.GetUserByID)
 	api.PUT("/users/:id", app.handler.UpdateUser)
 	api.DELETE("/users/:id", app.handler.DeleteUser)
+	api.GET("/users/:id/trips", app.handler.GetGuestTrips)
 
 	api.POST("/listings", app.handler.CreateListing)
 	api.POST("/listings/batch", app.handler.BatchImportListings)
+	api.GET("/listings", app.handler.GetListings)
+	api.GET("/listings/search", app.handler.SearchListings)
 	api.GET("/listings/:id", app.handler.GetListingByID)
 	api.PUT("/listings/:id", app.handler.UpdateListing)
 	api.DELETE("/listings/:id", app.handler.DeleteListing)
+	api.GET("/listings/:id/benchmark", app.handler.GetListingBenchmark)
 
 	api.POST("/bookings", app.handler.CreateBooking)
 	api.POST("/bookings/batch", app.handler.BatchImportBookings)
@@ -85,15 +136,27 @@ func (app *App) SetupRoutes() *echo.Echo {
 	api.GET("/reviews/:id", app.handler.GetReviewByID)
 	api.PUT("/reviews/:id", app.handler.UpdateReview)
 	api.DELETE("/reviews/:id", app.handler.DeleteReview)
+	api.POST("/reviews/:id/response", app.handler.RespondToReview)
+	api.GET("/listings/:listing_id/reviews", app.handler.GetListingReviews)
+	api.GET("/users/:user_id/reviews", app.handler.GetUserReviews)
+	api.GET("/admin/reviews", app.handler.GetReviewsModerationQueue)
+	api.PUT("/admin/reviews/:id/status", app.handler.ModerateReview)
+
+	api.POST("/guest-reviews", app.handler.CreateGuestReview)
+	api.GET("/guest-reviews/:id", app.handler.GetGuestReviewByID)
+	api.DELETE("/guest-reviews/:id", app.handler.DeleteGuestReview)
+	api.GET("/users/:user_id/reputation", app.handler.GetGuestReputation)
 
 	api.POST("/amenities", app.handler.CreateAmenity)
 	api.GET("/amenities", app.handler.GetAllAmenities)
+	api.GET("/amenities/categories", app.handler.GetAmenityCategories)
 	api.GET("/amenities/:id", app.handler.GetAmenityByID)
 	api.PUT("/amenities/:id", app.handler.UpdateAmenity)
 	api.DELETE("/amenities/:id", app.handler.DeleteAmenity)
 	api.POST("/listings/:listing_id/amenities/:amenity_id", app.handler.AddAmenityToListing)
 	api.DELETE("/listings/:listing_id/amenities/:amenity_id", app.handler.RemoveAmenityFromListing)
 	api.GET("/listings/:listing_id/amenities", app.handler.GetAmenitiesByListingID)
+	api.PUT("/listings/:listing_id/amenities", app.handler.ReplaceListingAmenities)
 
 	api.POST("/favorites", app.handler.CreateFavorite)
 	api.GET("/favorites/:id", app.handler.GetFavoriteByID)
@@ -101,6 +164,28 @@ func (app *App) SetupRoutes() *echo.Echo {
 	api.DELETE("/favorites/:id", app.handler.DeleteFavorite)
 	api.DELETE("/users/:user_id/favorites/:listing_id", app.handler.DeleteFavoriteByUserAndListing)
 
+	api.POST("/users/:user_id/wishlists", app.handler.CreateWishlist)
+	api.GET("/users/:user_id/wishlists", app.handler.GetWishlistsByUserID)
+	api.GET("/wishlists/:id", app.handler.GetWishlistByID)
+	api.PUT("/wishlists/:id", app.handler.UpdateWishlist)
+	api.DELETE("/wishlists/:id", app.handler.DeleteWishlist)
+	api.POST("/wishlists/:id/items", app.handler.AddWishlistItem)
+	api.PUT("/wishlists/:id/items/:listing_id", app.handler.UpdateWishlistItem)
+	api.DELETE("/wishlists/:id/items/:listing_id", app.handler.RemoveWishlistItem)
+	api.POST("/wishlists/:id/share", app.handler.ShareWishlist)
+	api.DELETE("/wishlists/:id/share", app.handler.UnshareWishlist)
+	api.GET("/shared/wishlists/:token", app.handler.GetSharedWishlist)
+
+	api.GET("/users/:user_id/notifications", app.handler.GetUserNotifications)
+	api.PUT("/notifications/:id/read", app.handler.MarkNotificationRead)
+
+	api.POST("/conversations", app.handler.StartConversation)
+	api.GET("/users/:user_id/conversations", app.handler.GetUserConversations)
+	api.GET("/conversations/:id", app.handler.GetConversation)
+	api.GET("/conversations/:id/messages", app.handler.GetConversationMessages)
+	api.POST("/conversations/:id/messages", app.handler.SendMessage)
+	api.PUT("/conversations/:id/read", app.handler.MarkConversationRead)
+
 	api.POST("/payments", app.handler.CreatePayment)
 	api.GET("/payments/:id", app.handler.GetPaymentByID)
 	api.GET("/bookings/:booking_id/payments", app.handler.GetPaymentsByBookingID)
@@ -110,22 +195,42 @@ func (app *App) SetupRoutes() *echo.Echo {
 	api.POST("/images", app.handler.CreateImage)
 	api.GET("/images/:id", app.handler.GetImageByID)
 	api.GET("/listings/:listing_id/images", app.handler.GetImagesByListingID)
+	api.POST("/listings/:listing_id/images", app.handler.UploadImage)
+	api.PUT("/listings/:listing_id/images/order", app.handler.ReorderImages)
 	api.PUT("/images/:id", app.handler.UpdateImage)
 	api.DELETE("/images/:id", app.handler.DeleteImage)
 
+	api.GET("/hosts/:id/dashboard", app.handler.GetHostDashboard)
+
 	api.GET("/functions/hosts/:host_id/revenue", app.handler.GetHostTotalRevenue)
 	api.GET("/functions/guests/:guest_id/total-spent", app.handler.GetGuestTotalSpent)
 	api.GET("/functions/hosts/:host_id/average-rating", app.handler.GetHostAverageRating)
 	api.GET("/functions/listings/:listing_id/active-bookings", app.handler.GetListingActiveBookingsCount)
 
 	api.GET("/reports/listings-statistics", app.handler.GetListingsStatisticsReport)
+	api.GET("/reports/cities-statistics", app.handler.GetCitiesStatisticsReport)
 	api.GET("/reports/hosts-performance", app.handler.GetHostsPerformanceReport)
+	api.POST("/reports/refresh", app.handler.RefreshReports)
 	api.GET("/reports/bookings", app.handler.GetBookingsReport)
 	api.GET("/reports/payments-summary", app.handler.GetPaymentsSummaryReport)
+	api.GET("/reports/listings-occupancy", app.handler.GetListingsOccupancyReport)
+	api.GET("/reports/hosts-occupancy", app.handler.GetHostsOccupancyReport)
+	api.GET("/reports/timeseries", app.handler.GetTimeseriesReport)
+	api.GET("/reports/guest-cohorts", app.handler.GetGuestCohortReport)
+	api.GET("/reports/listings-cancellations", app.handler.GetListingsCancellationReport)
+	api.GET("/reports/hosts-cancellations", app.handler.GetHostsCancellationReport)
+	api.GET("/reports/payment-failures", app.handler.GetPaymentFailuresReport)
+
+	api.GET("/analytics/listings", app.handler.GetListingsAnalytics)
+	api.GET("/analytics/hosts", app.handler.GetHostsAnalytics)
+	api.GET("/analytics/bookings", app.handler.GetBookingsAnalytics)
 
 	api.POST("/procedures/create-booking-with-payment", app.handler.CreateBookingWithPayment)
 	api.POST("/procedures/payments/:id/confirm", app.handler.ConfirmPayment)
 	api.POST("/procedures/bookings/:id/cancel-with-refund", app.handler.CancelBookingWithRefund)
 
+	api.GET("/reconciliation/payments", app.handler.GetPaymentsReconciliation)
+	api.POST("/reconciliation/payments/fix", app.handler.FixPaymentsReconciliation)
+
 	return e
 }
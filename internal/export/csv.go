@@ -0,0 +1,97 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"reflect"
+)
+
+// BOM нужен, чтобы Excel открыл UTF-8 с кириллицей без выбора кодировки
+var utf8BOM = []byte("\xEF\xBB\xBF")
+
+type csvWriter struct {
+	out     io.Writer
+	csv     *csv.Writer
+	rowType reflect.Type
+	columns []column
+	record  []string
+	started bool
+	rows    int
+}
+
+func newCSVWriter(w io.Writer, rowType reflect.Type, columns []column) *csvWriter {
+	return &csvWriter{
+		out:     w,
+		csv:     csv.NewWriter(w),
+		rowType: rowType,
+		columns: columns,
+		record:  make([]string, len(columns)),
+	}
+}
+
+func (w *csvWriter) WriteRow(row any) error {
+	value, err := rowValue(row, w.rowType)
+	if err != nil {
+		return err
+	}
+
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	for i, col := range w.columns {
+		w.record[i], _ = cellValue(value.Field(col.index))
+	}
+	if err := w.csv.Write(w.record); err != nil {
+		return err
+	}
+
+	w.rows++
+	if w.rows%flushEvery == 0 {
+		return w.flush()
+	}
+	return nil
+}
+
+func (w *csvWriter) Close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	return w.flush()
+}
+
+func (w *csvWriter) Abort(reason string) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	record := make([]string, max(len(w.columns), 1))
+	record[0] = ErrorMarkerPrefix + reason
+	if err := w.csv.Write(record); err != nil {
+		return err
+	}
+	return w.flush()
+}
+
+func (w *csvWriter) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+
+	if _, err := w.out.Write(utf8BOM); err != nil {
+		return err
+	}
+	return w.csv.Write(headers(w.columns))
+}
+
+func (w *csvWriter) flush() error {
+	w.csv.Flush()
+	if err := w.csv.Error(); err != nil {
+		return err
+	}
+	if f, ok := w.out.(flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
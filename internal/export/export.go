@@ -0,0 +1,148 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+// строки сбрасываются клиенту пачками, чтобы большой отчет не копился в буферах
+const flushEvery = 200
+
+var contentTypes = map[string]string{
+	FormatCSV:  "text/csv; charset=utf-8",
+	FormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// RowWriter построчно пишет отчет; строка заголовка выводится перед первой строкой данных
+// или при закрытии, если данных нет. До первой записи в w ничего не отправляется.
+// Abort завершает уже начатую выгрузку строкой-маркером ошибки вместо данных.
+type RowWriter interface {
+	WriteRow(row any) error
+	Close() error
+	Abort(reason string) error
+}
+
+// ErrorMarkerPrefix начинает первую ячейку последней строки прерванной выгрузки
+const ErrorMarkerPrefix = "#ERROR: "
+
+type flusher interface {
+	Flush()
+}
+
+type cellKind int
+
+const (
+	cellEmpty cellKind = iota
+	cellString
+	cellNumber
+	cellBool
+)
+
+type column struct {
+	name  string
+	index int
+}
+
+func IsSupported(format string) bool {
+	_, ok := contentTypes[format]
+	return ok
+}
+
+func ContentType(format string) string {
+	return contentTypes[format]
+}
+
+// NewWriter создает писатель для строк типа rowType; колонки берутся из json-тегов его полей
+func NewWriter(format string, w io.Writer, rowType reflect.Type) (RowWriter, error) {
+	if rowType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("export row must be a struct, got %s", rowType)
+	}
+
+	columns := columnsOf(rowType)
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, rowType, columns), nil
+	case FormatXLSX:
+		return newXLSXWriter(w, rowType, columns), nil
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+func columnsOf(rowType reflect.Type) []column {
+	columns := make([]column, 0, rowType.NumField())
+	for i := 0; i < rowType.NumField(); i++ {
+		field := rowType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		columns = append(columns, column{name: name, index: i})
+	}
+	return columns
+}
+
+func headers(columns []column) []string {
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = col.name
+	}
+	return names
+}
+
+// rowValue проверяет, что строка того же типа, под который построены колонки
+func rowValue(row any, rowType reflect.Type) (reflect.Value, error) {
+	value := reflect.ValueOf(row)
+	if value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Type() != rowType {
+		return reflect.Value{}, fmt.Errorf("export row must be %s, got %T", rowType, row)
+	}
+	return value, nil
+}
+
+func cellValue(value reflect.Value) (string, cellKind) {
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return "", cellEmpty
+		}
+		value = value.Elem()
+	}
+
+	if t, ok := value.Interface().(time.Time); ok {
+		return t.Format(time.RFC3339), cellString
+	}
+
+	switch value.Kind() {
+	case reflect.String:
+		return value.String(), cellString
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool()), cellBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10), cellNumber
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10), cellNumber
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64), cellNumber
+	default:
+		return fmt.Sprint(value.Interface()), cellString
+	}
+}
@@ -0,0 +1,184 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"io"
+	"reflect"
+)
+
+// минимальная книга из одного листа; лист пишется потоком со строками inline, без таблицы общих строк
+var xlsxStaticParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`},
+	{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`},
+	{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="Report" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`},
+	{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`},
+}
+
+const (
+	xlsxSheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetFooter = `</sheetData></worksheet>`
+)
+
+type xlsxWriter struct {
+	out     io.Writer
+	zip     *zip.Writer
+	sheet   *bufio.Writer
+	rowType reflect.Type
+	columns []column
+	started bool
+	rows    int
+}
+
+func newXLSXWriter(w io.Writer, rowType reflect.Type, columns []column) *xlsxWriter {
+	return &xlsxWriter{
+		out:     w,
+		rowType: rowType,
+		columns: columns,
+	}
+}
+
+func (w *xlsxWriter) WriteRow(row any) error {
+	value, err := rowValue(row, w.rowType)
+	if err != nil {
+		return err
+	}
+
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	w.sheet.WriteString("<row>")
+	for _, col := range w.columns {
+		text, kind := cellValue(value.Field(col.index))
+		w.writeCell(text, kind)
+	}
+	if _, err := w.sheet.WriteString("</row>"); err != nil {
+		return err
+	}
+
+	w.rows++
+	if w.rows%flushEvery == 0 {
+		return w.flush()
+	}
+	return nil
+}
+
+func (w *xlsxWriter) Close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	w.sheet.WriteString(xlsxSheetFooter)
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+	if err := w.zip.Close(); err != nil {
+		return err
+	}
+	if f, ok := w.out.(flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// Abort дописывает строку с ошибкой и закрывает книгу, чтобы файл открылся и причина была видна
+func (w *xlsxWriter) Abort(reason string) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+
+	w.sheet.WriteString("<row>")
+	w.writeCell(ErrorMarkerPrefix+reason, cellString)
+	if _, err := w.sheet.WriteString("</row>"); err != nil {
+		return err
+	}
+	return w.Close()
+}
+
+func (w *xlsxWriter) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+
+	w.zip = zip.NewWriter(w.out)
+	for _, part := range xlsxStaticParts {
+		file, err := w.zip.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return err
+		}
+	}
+
+	sheet, err := w.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	w.sheet = bufio.NewWriter(sheet)
+
+	w.sheet.WriteString(xlsxSheetHeader)
+	w.sheet.WriteString("<row>")
+	for _, name := range headers(w.columns) {
+		w.writeCell(name, cellString)
+	}
+	_, err = w.sheet.WriteString("</row>")
+	return err
+}
+
+// ошибки записи в bufio.Writer запоминаются и возвращаются следующей проверяемой записью
+func (w *xlsxWriter) writeCell(text string, kind cellKind) {
+	switch kind {
+	case cellEmpty:
+		w.sheet.WriteString("<c/>")
+	case cellNumber:
+		w.sheet.WriteString("<c><v>")
+		w.sheet.WriteString(text)
+		w.sheet.WriteString("</v></c>")
+	case cellBool:
+		if text == "true" {
+			w.sheet.WriteString(`<c t="b"><v>1</v></c>`)
+		} else {
+			w.sheet.WriteString(`<c t="b"><v>0</v></c>`)
+		}
+	default:
+		w.sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+		xml.EscapeText(w.sheet, []byte(text))
+		w.sheet.WriteString("</t></is></c>")
+	}
+}
+
+func (w *xlsxWriter) flush() error {
+	if err := w.sheet.Flush(); err != nil {
+		return err
+	}
+	if err := w.zip.Flush(); err != nil {
+		return err
+	}
+	if f, ok := w.out.(flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
@@ -8,12 +8,15 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Rissochek/db-cw/internal/geo"
 	"github.com/Rissochek/db-cw/internal/model"
 	"github.com/Rissochek/db-cw/internal/utils"
 	"github.com/brianvoe/gofakeit/v7"
 	"go.uber.org/zap"
 )
 
+var listingKinds = []string{"Уютная квартира", "Студия", "Апартаменты", "Лофт", "Загородный дом", "Комната"}
+
 type GoFakeIt struct {
 	faker *gofakeit.Faker
 }
@@ -85,7 +88,16 @@ func (faker *GoFakeIt) GenerateFakeListings(toGen int, users []model.User) (list
 
 				listings[i].ID = i + 1
 				listings[i].HostID = users[userID].ID
-				listings[i].Address = faker.faker.Address().Address
+				// город берется из справочника геокодера, поэтому координаты совпадут с адресом
+				city := geo.Cities[faker.faker.IntRange(0, len(geo.Cities)-1)]
+				street, cityName, country := faker.faker.Street(), city.Name, city.Country
+				listings[i].Address = fmt.Sprintf("%s, %s, %s", street, cityName, country)
+				listings[i].Street, listings[i].City, listings[i].Country = &street, &cityName, &country
+				kind := listingKinds[faker.faker.IntRange(0, len(listingKinds)-1)]
+				listings[i].Title = fmt.Sprintf("%s в %s", kind, cityName)
+				listings[i].Description = faker.faker.Paragraph()
+				latitude, longitude := geo.Locate(city, listings[i].Address)
+				listings[i].Latitude, listings[i].Longitude = &latitude, &longitude
 				listings[i].PricePerNight = faker.faker.Float64Range(500.0, 50000.0)
 				listings[i].IsAvailable = faker.faker.Bool()
 				listings[i].RoomsNumber = faker.faker.IntRange(1, 10)
@@ -219,6 +231,14 @@ func (faker *GoFakeIt) GenerateFakeReviews(toGen int, bookings []model.Booking,
 						reviews[i].BookingID = selectedBooking.BookingID
 						reviews[i].UserID = selectedBooking.GuestID
 						reviews[i].Score = faker.faker.IntRange(1, 5)
+						reviews[i].Status = model.ReviewStatusPublished
+
+						if faker.faker.Bool() {
+							reviews[i].Cleanliness = faker.fakeSubScore(reviews[i].Score)
+							reviews[i].Accuracy = faker.fakeSubScore(reviews[i].Score)
+							reviews[i].Location = faker.fakeSubScore(reviews[i].Score)
+							reviews[i].Value = faker.fakeSubScore(reviews[i].Score)
+						}
 
 						if faker.faker.Bool() {
 							reviews[i].Text = faker.faker.Paragraph()
@@ -244,6 +264,12 @@ func (faker *GoFakeIt) GenerateFakeReviews(toGen int, bookings []model.Booking,
 	return validReviews
 }
 
+// оценка по категории держится рядом с общей оценкой отзыва
+func (faker *GoFakeIt) fakeSubScore(score int) *int {
+	subScore := min(max(score+faker.faker.IntRange(-1, 1), 1), 5)
+	return &subScore
+}
+
 func checkTimeIntervals(booking model.Booking, bookingsMap map[int][]model.Booking) error {
 	bookings, ok := bookingsMap[booking.ListingID]
 	if ok {
@@ -258,28 +284,41 @@ func checkTimeIntervals(booking model.Booking, bookingsMap map[int][]model.Booki
 }
 
 func (faker *GoFakeIt) GenerateFakeAmenities() (amenities []model.Amenity) {
-	amenityNames := []string{
-		"WiFi",
-		"Кухня",
-		"Парковка",
-		"Кондиционер",
-		"Отопление",
-		"Стиральная машина",
-		"Фен",
-		"Телевизор",
-		"Микроволновка",
-		"Холодильник",
-		"Посудомоечная машина",
-		"Балкон/Терраса",
-		"Бассейн",
-		"Джакузи",
-		"Камин",
+	amenitySeeds := []struct {
+		name     string
+		category string
+		iconKey  string
+		labelEn  string
+	}{
+		{"WiFi", "basics", "wifi", "Wifi"},
+		{"Кухня", "kitchen", "kitchen", "Kitchen"},
+		{"Парковка", "basics", "parking", "Parking"},
+		{"Кондиционер", "climate", "air_conditioning", "Air conditioning"},
+		{"Отопление", "climate", "heating", "Heating"},
+		{"Стиральная машина", "basics", "washer", "Washer"},
+		{"Фен", "basics", "hair_dryer", "Hair dryer"},
+		{"Телевизор", "entertainment", "tv", "TV"},
+		{"Микроволновка", "kitchen", "microwave", "Microwave"},
+		{"Холодильник", "kitchen", "refrigerator", "Refrigerator"},
+		{"Посудомоечная машина", "kitchen", "dishwasher", "Dishwasher"},
+		{"Балкон/Терраса", "outdoor", "balcony", "Balcony or terrace"},
+		{"Бассейн", "outdoor", "pool", "Pool"},
+		{"Джакузи", "outdoor", "hot_tub", "Hot tub"},
+		{"Камин", "climate", "fireplace", "Fireplace"},
+		{"Детектор дыма", "safety", "smoke_alarm", "Smoke alarm"},
+		{"Огнетушитель", "safety", "fire_extinguisher", "Fire extinguisher"},
+		{"Аптечка", "safety", "first_aid_kit", "First aid kit"},
+		{"Вход без ступенек", "accessibility", "step_free_entrance", "Step-free entrance"},
+		{"Лифт", "accessibility", "elevator", "Elevator"},
 	}
 
-	amenities = make([]model.Amenity, len(amenityNames))
-	for i := range amenityNames {
+	amenities = make([]model.Amenity, len(amenitySeeds))
+	for i, seed := range amenitySeeds {
 		amenities[i].ID = i + 1
-		amenities[i].Name = amenityNames[i]
+		amenities[i].Name = seed.name
+		amenities[i].Category = &seed.category
+		amenities[i].IconKey = &seed.iconKey
+		amenities[i].Labels = model.Labels{"ru": seed.name, "en": seed.labelEn}
 	}
 
 	zap.S().Infof("generated %v amenities", len(amenities))
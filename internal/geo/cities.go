@@ -0,0 +1,79 @@
+package geo
+
+import (
+	"hash/fnv"
+	"math"
+	"strings"
+)
+
+// cityRadiusKm - радиус вокруг центра города, в котором раскладываются адреса
+var cityRadiusKm = 8.0
+
+type City struct {
+	Name      string
+	Country   string
+	Latitude  float64
+	Longitude float64
+	Aliases   []string
+}
+
+// Cities - справочник городов для офлайн-геокодера и генерации тестовых данных
+var Cities = []City{
+	{Name: "Moscow", Country: "Russia", Latitude: 55.7558, Longitude: 37.6173, Aliases: []string{"москва"}},
+	{Name: "Saint Petersburg", Country: "Russia", Latitude: 59.9343, Longitude: 30.3351, Aliases: []string{"санкт-петербург", "st. petersburg"}},
+	{Name: "Kazan", Country: "Russia", Latitude: 55.7961, Longitude: 49.1064, Aliases: []string{"казань"}},
+	{Name: "Novosibirsk", Country: "Russia", Latitude: 55.0084, Longitude: 82.9357, Aliases: []string{"новосибирск"}},
+	{Name: "Yekaterinburg", Country: "Russia", Latitude: 56.8389, Longitude: 60.6057, Aliases: []string{"екатеринбург"}},
+	{Name: "Nizhny Novgorod", Country: "Russia", Latitude: 56.2965, Longitude: 43.9361, Aliases: []string{"нижний новгород"}},
+	{Name: "Sochi", Country: "Russia", Latitude: 43.5855, Longitude: 39.7231, Aliases: []string{"сочи"}},
+	{Name: "Krasnodar", Country: "Russia", Latitude: 45.0355, Longitude: 38.9753, Aliases: []string{"краснодар"}},
+	{Name: "Kaliningrad", Country: "Russia", Latitude: 54.7104, Longitude: 20.4522, Aliases: []string{"калининград"}},
+	{Name: "Vladivostok", Country: "Russia", Latitude: 43.1198, Longitude: 131.8869, Aliases: []string{"владивосток"}},
+	{Name: "Irkutsk", Country: "Russia", Latitude: 52.2870, Longitude: 104.3050, Aliases: []string{"иркутск"}},
+	{Name: "Murmansk", Country: "Russia", Latitude: 68.9585, Longitude: 33.0827, Aliases: []string{"мурманск"}},
+	{Name: "Tbilisi", Country: "Georgia", Latitude: 41.7151, Longitude: 44.8271, Aliases: []string{"тбилиси"}},
+	{Name: "Yerevan", Country: "Armenia", Latitude: 40.1792, Longitude: 44.4991, Aliases: []string{"ереван"}},
+	{Name: "Almaty", Country: "Kazakhstan", Latitude: 43.2220, Longitude: 76.8512, Aliases: []string{"алматы"}},
+	{Name: "Istanbul", Country: "Turkey", Latitude: 41.0082, Longitude: 28.9784, Aliases: []string{"стамбул"}},
+	{Name: "Berlin", Country: "Germany", Latitude: 52.5200, Longitude: 13.4050, Aliases: []string{"берлин"}},
+	{Name: "Paris", Country: "France", Latitude: 48.8566, Longitude: 2.3522, Aliases: []string{"париж"}},
+	{Name: "Barcelona", Country: "Spain", Latitude: 41.3874, Longitude: 2.1686, Aliases: []string{"барселона"}},
+	{Name: "Dubai", Country: "United Arab Emirates", Latitude: 25.2048, Longitude: 55.2708, Aliases: []string{"дубай"}},
+}
+
+// FindCity ищет в адресе название города; при нескольких совпадениях побеждает самое длинное
+func FindCity(address string) (City, bool) {
+	address = strings.ToLower(address)
+
+	var found City
+	bestLength := 0
+	for _, city := range Cities {
+		for _, name := range append([]string{city.Name}, city.Aliases...) {
+			name = strings.ToLower(name)
+			if len(name) > bestLength && strings.Contains(address, name) {
+				found = city
+				bestLength = len(name)
+			}
+		}
+	}
+
+	return found, bestLength > 0
+}
+
+// Locate детерминированно раскладывает адрес вокруг центра города: один и тот же адрес всегда
+// получает одни и те же координаты, разные адреса одного города - разные
+func Locate(city City, address string) (latitude, longitude float64) {
+	h := fnv.New64a()
+	h.Write([]byte(strings.ToLower(strings.TrimSpace(address))))
+	sum := h.Sum64()
+
+	u := float64(sum>>32) / float64(1<<32)
+	v := float64(sum&0xffffffff) / float64(1<<32)
+
+	distance := cityRadiusKm * math.Sqrt(u)
+	angle := 2 * math.Pi * v
+
+	latitude = city.Latitude + distance*math.Cos(angle)/kmPerDegree
+	longitude = city.Longitude + distance*math.Sin(angle)/(kmPerDegree*math.Cos(city.Latitude*math.Pi/180))
+	return latitude, longitude
+}
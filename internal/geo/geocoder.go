@@ -0,0 +1,28 @@
+package geo
+
+import (
+	"context"
+	"errors"
+)
+
+// kmPerDegree - длина одного градуса широты в километрах
+const kmPerDegree = 111.32
+
+var ErrAddressNotFound = errors.New("address not found")
+
+// FixtureGeocoder определяет координаты по справочнику городов без обращения к внешним сервисам
+type FixtureGeocoder struct{}
+
+func NewFixtureGeocoder() *FixtureGeocoder {
+	return &FixtureGeocoder{}
+}
+
+func (g *FixtureGeocoder) Geocode(ctx context.Context, address string) (latitude, longitude float64, err error) {
+	city, ok := FindCity(address)
+	if !ok {
+		return 0, 0, ErrAddressNotFound
+	}
+
+	latitude, longitude = Locate(city, address)
+	return latitude, longitude, nil
+}
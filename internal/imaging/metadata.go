@@ -0,0 +1,271 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+var (
+	pngSignature = []byte("\x89PNG\r\n\x1a\n")
+	// чанки с текстом и exif могут содержать gps, автора и модель камеры
+	pngMetadataChunks = map[string]bool{
+		"eXIf": true,
+		"tEXt": true,
+		"zTXt": true,
+		"iTXt": true,
+		"tIME": true,
+	}
+	webpMetadataChunks = map[string]bool{
+		"EXIF": true,
+		"XMP ": true,
+	}
+)
+
+const (
+	jpegMarkerSOI  = 0xD8
+	jpegMarkerSOS  = 0xDA
+	jpegMarkerAPP0 = 0xE0
+	jpegMarkerAPP1 = 0xE1
+	jpegMarkerIPTC = 0xED
+	jpegMarkerCOM  = 0xFE
+
+	exifOrientationTag = 0x0112
+
+	webpFlagXMP  = 0x04
+	webpFlagEXIF = 0x08
+)
+
+// StripMetadata удаляет EXIF, XMP, IPTC и текстовые метаданные без перекодирования.
+// Для JPEG сохраняется только тег ориентации, чтобы фото не переворачивалось.
+func StripMetadata(contentType string, data []byte) ([]byte, error) {
+	switch contentType {
+	case "image/jpeg":
+		return stripJPEG(data)
+	case "image/png":
+		return stripPNG(data)
+	case "image/webp":
+		return stripWebP(data)
+	default:
+		return nil, fmt.Errorf("unsupported image format: %s", contentType)
+	}
+}
+
+// Orientation возвращает значение EXIF-тега ориентации JPEG или 1, если его нет.
+func Orientation(data []byte) int {
+	orientation := 1
+	_ = walkJPEG(data, func(marker byte, segment []byte) bool {
+		if marker == jpegMarkerAPP1 {
+			if o, ok := parseExifOrientation(segment[4:]); ok {
+				orientation = o
+				return false
+			}
+		}
+		return marker != jpegMarkerSOS
+	})
+	return orientation
+}
+
+func stripJPEG(data []byte) ([]byte, error) {
+	orientation := Orientation(data)
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write([]byte{0xFF, jpegMarkerSOI})
+
+	orientationWritten := orientation == 1
+	err := walkJPEG(data, func(marker byte, segment []byte) bool {
+		if !orientationWritten && marker != jpegMarkerAPP0 {
+			out.Write(exifOrientationSegment(orientation))
+			orientationWritten = true
+		}
+
+		switch marker {
+		case jpegMarkerAPP1, jpegMarkerIPTC, jpegMarkerCOM:
+		default:
+			out.Write(segment)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return out.Bytes(), nil
+}
+
+// walkJPEG вызывает fn для каждого сегмента, включая SOS с данными до конца файла.
+func walkJPEG(data []byte, fn func(marker byte, segment []byte) bool) error {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != jpegMarkerSOI {
+		return fmt.Errorf("invalid jpeg")
+	}
+
+	pos := 2
+	for pos < len(data) {
+		if data[pos] != 0xFF {
+			return fmt.Errorf("invalid jpeg")
+		}
+		start := pos
+		for pos < len(data) && data[pos] == 0xFF {
+			pos++
+		}
+		if pos >= len(data) {
+			return fmt.Errorf("invalid jpeg")
+		}
+		marker := data[pos]
+		pos++
+
+		if marker == jpegMarkerSOS {
+			fn(marker, data[start:])
+			return nil
+		}
+
+		// маркеры без длины
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			if !fn(marker, data[start:pos]) {
+				return nil
+			}
+			continue
+		}
+
+		if pos+2 > len(data) {
+			return fmt.Errorf("invalid jpeg")
+		}
+		length := int(binary.BigEndian.Uint16(data[pos:]))
+		if length < 2 || pos+length > len(data) {
+			return fmt.Errorf("invalid jpeg")
+		}
+		pos += length
+
+		if !fn(marker, data[start:pos]) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func parseExifOrientation(payload []byte) (int, bool) {
+	if len(payload) < 14 || !bytes.HasPrefix(payload, []byte("Exif\x00\x00")) {
+		return 0, false
+	}
+	tiff := payload[6:]
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0, false
+	}
+
+	ifdOffset := int(order.Uint32(tiff[4:]))
+	if ifdOffset+2 > len(tiff) {
+		return 0, false
+	}
+	count := int(order.Uint16(tiff[ifdOffset:]))
+	for i := 0; i < count; i++ {
+		entry := ifdOffset + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0, false
+		}
+		if order.Uint16(tiff[entry:]) == exifOrientationTag {
+			value := int(order.Uint16(tiff[entry+8:]))
+			if value < 1 || value > 8 {
+				return 0, false
+			}
+			return value, true
+		}
+	}
+
+	return 0, false
+}
+
+// exifOrientationSegment собирает APP1 с единственным тегом ориентации
+func exifOrientationSegment(orientation int) []byte {
+	payload := []byte("Exif\x00\x00")
+	payload = append(payload, 'M', 'M', 0x00, 0x2A, 0x00, 0x00, 0x00, 0x08)
+	payload = binary.BigEndian.AppendUint16(payload, 1)
+	payload = binary.BigEndian.AppendUint16(payload, exifOrientationTag)
+	payload = binary.BigEndian.AppendUint16(payload, 3)
+	payload = binary.BigEndian.AppendUint32(payload, 1)
+	payload = binary.BigEndian.AppendUint16(payload, uint16(orientation))
+	payload = append(payload, 0x00, 0x00)
+	payload = binary.BigEndian.AppendUint32(payload, 0)
+
+	segment := []byte{0xFF, jpegMarkerAPP1}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(payload)+2))
+	return append(segment, payload...)
+}
+
+func stripPNG(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, fmt.Errorf("invalid png")
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(pngSignature)
+
+	pos := len(pngSignature)
+	for pos < len(data) {
+		if pos+8 > len(data) {
+			return nil, fmt.Errorf("invalid png")
+		}
+		length := int(binary.BigEndian.Uint32(data[pos:]))
+		chunkType := string(data[pos+4 : pos+8])
+		end := pos + 12 + length
+		if length < 0 || end > len(data) {
+			return nil, fmt.Errorf("invalid png")
+		}
+
+		if !pngMetadataChunks[chunkType] {
+			out.Write(data[pos:end])
+		}
+		pos = end
+
+		if chunkType == "IEND" {
+			break
+		}
+	}
+
+	return out.Bytes(), nil
+}
+
+func stripWebP(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, fmt.Errorf("invalid webp")
+	}
+
+	out := make([]byte, 12, len(data))
+	copy(out, data[:12])
+
+	pos := 12
+	for pos < len(data) {
+		if pos+8 > len(data) {
+			return nil, fmt.Errorf("invalid webp")
+		}
+		chunkType := string(data[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(data[pos+4:]))
+		end := pos + 8 + size + size%2
+		if end > len(data) {
+			if pos+8+size != len(data) {
+				return nil, fmt.Errorf("invalid webp")
+			}
+			end = len(data)
+		}
+
+		if !webpMetadataChunks[chunkType] {
+			chunkStart := len(out)
+			out = append(out, data[pos:end]...)
+			if chunkType == "VP8X" && size > 0 {
+				out[chunkStart+8] &^= webpFlagEXIF | webpFlagXMP
+			}
+		}
+		pos = end
+	}
+
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(out)-8))
+
+	return out, nil
+}
@@ -0,0 +1,79 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	_ "image/png"
+
+	_ "golang.org/x/image/webp"
+)
+
+var (
+	jpegQuality = 85
+	maxPixels   = 50_000_000
+
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+)
+
+const VariantContentType = "image/jpeg"
+
+type VariantSpec struct {
+	Name    string
+	MaxSize int
+}
+
+type Variant struct {
+	Name        string
+	Width       int
+	Height      int
+	ContentType string
+	Data        []byte
+}
+
+// GenerateVariants декодирует исходник и перекодирует его в JPEG нужных размеров.
+// Перекодирование само по себе отбрасывает все метаданные исходного файла.
+func GenerateVariants(data []byte, specs []VariantSpec) ([]Variant, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		if errors.Is(err, image.ErrFormat) {
+			return nil, ErrUnsupportedFormat
+		}
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	if config.Width*config.Height > maxPixels {
+		return nil, fmt.Errorf("image is too large: %dx%d", config.Width, config.Height)
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	src := toRGBA(decoded)
+	orientation := Orientation(data)
+
+	variants := make([]Variant, 0, len(specs))
+	for _, spec := range specs {
+		width, height := fitSize(src.Bounds().Dx(), src.Bounds().Dy(), spec.MaxSize)
+		resized := orient(resize(src, width, height), orientation)
+
+		var buf bytes.Buffer
+		if err := jpeg.Encode(&buf, resized, &jpeg.Options{Quality: jpegQuality}); err != nil {
+			return nil, fmt.Errorf("failed to encode %s variant: %w", spec.Name, err)
+		}
+
+		variants = append(variants, Variant{
+			Name:        spec.Name,
+			Width:       resized.Bounds().Dx(),
+			Height:      resized.Bounds().Dy(),
+			ContentType: VariantContentType,
+			Data:        buf.Bytes(),
+		})
+	}
+
+	return variants, nil
+}
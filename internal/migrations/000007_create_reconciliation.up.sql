@@ -0,0 +1,85 @@
+CREATE OR REPLACE FUNCTION get_payment_discrepancies()
+RETURNS TABLE (
+    discrepancy_type TEXT,
+    booking_id INTEGER,
+    payment_id INTEGER,
+    expected_amount DECIMAL(12,2),
+    actual_amount DECIMAL(12,2),
+    payments_count INTEGER
+) AS $$
+BEGIN
+    RETURN QUERY
+    -- бронирования помеченные оплаченными без завершенного платежа
+    SELECT
+        'paid_without_payment'::TEXT,
+        b.booking_id,
+        NULL::INTEGER,
+        b.total_price,
+        0.00::DECIMAL(12,2),
+        0
+    FROM bookings b
+    WHERE b.is_paid = TRUE
+      AND NOT EXISTS (
+          SELECT 1 FROM payments p
+          WHERE p.booking_id = b.booking_id AND p.payment_status = 'completed'
+      )
+
+    UNION ALL
+
+    -- бронирования с завершенным платежом но не помеченные оплаченными
+    SELECT
+        'completed_payment_not_marked_paid'::TEXT,
+        b.booking_id,
+        NULL::INTEGER,
+        b.total_price,
+        COALESCE(SUM(p.amount), 0.00)::DECIMAL(12,2),
+        COUNT(p.payment_id)::INTEGER
+    FROM bookings b
+    JOIN payments p ON p.booking_id = b.booking_id AND p.payment_status = 'completed'
+    WHERE b.is_paid = FALSE
+    GROUP BY b.booking_id, b.total_price
+
+    UNION ALL
+
+    -- завершенные платежи с суммой отличной от стоимости бронирования
+    SELECT
+        'amount_mismatch'::TEXT,
+        b.booking_id,
+        p.payment_id,
+        b.total_price,
+        p.amount,
+        1
+    FROM payments p
+    JOIN bookings b ON p.booking_id = b.booking_id
+    WHERE p.payment_status = 'completed'
+      AND p.amount <> b.total_price
+
+    UNION ALL
+
+    -- платежи без бронирования
+    SELECT
+        'orphaned_payment'::TEXT,
+        NULL::INTEGER,
+        p.payment_id,
+        NULL::DECIMAL(12,2),
+        p.amount,
+        1
+    FROM payments p
+    WHERE p.booking_id IS NULL
+
+    UNION ALL
+
+    -- несколько завершенных платежей по одному бронированию
+    SELECT
+        'duplicate_completed_payment'::TEXT,
+        b.booking_id,
+        NULL::INTEGER,
+        b.total_price,
+        SUM(p.amount)::DECIMAL(12,2),
+        COUNT(p.payment_id)::INTEGER
+    FROM bookings b
+    JOIN payments p ON p.booking_id = b.booking_id AND p.payment_status = 'completed'
+    GROUP BY b.booking_id, b.total_price
+    HAVING COUNT(p.payment_id) > 1;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,111 @@
+ALTER TABLE reviews
+    ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'published' CHECK (status IN ('pending', 'published', 'hidden')),
+    ADD COLUMN IF NOT EXISTS flag_reason TEXT,
+    ADD COLUMN IF NOT EXISTS host_response TEXT,
+    ADD COLUMN IF NOT EXISTS host_response_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_reviews_status ON reviews(status);
+
+-- в статистике объявления учитываются только опубликованные отзывы
+CREATE OR REPLACE FUNCTION update_listing_reviews_stats()
+RETURNS TRIGGER AS $$
+DECLARE
+    listing_id_val INTEGER;
+    avg_rating DECIMAL(3,2);
+    reviews_cnt INTEGER;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        listing_id_val := (SELECT listing_id FROM bookings WHERE booking_id = OLD.booking_id);
+    ELSIF TG_OP = 'INSERT' OR TG_OP = 'UPDATE' THEN
+        listing_id_val := (SELECT listing_id FROM bookings WHERE booking_id = NEW.booking_id);
+    END IF;
+    
+    SELECT COALESCE(AVG(score), 0), COUNT(*)
+    INTO avg_rating, reviews_cnt
+    FROM reviews r
+    JOIN bookings b ON r.booking_id = b.booking_id
+    WHERE b.listing_id = listing_id_val
+      AND r.status = 'published';
+    
+    UPDATE listings 
+    SET average_rating = avg_rating, reviews_count = reviews_cnt
+    WHERE id = listing_id_val;
+    
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    ELSE
+        RETURN NEW;
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+-- рейтинг хоста и отчет по бронированиям тоже видят только опубликованные отзывы
+CREATE OR REPLACE FUNCTION get_host_average_rating(host_id_param INTEGER)
+RETURNS DECIMAL(3,2) AS $$
+DECLARE
+    avg_rating DECIMAL(3,2);
+BEGIN
+    SELECT COALESCE(AVG(r.score), 0.00)
+    INTO avg_rating
+    FROM reviews r
+    JOIN bookings b ON r.booking_id = b.booking_id
+    JOIN listings l ON b.listing_id = l.id
+    WHERE l.host_id = host_id_param
+      AND r.status = 'published';
+    
+    RETURN avg_rating;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION get_bookings_report(
+    start_date_param TIMESTAMPTZ DEFAULT NULL,
+    end_date_param TIMESTAMPTZ DEFAULT NULL
+)
+RETURNS TABLE (
+    booking_id INTEGER,
+    listing_id INTEGER,
+    listing_address TEXT,
+    host_id INTEGER,
+    host_name TEXT,
+    guest_id INTEGER,
+    guest_name TEXT,
+    in_date TIMESTAMPTZ,
+    out_date TIMESTAMPTZ,
+    duration_days INTEGER,
+    total_price DECIMAL(12,2),
+    is_paid BOOLEAN,
+    payment_status TEXT,
+    payment_amount DECIMAL(12,2),
+    review_score INTEGER
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT 
+        b.booking_id,
+        b.listing_id,
+        l.address AS listing_address,
+        b.host_id,
+        (uh.first_name || ' ' || uh.second_name) AS host_name,
+        b.guest_id,
+        (ug.first_name || ' ' || ug.second_name) AS guest_name,
+        b.in_date,
+        b.out_date,
+        EXTRACT(DAY FROM (b.out_date - b.in_date))::INTEGER AS duration_days,
+        b.total_price,
+        b.is_paid,
+        COALESCE(p.payment_status, 'no_payment') AS payment_status,
+        COALESCE(p.amount, 0.00) AS payment_amount,
+        r.score AS review_score
+    FROM bookings b
+    JOIN listings l ON b.listing_id = l.id
+    JOIN users uh ON b.host_id = uh.id
+    JOIN users ug ON b.guest_id = ug.id
+    LEFT JOIN payments p ON b.booking_id = p.booking_id
+    LEFT JOIN reviews r ON b.booking_id = r.booking_id AND r.status = 'published'
+    WHERE (start_date_param IS NULL OR b.in_date >= start_date_param)
+      AND (end_date_param IS NULL OR b.out_date <= end_date_param)
+    ORDER BY b.in_date DESC;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,142 @@
+CREATE OR REPLACE FUNCTION update_listing_reviews_stats()
+RETURNS TRIGGER AS $$
+DECLARE
+    listing_id_val INTEGER;
+    avg_rating DECIMAL(3,2);
+    reviews_cnt INTEGER;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        listing_id_val := (SELECT listing_id FROM bookings WHERE booking_id = OLD.booking_id);
+    ELSIF TG_OP = 'INSERT' OR TG_OP = 'UPDATE' THEN
+        listing_id_val := (SELECT listing_id FROM bookings WHERE booking_id = NEW.booking_id);
+    END IF;
+    
+    SELECT COALESCE(AVG(score), 0), COUNT(*)
+    INTO avg_rating, reviews_cnt
+    FROM reviews r
+    JOIN bookings b ON r.booking_id = b.booking_id
+    WHERE b.listing_id = listing_id_val
+      AND r.status = 'published';
+    
+    UPDATE listings 
+    SET average_rating = avg_rating, reviews_count = reviews_cnt
+    WHERE id = listing_id_val;
+    
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    ELSE
+        RETURN NEW;
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP VIEW IF EXISTS listings_summary;
+CREATE OR REPLACE VIEW listings_summary AS
+SELECT 
+    l.id AS listing_id,
+    l.address,
+    l.host_id,
+    (u.first_name || ' ' || u.second_name) AS host_name,
+    l.price_per_night,
+    l.rooms_number,
+    l.beds_number,
+    l.is_available,
+    l.average_rating,
+    l.reviews_count,
+    l.bookings_count,
+    COALESCE(SUM(p.amount) FILTER (WHERE p.payment_status = 'completed'), 0.00) AS total_revenue,
+    COALESCE(AVG(p.amount) FILTER (WHERE p.payment_status = 'completed'), 0.00) AS avg_payment_amount,
+    COUNT(DISTINCT b.booking_id) FILTER (WHERE b.out_date > CURRENT_TIMESTAMP) AS active_bookings_count,
+    COUNT(DISTINCT f.id) AS favorites_count
+FROM listings l
+JOIN users u ON l.host_id = u.id
+LEFT JOIN bookings b ON l.id = b.listing_id
+LEFT JOIN payments p ON b.booking_id = p.booking_id
+LEFT JOIN favorites f ON l.id = f.listing_id
+GROUP BY l.id, l.address, l.host_id, u.first_name, u.second_name, 
+         l.price_per_night, l.rooms_number, l.beds_number, l.is_available,
+         l.average_rating, l.reviews_count, l.bookings_count;
+
+DROP FUNCTION IF EXISTS get_listings_statistics_report();
+CREATE OR REPLACE FUNCTION get_listings_statistics_report()
+RETURNS TABLE (
+    listing_id INTEGER,
+    address TEXT,
+    host_id INTEGER,
+    host_name TEXT,
+    price_per_night DECIMAL(10,2),
+    average_rating DECIMAL(3,2),
+    reviews_count INTEGER,
+    bookings_count INTEGER,
+    total_revenue DECIMAL(12,2),
+    is_available BOOLEAN
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT 
+        l.id AS listing_id,
+        l.address,
+        l.host_id,
+        (u.first_name || ' ' || u.second_name) AS host_name,
+        l.price_per_night,
+        l.average_rating,
+        l.reviews_count,
+        l.bookings_count,
+        COALESCE(SUM(p.amount) FILTER (WHERE p.payment_status = 'completed'), 0.00) AS total_revenue,
+        l.is_available
+    FROM listings l
+    JOIN users u ON l.host_id = u.id
+    LEFT JOIN bookings b ON l.id = b.listing_id
+    LEFT JOIN payments p ON b.booking_id = p.booking_id
+    GROUP BY l.id, l.address, l.host_id, u.first_name, u.second_name, 
+             l.price_per_night, l.average_rating, l.reviews_count, 
+             l.bookings_count, l.is_available
+    ORDER BY total_revenue DESC, l.average_rating DESC;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS get_hosts_performance_report();
+CREATE OR REPLACE FUNCTION get_hosts_performance_report()
+RETURNS TABLE (
+    host_id INTEGER,
+    host_name TEXT,
+    host_email TEXT,
+    listings_count INTEGER,
+    total_bookings INTEGER,
+    average_rating DECIMAL(3,2),
+    total_revenue DECIMAL(12,2),
+    completed_payments_count INTEGER
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT 
+        u.id AS host_id,
+        (u.first_name || ' ' || u.second_name) AS host_name,
+        u.email AS host_email,
+        COUNT(DISTINCT l.id)::INTEGER AS listings_count,
+        COUNT(DISTINCT b.booking_id)::INTEGER AS total_bookings,
+        COALESCE(AVG(r.score), 0.00) AS average_rating,
+        COALESCE(SUM(p.amount) FILTER (WHERE p.payment_status = 'completed'), 0.00) AS total_revenue,
+        COUNT(DISTINCT p.payment_id) FILTER (WHERE p.payment_status = 'completed')::INTEGER AS completed_payments_count
+    FROM users u
+    LEFT JOIN listings l ON u.id = l.host_id
+    LEFT JOIN bookings b ON l.id = b.listing_id AND b.host_id = u.id
+    LEFT JOIN reviews r ON b.booking_id = r.booking_id
+    LEFT JOIN payments p ON b.booking_id = p.booking_id
+    WHERE EXISTS (SELECT 1 FROM listings lst WHERE lst.host_id = u.id)
+    GROUP BY u.id, u.first_name, u.second_name, u.email
+    ORDER BY total_revenue DESC, average_rating DESC;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE listings
+    DROP COLUMN IF EXISTS avg_cleanliness,
+    DROP COLUMN IF EXISTS avg_accuracy,
+    DROP COLUMN IF EXISTS avg_location,
+    DROP COLUMN IF EXISTS avg_value;
+
+ALTER TABLE reviews
+    DROP COLUMN IF EXISTS cleanliness_score,
+    DROP COLUMN IF EXISTS accuracy_score,
+    DROP COLUMN IF EXISTS location_score,
+    DROP COLUMN IF EXISTS value_score;
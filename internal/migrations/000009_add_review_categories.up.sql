@@ -0,0 +1,173 @@
+ALTER TABLE reviews
+    ADD COLUMN IF NOT EXISTS cleanliness_score INTEGER CHECK (cleanliness_score >= 1 AND cleanliness_score <= 5),
+    ADD COLUMN IF NOT EXISTS accuracy_score INTEGER CHECK (accuracy_score >= 1 AND accuracy_score <= 5),
+    ADD COLUMN IF NOT EXISTS location_score INTEGER CHECK (location_score >= 1 AND location_score <= 5),
+    ADD COLUMN IF NOT EXISTS value_score INTEGER CHECK (value_score >= 1 AND value_score <= 5);
+
+ALTER TABLE listings
+    ADD COLUMN IF NOT EXISTS avg_cleanliness DECIMAL(3,2) DEFAULT 0.00 CHECK (avg_cleanliness >= 0 AND avg_cleanliness <= 5),
+    ADD COLUMN IF NOT EXISTS avg_accuracy DECIMAL(3,2) DEFAULT 0.00 CHECK (avg_accuracy >= 0 AND avg_accuracy <= 5),
+    ADD COLUMN IF NOT EXISTS avg_location DECIMAL(3,2) DEFAULT 0.00 CHECK (avg_location >= 0 AND avg_location <= 5),
+    ADD COLUMN IF NOT EXISTS avg_value DECIMAL(3,2) DEFAULT 0.00 CHECK (avg_value >= 0 AND avg_value <= 5);
+
+CREATE OR REPLACE FUNCTION update_listing_reviews_stats()
+RETURNS TRIGGER AS $$
+DECLARE
+    listing_id_val INTEGER;
+    avg_rating DECIMAL(3,2);
+    reviews_cnt INTEGER;
+    avg_cleanliness_val DECIMAL(3,2);
+    avg_accuracy_val DECIMAL(3,2);
+    avg_location_val DECIMAL(3,2);
+    avg_value_val DECIMAL(3,2);
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        listing_id_val := (SELECT listing_id FROM bookings WHERE booking_id = OLD.booking_id);
+    ELSIF TG_OP = 'INSERT' OR TG_OP = 'UPDATE' THEN
+        listing_id_val := (SELECT listing_id FROM bookings WHERE booking_id = NEW.booking_id);
+    END IF;
+    
+    SELECT COALESCE(AVG(score), 0), COUNT(*),
+           COALESCE(AVG(cleanliness_score), 0), COALESCE(AVG(accuracy_score), 0),
+           COALESCE(AVG(location_score), 0), COALESCE(AVG(value_score), 0)
+    INTO avg_rating, reviews_cnt,
+         avg_cleanliness_val, avg_accuracy_val,
+         avg_location_val, avg_value_val
+    FROM reviews r
+    JOIN bookings b ON r.booking_id = b.booking_id
+    WHERE b.listing_id = listing_id_val
+      AND r.status = 'published';
+    
+    UPDATE listings 
+    SET average_rating = avg_rating, reviews_count = reviews_cnt,
+        avg_cleanliness = avg_cleanliness_val, avg_accuracy = avg_accuracy_val,
+        avg_location = avg_location_val, avg_value = avg_value_val
+    WHERE id = listing_id_val;
+    
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    ELSE
+        RETURN NEW;
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE VIEW listings_summary AS
+SELECT 
+    l.id AS listing_id,
+    l.address,
+    l.host_id,
+    (u.first_name || ' ' || u.second_name) AS host_name,
+    l.price_per_night,
+    l.rooms_number,
+    l.beds_number,
+    l.is_available,
+    l.average_rating,
+    l.reviews_count,
+    l.bookings_count,
+    COALESCE(SUM(p.amount) FILTER (WHERE p.payment_status = 'completed'), 0.00) AS total_revenue,
+    COALESCE(AVG(p.amount) FILTER (WHERE p.payment_status = 'completed'), 0.00) AS avg_payment_amount,
+    COUNT(DISTINCT b.booking_id) FILTER (WHERE b.out_date > CURRENT_TIMESTAMP) AS active_bookings_count,
+    COUNT(DISTINCT f.id) AS favorites_count,
+    l.avg_cleanliness,
+    l.avg_accuracy,
+    l.avg_location,
+    l.avg_value
+FROM listings l
+JOIN users u ON l.host_id = u.id
+LEFT JOIN bookings b ON l.id = b.listing_id
+LEFT JOIN payments p ON b.booking_id = p.booking_id
+LEFT JOIN favorites f ON l.id = f.listing_id
+GROUP BY l.id, l.address, l.host_id, u.first_name, u.second_name, 
+         l.price_per_night, l.rooms_number, l.beds_number, l.is_available,
+         l.average_rating, l.reviews_count, l.bookings_count,
+         l.avg_cleanliness, l.avg_accuracy, l.avg_location, l.avg_value;
+
+DROP FUNCTION IF EXISTS get_listings_statistics_report();
+CREATE OR REPLACE FUNCTION get_listings_statistics_report()
+RETURNS TABLE (
+    listing_id INTEGER,
+    address TEXT,
+    host_id INTEGER,
+    host_name TEXT,
+    price_per_night DECIMAL(10,2),
+    average_rating DECIMAL(3,2),
+    reviews_count INTEGER,
+    bookings_count INTEGER,
+    total_revenue DECIMAL(12,2),
+    is_available BOOLEAN,
+    avg_cleanliness DECIMAL(3,2),
+    avg_accuracy DECIMAL(3,2),
+    avg_location DECIMAL(3,2),
+    avg_value DECIMAL(3,2)
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT 
+        l.id AS listing_id,
+        l.address,
+        l.host_id,
+        (u.first_name || ' ' || u.second_name) AS host_name,
+        l.price_per_night,
+        l.average_rating,
+        l.reviews_count,
+        l.bookings_count,
+        COALESCE(SUM(p.amount) FILTER (WHERE p.payment_status = 'completed'), 0.00) AS total_revenue,
+        l.is_available,
+        l.avg_cleanliness,
+        l.avg_accuracy,
+        l.avg_location,
+        l.avg_value
+    FROM listings l
+    JOIN users u ON l.host_id = u.id
+    LEFT JOIN bookings b ON l.id = b.listing_id
+    LEFT JOIN payments p ON b.booking_id = p.booking_id
+    GROUP BY l.id, l.address, l.host_id, u.first_name, u.second_name, 
+             l.price_per_night, l.average_rating, l.reviews_count, 
+             l.bookings_count, l.is_available,
+             l.avg_cleanliness, l.avg_accuracy, l.avg_location, l.avg_value
+    ORDER BY total_revenue DESC, l.average_rating DESC;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS get_hosts_performance_report();
+CREATE OR REPLACE FUNCTION get_hosts_performance_report()
+RETURNS TABLE (
+    host_id INTEGER,
+    host_name TEXT,
+    host_email TEXT,
+    listings_count INTEGER,
+    total_bookings INTEGER,
+    average_rating DECIMAL(3,2),
+    total_revenue DECIMAL(12,2),
+    completed_payments_count INTEGER,
+    avg_cleanliness DECIMAL(3,2),
+    avg_accuracy DECIMAL(3,2),
+    avg_location DECIMAL(3,2),
+    avg_value DECIMAL(3,2)
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT 
+        u.id AS host_id,
+        (u.first_name || ' ' || u.second_name) AS host_name,
+        u.email AS host_email,
+        COUNT(DISTINCT l.id)::INTEGER AS listings_count,
+        COUNT(DISTINCT b.booking_id)::INTEGER AS total_bookings,
+        COALESCE(AVG(r.score) FILTER (WHERE r.status = 'published'), 0.00)::DECIMAL(3,2) AS average_rating,
+        COALESCE(SUM(p.amount) FILTER (WHERE p.payment_status = 'completed'), 0.00) AS total_revenue,
+        COUNT(DISTINCT p.payment_id) FILTER (WHERE p.payment_status = 'completed')::INTEGER AS completed_payments_count,
+        COALESCE(AVG(r.cleanliness_score) FILTER (WHERE r.status = 'published'), 0.00)::DECIMAL(3,2) AS avg_cleanliness,
+        COALESCE(AVG(r.accuracy_score) FILTER (WHERE r.status = 'published'), 0.00)::DECIMAL(3,2) AS avg_accuracy,
+        COALESCE(AVG(r.location_score) FILTER (WHERE r.status = 'published'), 0.00)::DECIMAL(3,2) AS avg_location,
+        COALESCE(AVG(r.value_score) FILTER (WHERE r.status = 'published'), 0.00)::DECIMAL(3,2) AS avg_value
+    FROM users u
+    LEFT JOIN listings l ON u.id = l.host_id
+    LEFT JOIN bookings b ON l.id = b.listing_id AND b.host_id = u.id
+    LEFT JOIN reviews r ON b.booking_id = r.booking_id
+    LEFT JOIN payments p ON b.booking_id = p.booking_id
+    WHERE EXISTS (SELECT 1 FROM listings lst WHERE lst.host_id = u.id)
+    GROUP BY u.id, u.first_name, u.second_name, u.email
+    ORDER BY total_revenue DESC, average_rating DESC;
+END;
+$$ LANGUAGE plpgsql;
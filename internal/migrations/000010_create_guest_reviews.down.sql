@@ -0,0 +1,156 @@
+CREATE OR REPLACE VIEW bookings_payments_analytics AS
+SELECT 
+    b.booking_id,
+    b.listing_id,
+    l.address AS listing_address,
+    l.price_per_night,
+    b.host_id,
+    (uh.first_name || ' ' || uh.second_name) AS host_name,
+    b.guest_id,
+    (ug.first_name || ' ' || ug.second_name) AS guest_name,
+    b.in_date,
+    b.out_date,
+    EXTRACT(DAY FROM (b.out_date - b.in_date))::INTEGER AS duration_days,
+    b.total_price,
+    b.is_paid,
+    p.payment_id,
+    p.amount AS payment_amount,
+    p.payment_method,
+    p.payment_status,
+    p.paid_at,
+    r.id AS review_id,
+    r.score AS review_score,
+    r.text AS review_text,
+    CASE 
+        WHEN b.out_date < CURRENT_TIMESTAMP THEN 'completed'
+        WHEN b.in_date <= CURRENT_TIMESTAMP AND b.out_date >= CURRENT_TIMESTAMP THEN 'active'
+        ELSE 'upcoming'
+    END AS booking_status
+FROM bookings b
+JOIN listings l ON b.listing_id = l.id
+JOIN users uh ON b.host_id = uh.id
+JOIN users ug ON b.guest_id = ug.id
+LEFT JOIN payments p ON b.booking_id = p.booking_id
+LEFT JOIN reviews r ON b.booking_id = r.booking_id;
+
+CREATE OR REPLACE FUNCTION get_bookings_report(
+    start_date_param TIMESTAMPTZ DEFAULT NULL,
+    end_date_param TIMESTAMPTZ DEFAULT NULL
+)
+RETURNS TABLE (
+    booking_id INTEGER,
+    listing_id INTEGER,
+    listing_address TEXT,
+    host_id INTEGER,
+    host_name TEXT,
+    guest_id INTEGER,
+    guest_name TEXT,
+    in_date TIMESTAMPTZ,
+    out_date TIMESTAMPTZ,
+    duration_days INTEGER,
+    total_price DECIMAL(12,2),
+    is_paid BOOLEAN,
+    payment_status TEXT,
+    payment_amount DECIMAL(12,2),
+    review_score INTEGER
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT 
+        b.booking_id,
+        b.listing_id,
+        l.address AS listing_address,
+        b.host_id,
+        (uh.first_name || ' ' || uh.second_name) AS host_name,
+        b.guest_id,
+        (ug.first_name || ' ' || ug.second_name) AS guest_name,
+        b.in_date,
+        b.out_date,
+        EXTRACT(DAY FROM (b.out_date - b.in_date))::INTEGER AS duration_days,
+        b.total_price,
+        b.is_paid,
+        COALESCE(p.payment_status, 'no_payment') AS payment_status,
+        COALESCE(p.amount, 0.00) AS payment_amount,
+        r.score AS review_score
+    FROM bookings b
+    JOIN listings l ON b.listing_id = l.id
+    JOIN users uh ON b.host_id = uh.id
+    JOIN users ug ON b.guest_id = ug.id
+    LEFT JOIN payments p ON b.booking_id = p.booking_id
+    LEFT JOIN reviews r ON b.booking_id = r.booking_id AND r.status = 'published'
+    WHERE (start_date_param IS NULL OR b.in_date >= start_date_param)
+      AND (end_date_param IS NULL OR b.out_date <= end_date_param)
+    ORDER BY b.in_date DESC;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION get_host_average_rating(host_id_param INTEGER)
+RETURNS DECIMAL(3,2) AS $$
+DECLARE
+    avg_rating DECIMAL(3,2);
+BEGIN
+    SELECT COALESCE(AVG(r.score), 0.00)
+    INTO avg_rating
+    FROM reviews r
+    JOIN bookings b ON r.booking_id = b.booking_id
+    JOIN listings l ON b.listing_id = l.id
+    WHERE l.host_id = host_id_param
+      AND r.status = 'published';
+    
+    RETURN avg_rating;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION update_listing_reviews_stats()
+RETURNS TRIGGER AS $$
+DECLARE
+    listing_id_val INTEGER;
+    avg_rating DECIMAL(3,2);
+    reviews_cnt INTEGER;
+    avg_cleanliness_val DECIMAL(3,2);
+    avg_accuracy_val DECIMAL(3,2);
+    avg_location_val DECIMAL(3,2);
+    avg_value_val DECIMAL(3,2);
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        listing_id_val := (SELECT listing_id FROM bookings WHERE booking_id = OLD.booking_id);
+    ELSIF TG_OP = 'INSERT' OR TG_OP = 'UPDATE' THEN
+        listing_id_val := (SELECT listing_id FROM bookings WHERE booking_id = NEW.booking_id);
+    END IF;
+    
+    SELECT COALESCE(AVG(score), 0), COUNT(*),
+           COALESCE(AVG(cleanliness_score), 0), COALESCE(AVG(accuracy_score), 0),
+           COALESCE(AVG(location_score), 0), COALESCE(AVG(value_score), 0)
+    INTO avg_rating, reviews_cnt,
+         avg_cleanliness_val, avg_accuracy_val,
+         avg_location_val, avg_value_val
+    FROM reviews r
+    JOIN bookings b ON r.booking_id = b.booking_id
+    WHERE b.listing_id = listing_id_val
+      AND r.status = 'published';
+    
+    UPDATE listings 
+    SET average_rating = avg_rating, reviews_count = reviews_cnt,
+        avg_cleanliness = avg_cleanliness_val, avg_accuracy = avg_accuracy_val,
+        avg_location = avg_location_val, avg_value = avg_value_val
+    WHERE id = listing_id_val;
+    
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    ELSE
+        RETURN NEW;
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS refresh_revealed_reviews_stats(TIMESTAMPTZ);
+DROP TRIGGER IF EXISTS guest_reviews_update_listing_stats_trigger ON guest_reviews;
+DROP FUNCTION IF EXISTS recalculate_listing_reviews_stats(INTEGER);
+DROP FUNCTION IF EXISTS is_booking_reviews_revealed(INTEGER);
+DROP FUNCTION IF EXISTS review_reveal_after_days();
+DROP TABLE IF EXISTS review_settings;
+DROP FUNCTION IF EXISTS get_guest_reputation(INTEGER, INTEGER);
+DROP FUNCTION IF EXISTS is_booking_reviews_revealed(INTEGER, INTEGER);
+DROP TRIGGER IF EXISTS guest_reviews_audit_trigger ON guest_reviews;
+DROP INDEX IF EXISTS idx_guest_reviews_guest_id;
+DROP TABLE IF EXISTS guest_reviews CASCADE;
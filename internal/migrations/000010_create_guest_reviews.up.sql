@@ -0,0 +1,257 @@
+-- таблица отзывов хостов о гостях
+CREATE TABLE IF NOT EXISTS guest_reviews (
+    id SERIAL PRIMARY KEY,
+    booking_id INTEGER NOT NULL UNIQUE REFERENCES bookings(booking_id) ON DELETE CASCADE,
+    host_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    guest_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    text TEXT,
+    score INTEGER NOT NULL CHECK (score >= 1 AND score <= 5),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_guest_reviews_guest_id ON guest_reviews(guest_id);
+
+DROP TRIGGER IF EXISTS guest_reviews_audit_trigger ON guest_reviews;
+CREATE TRIGGER guest_reviews_audit_trigger
+    AFTER INSERT OR UPDATE OR DELETE ON guest_reviews
+    FOR EACH ROW
+    EXECUTE FUNCTION audit_trigger_function();
+
+-- отзывы обеих сторон раскрываются, когда оставлены оба или истек срок
+CREATE OR REPLACE FUNCTION is_booking_reviews_revealed(
+    booking_id_param INTEGER,
+    reveal_after_days INTEGER
+)
+RETURNS BOOLEAN AS $$
+DECLARE
+    v_out_date TIMESTAMPTZ;
+BEGIN
+    SELECT out_date INTO v_out_date
+    FROM bookings
+    WHERE booking_id = booking_id_param;
+
+    IF v_out_date IS NULL THEN
+        RETURN FALSE;
+    END IF;
+
+    IF v_out_date + make_interval(days => reveal_after_days) < CURRENT_TIMESTAMP THEN
+        RETURN TRUE;
+    END IF;
+
+    RETURN EXISTS (SELECT 1 FROM reviews WHERE booking_id = booking_id_param)
+       AND EXISTS (SELECT 1 FROM guest_reviews WHERE booking_id = booking_id_param);
+END;
+$$ LANGUAGE plpgsql STABLE;
+
+-- единственная строка с настройками отзывов: срок на отзыв и раскрытие после выезда.
+-- сервис читает его при старте, агрегаты внутри БД - через review_reveal_after_days()
+CREATE TABLE IF NOT EXISTS review_settings (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    reveal_after_days INTEGER NOT NULL CHECK (reveal_after_days > 0)
+);
+
+INSERT INTO review_settings (reveal_after_days) VALUES (14) ON CONFLICT (id) DO NOTHING;
+
+CREATE OR REPLACE FUNCTION review_reveal_after_days()
+RETURNS INTEGER AS $$
+    SELECT reveal_after_days FROM review_settings;
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION is_booking_reviews_revealed(booking_id_param INTEGER)
+RETURNS BOOLEAN AS $$
+    SELECT is_booking_reviews_revealed(booking_id_param, review_reveal_after_days());
+$$ LANGUAGE sql STABLE;
+
+CREATE OR REPLACE FUNCTION get_guest_reputation(
+    guest_id_param INTEGER,
+    reveal_after_days INTEGER
+)
+RETURNS TABLE (
+    guest_id INTEGER,
+    average_rating DECIMAL(3,2),
+    reviews_count INTEGER
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT
+        guest_id_param,
+        COALESCE(AVG(gr.score), 0.00)::DECIMAL(3,2),
+        COUNT(gr.id)::INTEGER
+    FROM guest_reviews gr
+    WHERE gr.guest_id = guest_id_param
+      AND is_booking_reviews_revealed(gr.booking_id, reveal_after_days);
+END;
+$$ LANGUAGE plpgsql;
+
+-- рейтинг объявления учитывает только опубликованные и уже раскрытые отзывы
+CREATE OR REPLACE FUNCTION recalculate_listing_reviews_stats(listing_id_param INTEGER)
+RETURNS VOID AS $$
+BEGIN
+    UPDATE listings
+    SET average_rating = s.avg_rating, reviews_count = s.reviews_cnt,
+        avg_cleanliness = s.avg_cleanliness, avg_accuracy = s.avg_accuracy,
+        avg_location = s.avg_location, avg_value = s.avg_value
+    FROM (
+        SELECT COALESCE(AVG(r.score), 0) AS avg_rating, COUNT(*) AS reviews_cnt,
+               COALESCE(AVG(r.cleanliness_score), 0) AS avg_cleanliness, COALESCE(AVG(r.accuracy_score), 0) AS avg_accuracy,
+               COALESCE(AVG(r.location_score), 0) AS avg_location, COALESCE(AVG(r.value_score), 0) AS avg_value
+        FROM reviews r
+        JOIN bookings b ON r.booking_id = b.booking_id
+        WHERE b.listing_id = listing_id_param
+          AND r.status = 'published'
+          AND is_booking_reviews_revealed(r.booking_id)
+    ) s
+    WHERE listings.id = listing_id_param;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION update_listing_reviews_stats()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        PERFORM recalculate_listing_reviews_stats((SELECT listing_id FROM bookings WHERE booking_id = OLD.booking_id));
+        RETURN OLD;
+    END IF;
+
+    PERFORM recalculate_listing_reviews_stats((SELECT listing_id FROM bookings WHERE booking_id = NEW.booking_id));
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- отзыв хоста о госте может раскрыть уже оставленный отзыв гостя
+DROP TRIGGER IF EXISTS guest_reviews_update_listing_stats_trigger ON guest_reviews;
+CREATE TRIGGER guest_reviews_update_listing_stats_trigger
+    AFTER INSERT OR DELETE ON guest_reviews
+    FOR EACH ROW
+    EXECUTE FUNCTION update_listing_reviews_stats();
+
+-- пересчитывает объявления, у которых срок раскрытия отзывов истек после since_param;
+-- при since_param = NULL пересчитываются все объявления с отзывами
+CREATE OR REPLACE FUNCTION refresh_revealed_reviews_stats(since_param TIMESTAMPTZ)
+RETURNS INTEGER AS $$
+DECLARE
+    v_listing_id INTEGER;
+    v_count INTEGER := 0;
+BEGIN
+    FOR v_listing_id IN
+        SELECT DISTINCT b.listing_id
+        FROM reviews r
+        JOIN bookings b ON r.booking_id = b.booking_id
+        WHERE since_param IS NULL
+           OR b.out_date + make_interval(days => review_reveal_after_days()) BETWEEN since_param AND CURRENT_TIMESTAMP
+    LOOP
+        PERFORM recalculate_listing_reviews_stats(v_listing_id);
+        v_count := v_count + 1;
+    END LOOP;
+
+    RETURN v_count;
+END;
+$$ LANGUAGE plpgsql;
+
+-- нераскрытые и непрошедшие модерацию оценки не должны попадать в рейтинг хоста и отчеты
+CREATE OR REPLACE FUNCTION get_host_average_rating(host_id_param INTEGER)
+RETURNS DECIMAL(3,2) AS $$
+DECLARE
+    avg_rating DECIMAL(3,2);
+BEGIN
+    SELECT COALESCE(AVG(r.score), 0.00)
+    INTO avg_rating
+    FROM reviews r
+    JOIN bookings b ON r.booking_id = b.booking_id
+    JOIN listings l ON b.listing_id = l.id
+    WHERE l.host_id = host_id_param
+      AND r.status = 'published'
+      AND is_booking_reviews_revealed(r.booking_id);
+    
+    RETURN avg_rating;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION get_bookings_report(
+    start_date_param TIMESTAMPTZ DEFAULT NULL,
+    end_date_param TIMESTAMPTZ DEFAULT NULL
+)
+RETURNS TABLE (
+    booking_id INTEGER,
+    listing_id INTEGER,
+    listing_address TEXT,
+    host_id INTEGER,
+    host_name TEXT,
+    guest_id INTEGER,
+    guest_name TEXT,
+    in_date TIMESTAMPTZ,
+    out_date TIMESTAMPTZ,
+    duration_days INTEGER,
+    total_price DECIMAL(12,2),
+    is_paid BOOLEAN,
+    payment_status TEXT,
+    payment_amount DECIMAL(12,2),
+    review_score INTEGER
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT 
+        b.booking_id,
+        b.listing_id,
+        l.address AS listing_address,
+        b.host_id,
+        (uh.first_name || ' ' || uh.second_name) AS host_name,
+        b.guest_id,
+        (ug.first_name || ' ' || ug.second_name) AS guest_name,
+        b.in_date,
+        b.out_date,
+        EXTRACT(DAY FROM (b.out_date - b.in_date))::INTEGER AS duration_days,
+        b.total_price,
+        b.is_paid,
+        COALESCE(p.payment_status, 'no_payment') AS payment_status,
+        COALESCE(p.amount, 0.00) AS payment_amount,
+        r.score AS review_score
+    FROM bookings b
+    JOIN listings l ON b.listing_id = l.id
+    JOIN users uh ON b.host_id = uh.id
+    JOIN users ug ON b.guest_id = ug.id
+    LEFT JOIN payments p ON b.booking_id = p.booking_id
+    LEFT JOIN reviews r ON b.booking_id = r.booking_id AND r.status = 'published'
+        AND is_booking_reviews_revealed(r.booking_id)
+    WHERE (start_date_param IS NULL OR b.in_date >= start_date_param)
+      AND (end_date_param IS NULL OR b.out_date <= end_date_param)
+    ORDER BY b.in_date DESC;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE VIEW bookings_payments_analytics AS
+SELECT 
+    b.booking_id,
+    b.listing_id,
+    l.address AS listing_address,
+    l.price_per_night,
+    b.host_id,
+    (uh.first_name || ' ' || uh.second_name) AS host_name,
+    b.guest_id,
+    (ug.first_name || ' ' || ug.second_name) AS guest_name,
+    b.in_date,
+    b.out_date,
+    EXTRACT(DAY FROM (b.out_date - b.in_date))::INTEGER AS duration_days,
+    b.total_price,
+    b.is_paid,
+    p.payment_id,
+    p.amount AS payment_amount,
+    p.payment_method,
+    p.payment_status,
+    p.paid_at,
+    r.id AS review_id,
+    CASE WHEN r.status = 'published' AND is_booking_reviews_revealed(b.booking_id) THEN r.score END AS review_score,
+    CASE WHEN r.status = 'published' AND is_booking_reviews_revealed(b.booking_id) THEN r.text END AS review_text,
+    CASE 
+        WHEN b.out_date < CURRENT_TIMESTAMP THEN 'completed'
+        WHEN b.in_date <= CURRENT_TIMESTAMP AND b.out_date >= CURRENT_TIMESTAMP THEN 'active'
+        ELSE 'upcoming'
+    END AS booking_status
+FROM bookings b
+JOIN listings l ON b.listing_id = l.id
+JOIN users uh ON b.host_id = uh.id
+JOIN users ug ON b.guest_id = ug.id
+LEFT JOIN payments p ON b.booking_id = p.booking_id
+LEFT JOIN reviews r ON b.booking_id = r.booking_id;
+
+SELECT refresh_revealed_reviews_stats(NULL);
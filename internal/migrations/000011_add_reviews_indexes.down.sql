@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_reviews_user_id;
+DROP INDEX IF EXISTS idx_reviews_created_at;
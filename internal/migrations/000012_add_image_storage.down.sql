@@ -0,0 +1,4 @@
+ALTER TABLE images
+    DROP COLUMN IF EXISTS storage_key,
+    DROP COLUMN IF EXISTS content_type,
+    DROP COLUMN IF EXISTS size_bytes;
@@ -0,0 +1,4 @@
+ALTER TABLE images
+    ADD COLUMN IF NOT EXISTS storage_key TEXT UNIQUE,
+    ADD COLUMN IF NOT EXISTS content_type TEXT,
+    ADD COLUMN IF NOT EXISTS size_bytes BIGINT CHECK (size_bytes >= 0);
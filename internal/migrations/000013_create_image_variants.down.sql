@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS image_variants;
+
+DROP INDEX IF EXISTS idx_images_processing_pending;
+
+ALTER TABLE images
+    DROP COLUMN IF EXISTS processing_status,
+    DROP COLUMN IF EXISTS processing_error,
+    DROP COLUMN IF EXISTS processing_started_at,
+    DROP COLUMN IF EXISTS processed_at;
@@ -0,0 +1,26 @@
+-- статус фоновой обработки загруженных изображений (NULL для внешних ссылок)
+ALTER TABLE images
+    ADD COLUMN IF NOT EXISTS processing_status TEXT
+        CHECK (processing_status IN ('pending', 'processing', 'ready', 'failed')),
+    ADD COLUMN IF NOT EXISTS processing_error TEXT,
+    ADD COLUMN IF NOT EXISTS processing_started_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS processed_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_images_processing_pending
+    ON images(image_id)
+    WHERE processing_status = 'pending';
+
+-- уменьшенные копии изображений без метаданных
+CREATE TABLE IF NOT EXISTS image_variants (
+    id SERIAL PRIMARY KEY,
+    image_id INTEGER NOT NULL REFERENCES images(image_id) ON DELETE CASCADE,
+    variant TEXT NOT NULL CHECK (variant IN ('thumbnail', 'medium', 'large')),
+    storage_key TEXT NOT NULL UNIQUE,
+    image_url TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    width INTEGER NOT NULL CHECK (width > 0),
+    height INTEGER NOT NULL CHECK (height > 0),
+    size_bytes BIGINT NOT NULL CHECK (size_bytes >= 0),
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (image_id, variant)
+);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS uq_images_listing_primary;
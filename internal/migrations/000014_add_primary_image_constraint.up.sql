@@ -0,0 +1,35 @@
+-- оставляем одно основное изображение на объявление
+UPDATE images SET is_primary = FALSE
+WHERE is_primary
+  AND image_id NOT IN (
+      SELECT DISTINCT ON (listing_id) image_id
+      FROM images
+      WHERE is_primary
+      ORDER BY listing_id, order_index, image_id
+  );
+
+-- объявления без основного изображения получают первое по порядку
+UPDATE images SET is_primary = TRUE
+WHERE image_id IN (
+    SELECT DISTINCT ON (i.listing_id) i.image_id
+    FROM images i
+    WHERE NOT EXISTS (
+        SELECT 1 FROM images p
+        WHERE p.listing_id = i.listing_id AND p.is_primary
+    )
+    ORDER BY i.listing_id, i.order_index, i.image_id
+);
+
+-- убираем дубли и пропуски в порядке галереи
+UPDATE images i
+SET order_index = r.position
+FROM (
+    SELECT image_id, ROW_NUMBER() OVER (PARTITION BY listing_id ORDER BY order_index, image_id) - 1 AS position
+    FROM images
+) r
+WHERE i.image_id = r.image_id
+  AND i.order_index IS DISTINCT FROM r.position;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_images_listing_primary
+    ON images(listing_id)
+    WHERE is_primary;
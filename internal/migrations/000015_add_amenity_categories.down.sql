@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_listing_amenities_amenity_id;
+DROP INDEX IF EXISTS idx_amenities_category;
+
+ALTER TABLE amenities
+    DROP COLUMN IF EXISTS category,
+    DROP COLUMN IF EXISTS icon_key,
+    DROP COLUMN IF EXISTS labels;
+
+DROP TABLE IF EXISTS amenity_categories;
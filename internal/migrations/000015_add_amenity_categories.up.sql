@@ -0,0 +1,47 @@
+-- категории удобств; labels хранит переводы вида {"ru": "...", "en": "..."}
+CREATE TABLE IF NOT EXISTS amenity_categories (
+    key TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    labels JSONB NOT NULL DEFAULT '{}',
+    sort_order INTEGER NOT NULL DEFAULT 0
+);
+
+INSERT INTO amenity_categories (key, name, labels, sort_order) VALUES
+    ('basics', 'Основное', '{"ru": "Основное", "en": "Basics"}', 1),
+    ('kitchen', 'Кухня', '{"ru": "Кухня", "en": "Kitchen"}', 2),
+    ('climate', 'Климат', '{"ru": "Климат", "en": "Heating and cooling"}', 3),
+    ('entertainment', 'Развлечения', '{"ru": "Развлечения", "en": "Entertainment"}', 4),
+    ('outdoor', 'На улице', '{"ru": "На улице", "en": "Outdoor"}', 5),
+    ('safety', 'Безопасность', '{"ru": "Безопасность", "en": "Safety"}', 6),
+    ('accessibility', 'Доступная среда', '{"ru": "Доступная среда", "en": "Accessibility"}', 7)
+ON CONFLICT (key) DO NOTHING;
+
+ALTER TABLE amenities
+    ADD COLUMN IF NOT EXISTS category TEXT REFERENCES amenity_categories(key) ON UPDATE CASCADE ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS icon_key TEXT,
+    ADD COLUMN IF NOT EXISTS labels JSONB NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS idx_amenities_category ON amenities(category);
+CREATE INDEX IF NOT EXISTS idx_listing_amenities_amenity_id ON listing_amenities(amenity_id);
+
+-- разметка уже существующих удобств
+UPDATE amenities a
+SET category = v.category, icon_key = v.icon_key, labels = v.labels::jsonb
+FROM (VALUES
+    ('WiFi', 'basics', 'wifi', '{"ru": "WiFi", "en": "Wifi"}'),
+    ('Кухня', 'kitchen', 'kitchen', '{"ru": "Кухня", "en": "Kitchen"}'),
+    ('Парковка', 'basics', 'parking', '{"ru": "Парковка", "en": "Parking"}'),
+    ('Кондиционер', 'climate', 'air_conditioning', '{"ru": "Кондиционер", "en": "Air conditioning"}'),
+    ('Отопление', 'climate', 'heating', '{"ru": "Отопление", "en": "Heating"}'),
+    ('Стиральная машина', 'basics', 'washer', '{"ru": "Стиральная машина", "en": "Washer"}'),
+    ('Фен', 'basics', 'hair_dryer', '{"ru": "Фен", "en": "Hair dryer"}'),
+    ('Телевизор', 'entertainment', 'tv', '{"ru": "Телевизор", "en": "TV"}'),
+    ('Микроволновка', 'kitchen', 'microwave', '{"ru": "Микроволновка", "en": "Microwave"}'),
+    ('Холодильник', 'kitchen', 'refrigerator', '{"ru": "Холодильник", "en": "Refrigerator"}'),
+    ('Посудомоечная машина', 'kitchen', 'dishwasher', '{"ru": "Посудомоечная машина", "en": "Dishwasher"}'),
+    ('Балкон/Терраса', 'outdoor', 'balcony', '{"ru": "Балкон/Терраса", "en": "Balcony or terrace"}'),
+    ('Бассейн', 'outdoor', 'pool', '{"ru": "Бассейн", "en": "Pool"}'),
+    ('Джакузи', 'outdoor', 'hot_tub', '{"ru": "Джакузи", "en": "Hot tub"}'),
+    ('Камин', 'climate', 'fireplace', '{"ru": "Камин", "en": "Fireplace"}')
+) AS v(name, category, icon_key, labels)
+WHERE a.name = v.name;
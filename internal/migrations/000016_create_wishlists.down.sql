@@ -0,0 +1,54 @@
+CREATE OR REPLACE VIEW listings_summary AS
+SELECT 
+    l.id AS listing_id,
+    l.address,
+    l.host_id,
+    (u.first_name || ' ' || u.second_name) AS host_name,
+    l.price_per_night,
+    l.rooms_number,
+    l.beds_number,
+    l.is_available,
+    l.average_rating,
+    l.reviews_count,
+    l.bookings_count,
+    COALESCE(SUM(p.amount) FILTER (WHERE p.payment_status = 'completed'), 0.00) AS total_revenue,
+    COALESCE(AVG(p.amount) FILTER (WHERE p.payment_status = 'completed'), 0.00) AS avg_payment_amount,
+    COUNT(DISTINCT b.booking_id) FILTER (WHERE b.out_date > CURRENT_TIMESTAMP) AS active_bookings_count,
+    COUNT(DISTINCT f.id) AS favorites_count,
+    l.avg_cleanliness,
+    l.avg_accuracy,
+    l.avg_location,
+    l.avg_value
+FROM listings l
+JOIN users u ON l.host_id = u.id
+LEFT JOIN bookings b ON l.id = b.listing_id
+LEFT JOIN payments p ON b.booking_id = p.booking_id
+LEFT JOIN favorites f ON l.id = f.listing_id
+GROUP BY l.id, l.address, l.host_id, u.first_name, u.second_name, 
+         l.price_per_night, l.rooms_number, l.beds_number, l.is_available,
+         l.average_rating, l.reviews_count, l.bookings_count,
+         l.avg_cleanliness, l.avg_accuracy, l.avg_location, l.avg_value;
+
+DROP INDEX IF EXISTS idx_favorites_user_listing;
+
+ALTER TABLE favorites DROP CONSTRAINT IF EXISTS favorites_wishlist_id_listing_id_key;
+
+-- при откате из нескольких списков остается одна запись на пару пользователь-объявление
+DELETE FROM favorites f
+USING favorites d
+WHERE f.user_id = d.user_id
+  AND f.listing_id = d.listing_id
+  AND f.id > d.id;
+
+ALTER TABLE favorites ADD CONSTRAINT favorites_user_id_listing_id_key UNIQUE (user_id, listing_id);
+
+DROP TRIGGER IF EXISTS favorites_default_wishlist_trigger ON favorites;
+DROP FUNCTION IF EXISTS favorites_default_wishlist();
+
+ALTER TABLE favorites
+    DROP COLUMN IF EXISTS wishlist_id,
+    DROP COLUMN IF EXISTS note,
+    DROP COLUMN IF EXISTS created_at;
+
+DROP TRIGGER IF EXISTS wishlists_audit_trigger ON wishlists;
+DROP TABLE IF EXISTS wishlists;
@@ -0,0 +1,99 @@
+-- именованные списки избранного
+CREATE TABLE IF NOT EXISTS wishlists (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL CHECK (length(name) > 0),
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    share_token TEXT UNIQUE,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_wishlists_user_id ON wishlists(user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_wishlists_user_default ON wishlists(user_id) WHERE is_default;
+
+DROP TRIGGER IF EXISTS wishlists_audit_trigger ON wishlists;
+CREATE TRIGGER wishlists_audit_trigger
+    AFTER INSERT OR UPDATE OR DELETE ON wishlists
+    FOR EACH ROW
+    EXECUTE FUNCTION audit_trigger_function();
+
+ALTER TABLE favorites
+    ADD COLUMN IF NOT EXISTS wishlist_id INTEGER REFERENCES wishlists(id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS note TEXT,
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP;
+
+-- существующее избранное переезжает в список по умолчанию
+INSERT INTO wishlists (user_id, name, is_default)
+SELECT DISTINCT user_id, 'Избранное', TRUE
+FROM favorites
+ON CONFLICT DO NOTHING;
+
+UPDATE favorites f
+SET wishlist_id = w.id
+FROM wishlists w
+WHERE w.user_id = f.user_id AND w.is_default AND f.wishlist_id IS NULL;
+
+-- вставка без списка попадает в список по умолчанию
+CREATE OR REPLACE FUNCTION favorites_default_wishlist()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.wishlist_id IS NULL THEN
+        INSERT INTO wishlists (user_id, name, is_default)
+        VALUES (NEW.user_id, 'Избранное', TRUE)
+        ON CONFLICT (user_id) WHERE is_default DO NOTHING;
+
+        SELECT id INTO NEW.wishlist_id
+        FROM wishlists
+        WHERE user_id = NEW.user_id AND is_default;
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS favorites_default_wishlist_trigger ON favorites;
+CREATE TRIGGER favorites_default_wishlist_trigger
+    BEFORE INSERT ON favorites
+    FOR EACH ROW
+    EXECUTE FUNCTION favorites_default_wishlist();
+
+ALTER TABLE favorites ALTER COLUMN wishlist_id SET NOT NULL;
+
+-- одно объявление может лежать в нескольких списках
+ALTER TABLE favorites DROP CONSTRAINT IF EXISTS favorites_user_id_listing_id_key;
+ALTER TABLE favorites ADD CONSTRAINT favorites_wishlist_id_listing_id_key UNIQUE (wishlist_id, listing_id);
+
+CREATE INDEX IF NOT EXISTS idx_favorites_user_listing ON favorites(user_id, listing_id);
+
+-- объявление в нескольких списках одного пользователя считается один раз
+CREATE OR REPLACE VIEW listings_summary AS
+SELECT 
+    l.id AS listing_id,
+    l.address,
+    l.host_id,
+    (u.first_name || ' ' || u.second_name) AS host_name,
+    l.price_per_night,
+    l.rooms_number,
+    l.beds_number,
+    l.is_available,
+    l.average_rating,
+    l.reviews_count,
+    l.bookings_count,
+    COALESCE(SUM(p.amount) FILTER (WHERE p.payment_status = 'completed'), 0.00) AS total_revenue,
+    COALESCE(AVG(p.amount) FILTER (WHERE p.payment_status = 'completed'), 0.00) AS avg_payment_amount,
+    COUNT(DISTINCT b.booking_id) FILTER (WHERE b.out_date > CURRENT_TIMESTAMP) AS active_bookings_count,
+    COUNT(DISTINCT f.user_id) AS favorites_count,
+    l.avg_cleanliness,
+    l.avg_accuracy,
+    l.avg_location,
+    l.avg_value
+FROM listings l
+JOIN users u ON l.host_id = u.id
+LEFT JOIN bookings b ON l.id = b.listing_id
+LEFT JOIN payments p ON b.booking_id = p.booking_id
+LEFT JOIN favorites f ON l.id = f.listing_id
+GROUP BY l.id, l.address, l.host_id, u.first_name, u.second_name, 
+         l.price_per_night, l.rooms_number, l.beds_number, l.is_available,
+         l.average_rating, l.reviews_count, l.bookings_count,
+         l.avg_cleanliness, l.avg_accuracy, l.avg_location, l.avg_value;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_notifications_undelivered;
+DROP INDEX IF EXISTS idx_favorites_listing_id;
+DROP TABLE IF EXISTS notifications;
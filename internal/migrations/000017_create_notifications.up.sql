@@ -0,0 +1,22 @@
+-- уведомления пользователей об изменениях избранных объявлений
+CREATE TABLE IF NOT EXISTS notifications (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    listing_id INTEGER NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    type TEXT NOT NULL CHECK (type IN ('price_drop', 'availability')),
+    message TEXT NOT NULL,
+    old_price DECIMAL(10,2),
+    new_price DECIMAL(10,2),
+    is_read BOOLEAN NOT NULL DEFAULT FALSE,
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    -- доставляет фоновый воркер: новые сразу, неудачные повторно начиная с next_delivery_at
+    delivery_attempts INTEGER NOT NULL DEFAULT 0,
+    delivery_error TEXT,
+    next_delivery_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created ON notifications(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_favorites_listing_id ON favorites(listing_id);
+CREATE INDEX IF NOT EXISTS idx_notifications_undelivered ON notifications(next_delivery_at)
+    WHERE delivered_at IS NULL;
@@ -0,0 +1,7 @@
+DELETE FROM notifications WHERE type = 'message';
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_type_check
+    CHECK (type IN ('price_drop', 'availability'));
+
+DROP TABLE IF EXISTS messages;
+DROP TABLE IF EXISTS conversations;
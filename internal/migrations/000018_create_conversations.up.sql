@@ -0,0 +1,34 @@
+-- переписка гостя с хозяином жилья, при необходимости привязанная к бронированию
+CREATE TABLE IF NOT EXISTS conversations (
+    id SERIAL PRIMARY KEY,
+    listing_id INTEGER NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    guest_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    host_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    booking_id INTEGER REFERENCES bookings(booking_id) ON DELETE CASCADE,
+    last_message_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    CHECK (guest_id <> host_id)
+);
+
+-- одна переписка на гостя и объявление (и бронирование, если оно указано)
+CREATE UNIQUE INDEX IF NOT EXISTS uq_conversations_thread
+    ON conversations(listing_id, guest_id, COALESCE(booking_id, 0));
+CREATE INDEX IF NOT EXISTS idx_conversations_guest ON conversations(guest_id, last_message_at DESC);
+CREATE INDEX IF NOT EXISTS idx_conversations_host ON conversations(host_id, last_message_at DESC);
+
+CREATE TABLE IF NOT EXISTS messages (
+    id SERIAL PRIMARY KEY,
+    conversation_id INTEGER NOT NULL REFERENCES conversations(id) ON DELETE CASCADE,
+    sender_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    text TEXT NOT NULL CHECK (length(text) > 0),
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_messages_conversation_created ON messages(conversation_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_messages_unread ON messages(conversation_id, sender_id) WHERE read_at IS NULL;
+
+-- уведомления о новых сообщениях
+ALTER TABLE notifications DROP CONSTRAINT IF EXISTS notifications_type_check;
+ALTER TABLE notifications ADD CONSTRAINT notifications_type_check
+    CHECK (type IN ('price_drop', 'availability', 'message'));
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_listings_point;
+DROP INDEX IF EXISTS idx_listings_earth;
+
+ALTER TABLE listings DROP CONSTRAINT IF EXISTS listings_coordinates_check;
+ALTER TABLE listings DROP COLUMN IF EXISTS longitude;
+ALTER TABLE listings DROP COLUMN IF EXISTS latitude;
+
+DROP EXTENSION IF EXISTS earthdistance;
+DROP EXTENSION IF EXISTS cube;
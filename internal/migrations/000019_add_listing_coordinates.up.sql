@@ -0,0 +1,15 @@
+-- расстояния на сфере для поиска в радиусе
+CREATE EXTENSION IF NOT EXISTS cube;
+CREATE EXTENSION IF NOT EXISTS earthdistance;
+
+ALTER TABLE listings
+    ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION CHECK (latitude >= -90 AND latitude <= 90),
+    ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION CHECK (longitude >= -180 AND longitude <= 180);
+
+ALTER TABLE listings ADD CONSTRAINT listings_coordinates_check
+    CHECK ((latitude IS NULL) = (longitude IS NULL));
+
+-- поиск в радиусе через earth_box
+CREATE INDEX IF NOT EXISTS idx_listings_earth ON listings USING GIST (ll_to_earth(latitude, longitude));
+-- поиск по прямоугольнику карты
+CREATE INDEX IF NOT EXISTS idx_listings_point ON listings USING GIST (point(longitude, latitude));
@@ -0,0 +1,15 @@
+DROP FUNCTION IF EXISTS get_cities_statistics_report(TEXT);
+
+DROP INDEX IF EXISTS idx_listings_country;
+DROP INDEX IF EXISTS idx_listings_city;
+
+DROP TRIGGER IF EXISTS listings_fill_address_parts_trigger ON listings;
+DROP FUNCTION IF EXISTS listings_fill_address_parts();
+DROP FUNCTION IF EXISTS parse_listing_address(TEXT);
+
+ALTER TABLE listings
+    DROP COLUMN IF EXISTS country,
+    DROP COLUMN IF EXISTS postal_code,
+    DROP COLUMN IF EXISTS region,
+    DROP COLUMN IF EXISTS city,
+    DROP COLUMN IF EXISTS street;
@@ -0,0 +1,118 @@
+ALTER TABLE listings
+    ADD COLUMN IF NOT EXISTS street TEXT,
+    ADD COLUMN IF NOT EXISTS city TEXT,
+    ADD COLUMN IF NOT EXISTS region TEXT,
+    ADD COLUMN IF NOT EXISTS postal_code TEXT,
+    ADD COLUMN IF NOT EXISTS country TEXT;
+
+-- разбор адреса вида "улица, город[, регион индекс][, страна]"; что не удалось распознать, остается NULL
+CREATE OR REPLACE FUNCTION parse_listing_address(p_address TEXT)
+RETURNS TABLE (
+    street TEXT,
+    city TEXT,
+    region TEXT,
+    postal_code TEXT,
+    country TEXT
+) AS $$
+DECLARE
+    parts TEXT[];
+    parts_count INTEGER;
+    region_match TEXT[];
+BEGIN
+    parts := array_remove(ARRAY(
+        SELECT NULLIF(btrim(part), '') FROM unnest(string_to_array(p_address, ',')) AS part
+    ), NULL);
+    parts_count := COALESCE(array_length(parts, 1), 0);
+
+    IF parts_count >= 1 THEN
+        street := parts[1];
+    END IF;
+    IF parts_count >= 2 THEN
+        city := parts[2];
+    END IF;
+
+    IF parts_count >= 3 THEN
+        -- последняя часть с индексом - это регион, без индекса - страна
+        region_match := regexp_match(parts[parts_count], '^(.*?)\s*(\d{4,6}(?:-\d{4})?)$');
+        IF region_match IS NOT NULL THEN
+            region := NULLIF(region_match[1], '');
+            postal_code := region_match[2];
+        ELSE
+            country := parts[parts_count];
+            IF parts_count >= 4 THEN
+                region_match := regexp_match(parts[parts_count - 1], '^(.*?)\s*(\d{4,6}(?:-\d{4})?)$');
+                IF region_match IS NOT NULL THEN
+                    region := NULLIF(region_match[1], '');
+                    postal_code := region_match[2];
+                ELSE
+                    region := parts[parts_count - 1];
+                END IF;
+            END IF;
+        END IF;
+    END IF;
+
+    RETURN NEXT;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+UPDATE listings
+SET (street, city, region, postal_code, country) = (SELECT * FROM parse_listing_address(address));
+
+-- если части адреса не переданы явно, они разбираются из address
+CREATE OR REPLACE FUNCTION listings_fill_address_parts()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.street IS NULL AND NEW.city IS NULL AND NEW.region IS NULL
+        AND NEW.postal_code IS NULL AND NEW.country IS NULL THEN
+        SELECT a.street, a.city, a.region, a.postal_code, a.country
+        INTO NEW.street, NEW.city, NEW.region, NEW.postal_code, NEW.country
+        FROM parse_listing_address(NEW.address) a;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS listings_fill_address_parts_trigger ON listings;
+CREATE TRIGGER listings_fill_address_parts_trigger
+    BEFORE INSERT OR UPDATE OF address ON listings
+    FOR EACH ROW
+    EXECUTE FUNCTION listings_fill_address_parts();
+
+CREATE INDEX IF NOT EXISTS idx_listings_city ON listings(lower(city));
+CREATE INDEX IF NOT EXISTS idx_listings_country ON listings(lower(country));
+
+CREATE OR REPLACE FUNCTION get_cities_statistics_report(p_country TEXT DEFAULT NULL)
+RETURNS TABLE (
+    country TEXT,
+    city TEXT,
+    listings_count INTEGER,
+    bookings_count INTEGER,
+    total_revenue DECIMAL(12,2),
+    average_rating DECIMAL(3,2),
+    reviews_count INTEGER
+) AS $$
+BEGIN
+    RETURN QUERY
+    WITH listing_revenue AS (
+        SELECT b.listing_id,
+            COALESCE(SUM(p.amount) FILTER (WHERE p.payment_status = 'completed'), 0.00) AS revenue
+        FROM bookings b
+        JOIN payments p ON b.booking_id = p.booking_id
+        GROUP BY b.listing_id
+    )
+    SELECT
+        l.country,
+        l.city,
+        COUNT(*)::INTEGER AS listings_count,
+        COALESCE(SUM(l.bookings_count), 0)::INTEGER AS bookings_count,
+        COALESCE(SUM(lr.revenue), 0.00)::DECIMAL(12,2) AS total_revenue,
+        -- средняя оценка взвешивается по числу отзывов
+        COALESCE(SUM(l.average_rating * l.reviews_count) / NULLIF(SUM(l.reviews_count), 0), 0.00)::DECIMAL(3,2) AS average_rating,
+        COALESCE(SUM(l.reviews_count), 0)::INTEGER AS reviews_count
+    FROM listings l
+    LEFT JOIN listing_revenue lr ON lr.listing_id = l.id
+    WHERE p_country IS NULL OR lower(l.country) = lower(p_country)
+    GROUP BY l.country, l.city
+    ORDER BY total_revenue DESC, average_rating DESC;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,35 @@
+DROP INDEX IF EXISTS idx_listings_search_vector;
+
+CREATE OR REPLACE FUNCTION refresh_revealed_reviews_stats(since_param TIMESTAMPTZ)
+RETURNS INTEGER AS $$
+DECLARE
+    v_listing_id INTEGER;
+    v_count INTEGER := 0;
+BEGIN
+    FOR v_listing_id IN
+        SELECT DISTINCT b.listing_id
+        FROM reviews r
+        JOIN bookings b ON r.booking_id = b.booking_id
+        WHERE since_param IS NULL
+           OR b.out_date + make_interval(days => review_reveal_after_days()) BETWEEN since_param AND CURRENT_TIMESTAMP
+    LOOP
+        PERFORM recalculate_listing_reviews_stats(v_listing_id);
+        v_count := v_count + 1;
+    END LOOP;
+
+    RETURN v_count;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS guest_reviews_search_vector_trigger ON guest_reviews;
+DROP TRIGGER IF EXISTS reviews_search_vector_trigger ON reviews;
+DROP FUNCTION IF EXISTS update_listing_search_vector();
+DROP TRIGGER IF EXISTS listings_search_vector_trigger ON listings;
+DROP FUNCTION IF EXISTS listings_search_vector_trigger();
+DROP FUNCTION IF EXISTS build_listing_search_vector(listings);
+DROP FUNCTION IF EXISTS get_listing_reviews_text(INTEGER);
+
+ALTER TABLE listings
+    DROP COLUMN IF EXISTS search_vector,
+    DROP COLUMN IF EXISTS description,
+    DROP COLUMN IF EXISTS title;
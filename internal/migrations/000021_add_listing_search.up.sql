@@ -0,0 +1,106 @@
+ALTER TABLE listings
+    ADD COLUMN IF NOT EXISTS title TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS description TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR;
+
+-- текст опубликованных и уже раскрытых отзывов; используется и в индексе, и в сниппете выдачи
+CREATE OR REPLACE FUNCTION get_listing_reviews_text(listing_id_param INTEGER)
+RETURNS TEXT AS $$
+    SELECT string_agg(r.text, ' ' ORDER BY r.id)
+    FROM reviews r
+    JOIN bookings b ON r.booking_id = b.booking_id
+    WHERE b.listing_id = listing_id_param
+      AND r.status = 'published'
+      AND r.text IS NOT NULL
+      AND is_booking_reviews_revealed(r.booking_id);
+$$ LANGUAGE sql STABLE;
+
+-- конфигурация russian стеммит и кириллицу, и латиницу (english_stem для ascii-слов)
+-- веса: заголовок A, адрес B, описание C, опубликованные отзывы D
+CREATE OR REPLACE FUNCTION build_listing_search_vector(l listings)
+RETURNS TSVECTOR AS $$
+BEGIN
+    RETURN setweight(to_tsvector('russian', COALESCE(l.title, '')), 'A')
+        || setweight(to_tsvector('russian', concat_ws(' ', l.address, l.city, l.region, l.country)), 'B')
+        || setweight(to_tsvector('russian', COALESCE(l.description, '')), 'C')
+        || setweight(to_tsvector('russian', COALESCE(get_listing_reviews_text(l.id), '')), 'D');
+END;
+$$ LANGUAGE plpgsql STABLE;
+
+CREATE OR REPLACE FUNCTION listings_search_vector_trigger()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector := build_listing_search_vector(NEW);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS listings_search_vector_trigger ON listings;
+CREATE TRIGGER listings_search_vector_trigger
+    BEFORE INSERT OR UPDATE OF title, description, address, city, region, country ON listings
+    FOR EACH ROW
+    EXECUTE FUNCTION listings_search_vector_trigger();
+
+CREATE OR REPLACE FUNCTION update_listing_search_vector()
+RETURNS TRIGGER AS $$
+DECLARE
+    listing_id_val INTEGER;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        listing_id_val := (SELECT listing_id FROM bookings WHERE booking_id = OLD.booking_id);
+    ELSIF TG_OP = 'INSERT' OR TG_OP = 'UPDATE' THEN
+        listing_id_val := (SELECT listing_id FROM bookings WHERE booking_id = NEW.booking_id);
+    END IF;
+
+    UPDATE listings l
+    SET search_vector = build_listing_search_vector(l)
+    WHERE l.id = listing_id_val;
+
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    ELSE
+        RETURN NEW;
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+
+-- отзыв попадает в поиск после публикации и пропадает после скрытия
+DROP TRIGGER IF EXISTS reviews_search_vector_trigger ON reviews;
+CREATE TRIGGER reviews_search_vector_trigger
+    AFTER INSERT OR DELETE OR UPDATE OF text, status ON reviews
+    FOR EACH ROW
+    EXECUTE FUNCTION update_listing_search_vector();
+
+-- отзыв хоста о госте может раскрыть отзыв гостя, а значит и добавить его текст в поиск
+DROP TRIGGER IF EXISTS guest_reviews_search_vector_trigger ON guest_reviews;
+CREATE TRIGGER guest_reviews_search_vector_trigger
+    AFTER INSERT OR DELETE ON guest_reviews
+    FOR EACH ROW
+    EXECUTE FUNCTION update_listing_search_vector();
+
+-- по истечении срока раскрытия отзывы попадают не только в рейтинг, но и в поисковый индекс
+CREATE OR REPLACE FUNCTION refresh_revealed_reviews_stats(since_param TIMESTAMPTZ)
+RETURNS INTEGER AS $$
+DECLARE
+    v_listing_id INTEGER;
+    v_count INTEGER := 0;
+BEGIN
+    FOR v_listing_id IN
+        SELECT DISTINCT b.listing_id
+        FROM reviews r
+        JOIN bookings b ON r.booking_id = b.booking_id
+        WHERE since_param IS NULL
+           OR b.out_date + make_interval(days => review_reveal_after_days()) BETWEEN since_param AND CURRENT_TIMESTAMP
+    LOOP
+        PERFORM recalculate_listing_reviews_stats(v_listing_id);
+        UPDATE listings l SET search_vector = build_listing_search_vector(l) WHERE l.id = v_listing_id;
+        v_count := v_count + 1;
+    END LOOP;
+
+    RETURN v_count;
+END;
+$$ LANGUAGE plpgsql;
+
+UPDATE listings l SET search_vector = build_listing_search_vector(l);
+
+CREATE INDEX IF NOT EXISTS idx_listings_search_vector ON listings USING GIN (search_vector);
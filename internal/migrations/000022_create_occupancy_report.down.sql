@@ -0,0 +1,2 @@
+DROP FUNCTION IF EXISTS get_hosts_occupancy_report(TIMESTAMPTZ, TIMESTAMPTZ);
+DROP FUNCTION IF EXISTS get_listings_occupancy_report(TIMESTAMPTZ, TIMESTAMPTZ);
@@ -0,0 +1,95 @@
+-- загрузка объявлений за период: занятые ночи, ADR и RevPAR
+-- брони, пересекающие границы периода, учитываются только ночами внутри периода,
+-- выручка по ним распределяется пропорционально этим ночам;
+-- пересекающиеся брони могут дать больше ночей, чем в периоде: занятые ночи ограничиваются периодом,
+-- и от них же считаются загрузка и ADR
+CREATE OR REPLACE FUNCTION get_listings_occupancy_report(
+    start_date_param TIMESTAMPTZ,
+    end_date_param TIMESTAMPTZ
+)
+RETURNS TABLE (
+    listing_id INTEGER,
+    address TEXT,
+    host_id INTEGER,
+    host_name TEXT,
+    available_nights INTEGER,
+    booked_nights INTEGER,
+    occupancy_rate DECIMAL(5,4),
+    revenue DECIMAL(12,2),
+    adr DECIMAL(12,2),
+    revpar DECIMAL(12,2)
+) AS $$
+DECLARE
+    period_nights INTEGER := GREATEST(end_date_param::DATE - start_date_param::DATE, 0);
+BEGIN
+    RETURN QUERY
+    WITH booking_nights AS (
+        SELECT
+            b.listing_id,
+            GREATEST(LEAST(b.out_date, end_date_param)::DATE - GREATEST(b.in_date, start_date_param)::DATE, 0) AS nights,
+            GREATEST(b.out_date::DATE - b.in_date::DATE, 1) AS total_nights,
+            b.total_price
+        FROM bookings b
+        WHERE b.in_date < end_date_param
+          AND b.out_date > start_date_param
+    ),
+    listing_nights AS (
+        SELECT
+            bn.listing_id,
+            LEAST(SUM(bn.nights), period_nights) AS nights,
+            SUM(bn.total_price * bn.nights / bn.total_nights) AS revenue
+        FROM booking_nights bn
+        GROUP BY bn.listing_id
+    )
+    SELECT
+        l.id AS listing_id,
+        l.address,
+        l.host_id,
+        (u.first_name || ' ' || u.second_name) AS host_name,
+        period_nights AS available_nights,
+        COALESCE(ln.nights, 0)::INTEGER AS booked_nights,
+        COALESCE(ln.nights::DECIMAL / NULLIF(period_nights, 0), 0)::DECIMAL(5,4) AS occupancy_rate,
+        COALESCE(ln.revenue, 0.00)::DECIMAL(12,2) AS revenue,
+        COALESCE(ln.revenue / NULLIF(ln.nights, 0), 0.00)::DECIMAL(12,2) AS adr,
+        COALESCE(ln.revenue / NULLIF(period_nights, 0), 0.00)::DECIMAL(12,2) AS revpar
+    FROM listings l
+    JOIN users u ON u.id = l.host_id
+    LEFT JOIN listing_nights ln ON ln.listing_id = l.id
+    -- сортировка по номерам колонок: их имена совпадают с OUT-параметрами функции
+    ORDER BY 10 DESC, 7 DESC, 1;
+END;
+$$ LANGUAGE plpgsql;
+
+-- загрузка по хостам: ночи всех объявлений хоста суммируются
+CREATE OR REPLACE FUNCTION get_hosts_occupancy_report(
+    start_date_param TIMESTAMPTZ,
+    end_date_param TIMESTAMPTZ
+)
+RETURNS TABLE (
+    host_id INTEGER,
+    host_name TEXT,
+    listings_count INTEGER,
+    available_nights INTEGER,
+    booked_nights INTEGER,
+    occupancy_rate DECIMAL(5,4),
+    revenue DECIMAL(12,2),
+    adr DECIMAL(12,2),
+    revpar DECIMAL(12,2)
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT
+        r.host_id,
+        r.host_name,
+        COUNT(*)::INTEGER AS listings_count,
+        SUM(r.available_nights)::INTEGER AS available_nights,
+        SUM(r.booked_nights)::INTEGER AS booked_nights,
+        COALESCE(SUM(r.booked_nights)::DECIMAL / NULLIF(SUM(r.available_nights), 0), 0)::DECIMAL(5,4) AS occupancy_rate,
+        SUM(r.revenue)::DECIMAL(12,2) AS revenue,
+        COALESCE(SUM(r.revenue) / NULLIF(SUM(r.booked_nights), 0), 0.00)::DECIMAL(12,2) AS adr,
+        COALESCE(SUM(r.revenue) / NULLIF(SUM(r.available_nights), 0), 0.00)::DECIMAL(12,2) AS revpar
+    FROM get_listings_occupancy_report(start_date_param, end_date_param) r
+    GROUP BY r.host_id, r.host_name
+    ORDER BY 9 DESC, 6 DESC, 1;
+END;
+$$ LANGUAGE plpgsql;
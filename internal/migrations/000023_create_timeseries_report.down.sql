@@ -0,0 +1,37 @@
+DROP INDEX IF EXISTS idx_audit_log_table_record;
+DROP INDEX IF EXISTS idx_payments_paid_at;
+DROP INDEX IF EXISTS idx_bookings_in_date;
+
+DROP FUNCTION IF EXISTS get_timeseries_report(TEXT, TEXT, TIMESTAMPTZ, TIMESTAMPTZ, INTEGER, INTEGER);
+
+CREATE OR REPLACE PROCEDURE cancel_booking_with_refund(
+    p_booking_id INTEGER
+)
+LANGUAGE plpgsql
+AS $$
+DECLARE
+    v_payment_id INTEGER;
+    v_payment_status TEXT;
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM bookings WHERE booking_id = p_booking_id) THEN
+        RAISE EXCEPTION 'Booking with ID % not found', p_booking_id;
+    END IF;
+    
+    SELECT payment_id, payment_status INTO v_payment_id, v_payment_status
+    FROM payments
+    WHERE booking_id = p_booking_id
+    ORDER BY payment_id DESC
+    LIMIT 1;
+    
+    IF v_payment_status = 'completed' THEN
+        INSERT INTO payments (booking_id, amount, payment_method, payment_status)
+        SELECT booking_id, amount, payment_method, 'refunded'
+        FROM payments
+        WHERE payment_id = v_payment_id;
+    END IF;
+    
+    DELETE FROM bookings WHERE booking_id = p_booking_id;
+END;
+$$;
+
+
@@ -0,0 +1,119 @@
+-- возврат получает дату, чтобы попадать во временные ряды
+CREATE OR REPLACE PROCEDURE cancel_booking_with_refund(
+    p_booking_id INTEGER
+)
+LANGUAGE plpgsql
+AS $$
+DECLARE
+    v_payment_id INTEGER;
+    v_payment_status TEXT;
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM bookings WHERE booking_id = p_booking_id) THEN
+        RAISE EXCEPTION 'Booking with ID % not found', p_booking_id;
+    END IF;
+    
+    SELECT payment_id, payment_status INTO v_payment_id, v_payment_status
+    FROM payments
+    WHERE booking_id = p_booking_id
+    ORDER BY payment_id DESC
+    LIMIT 1;
+    
+    IF v_payment_status = 'completed' THEN
+        INSERT INTO payments (booking_id, amount, payment_method, payment_status, paid_at)
+        SELECT booking_id, amount, payment_method, 'refunded', CURRENT_TIMESTAMP
+        FROM payments
+        WHERE payment_id = v_payment_id;
+    END IF;
+    
+    DELETE FROM bookings WHERE booking_id = p_booking_id;
+END;
+$$;
+
+-- временной ряд по выручке, возвратам или бронированиям с заполнением пустых интервалов нулями
+-- revenue и refunds считаются по payments.paid_at, bookings - по bookings.in_date
+CREATE OR REPLACE FUNCTION get_timeseries_report(
+    p_metric TEXT,
+    p_granularity TEXT,
+    p_from TIMESTAMPTZ,
+    p_to TIMESTAMPTZ,
+    p_host_id INTEGER DEFAULT NULL,
+    p_listing_id INTEGER DEFAULT NULL
+)
+RETURNS TABLE (
+    bucket TIMESTAMPTZ,
+    amount DECIMAL(12,2),
+    events_count INTEGER
+) AS $$
+BEGIN
+    IF p_metric NOT IN ('revenue', 'refunds', 'bookings') THEN
+        RAISE EXCEPTION 'Unknown metric %', p_metric;
+    END IF;
+
+    IF p_granularity NOT IN ('day', 'week', 'month') THEN
+        RAISE EXCEPTION 'Unknown granularity %', p_granularity;
+    END IF;
+
+    RETURN QUERY
+    WITH buckets AS (
+        SELECT generate_series(
+            date_trunc(p_granularity, p_from),
+            date_trunc(p_granularity, p_to - INTERVAL '1 microsecond'),
+            ('1 ' || p_granularity)::INTERVAL
+        ) AS bucket_start
+    ),
+    payment_events AS (
+        SELECT
+            COALESCE(p.paid_at, pa.changed_at) AS happened_at,
+            p.amount AS total,
+            COALESCE(b.host_id, (db.old_data->>'host_id')::INTEGER) AS event_host_id,
+            COALESCE(b.listing_id, (db.old_data->>'listing_id')::INTEGER) AS event_listing_id
+        FROM payments p
+        LEFT JOIN bookings b ON b.booking_id = p.booking_id
+        -- у возвратов по отмененным броням бронь уже удалена: хост и объявление берутся из аудита,
+        -- а у старых возвратов без paid_at датой считается момент создания платежа
+        LEFT JOIN LATERAL (
+            SELECT a.new_data, a.changed_at
+            FROM audit_log a
+            WHERE a.table_name = 'payments' AND a.action = 'INSERT' AND a.record_id = p.payment_id
+            ORDER BY a.id
+            LIMIT 1
+        ) pa ON p.paid_at IS NULL OR p.booking_id IS NULL
+        LEFT JOIN LATERAL (
+            SELECT a.old_data
+            FROM audit_log a
+            WHERE a.table_name = 'bookings' AND a.action = 'DELETE'
+              AND a.record_id = (pa.new_data->>'booking_id')::BIGINT
+            ORDER BY a.id DESC
+            LIMIT 1
+        ) db ON p.booking_id IS NULL
+        WHERE p_metric IN ('revenue', 'refunds')
+          AND p.payment_status = CASE p_metric WHEN 'revenue' THEN 'completed' ELSE 'refunded' END
+    ),
+    events AS (
+        SELECT date_trunc(p_granularity, pe.happened_at) AS bucket_start, pe.total
+        FROM payment_events pe
+        WHERE pe.happened_at >= p_from AND pe.happened_at < p_to
+          AND (p_host_id IS NULL OR pe.event_host_id = p_host_id)
+          AND (p_listing_id IS NULL OR pe.event_listing_id = p_listing_id)
+        UNION ALL
+        SELECT date_trunc(p_granularity, b.in_date) AS bucket_start, b.total_price AS total
+        FROM bookings b
+        WHERE p_metric = 'bookings'
+          AND b.in_date >= p_from AND b.in_date < p_to
+          AND (p_host_id IS NULL OR b.host_id = p_host_id)
+          AND (p_listing_id IS NULL OR b.listing_id = p_listing_id)
+    )
+    SELECT
+        bk.bucket_start AS bucket,
+        COALESCE(SUM(e.total), 0.00)::DECIMAL(12,2) AS amount,
+        COUNT(e.bucket_start)::INTEGER AS events_count
+    FROM buckets bk
+    LEFT JOIN events e ON e.bucket_start = bk.bucket_start
+    GROUP BY bk.bucket_start
+    ORDER BY bk.bucket_start;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE INDEX IF NOT EXISTS idx_bookings_in_date ON bookings(in_date);
+CREATE INDEX IF NOT EXISTS idx_payments_paid_at ON payments(paid_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_table_record ON audit_log(table_name, record_id);
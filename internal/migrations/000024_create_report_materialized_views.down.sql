@@ -0,0 +1,93 @@
+DROP FUNCTION IF EXISTS refresh_report_views();
+DROP TABLE IF EXISTS report_refreshes;
+DROP MATERIALIZED VIEW IF EXISTS mv_hosts_performance;
+DROP MATERIALIZED VIEW IF EXISTS mv_listings_statistics;
+
+DROP FUNCTION IF EXISTS get_listings_statistics_report();
+CREATE OR REPLACE FUNCTION get_listings_statistics_report()
+RETURNS TABLE (
+    listing_id INTEGER,
+    address TEXT,
+    host_id INTEGER,
+    host_name TEXT,
+    price_per_night DECIMAL(10,2),
+    average_rating DECIMAL(3,2),
+    reviews_count INTEGER,
+    bookings_count INTEGER,
+    total_revenue DECIMAL(12,2),
+    is_available BOOLEAN,
+    avg_cleanliness DECIMAL(3,2),
+    avg_accuracy DECIMAL(3,2),
+    avg_location DECIMAL(3,2),
+    avg_value DECIMAL(3,2)
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT 
+        l.id AS listing_id,
+        l.address,
+        l.host_id,
+        (u.first_name || ' ' || u.second_name) AS host_name,
+        l.price_per_night,
+        l.average_rating,
+        l.reviews_count,
+        l.bookings_count,
+        COALESCE(SUM(p.amount) FILTER (WHERE p.payment_status = 'completed'), 0.00) AS total_revenue,
+        l.is_available,
+        l.avg_cleanliness,
+        l.avg_accuracy,
+        l.avg_location,
+        l.avg_value
+    FROM listings l
+    JOIN users u ON l.host_id = u.id
+    LEFT JOIN bookings b ON l.id = b.listing_id
+    LEFT JOIN payments p ON b.booking_id = p.booking_id
+    GROUP BY l.id, l.address, l.host_id, u.first_name, u.second_name, 
+             l.price_per_night, l.average_rating, l.reviews_count, 
+             l.bookings_count, l.is_available,
+             l.avg_cleanliness, l.avg_accuracy, l.avg_location, l.avg_value
+    ORDER BY total_revenue DESC, l.average_rating DESC;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP FUNCTION IF EXISTS get_hosts_performance_report();
+CREATE OR REPLACE FUNCTION get_hosts_performance_report()
+RETURNS TABLE (
+    host_id INTEGER,
+    host_name TEXT,
+    host_email TEXT,
+    listings_count INTEGER,
+    total_bookings INTEGER,
+    average_rating DECIMAL(3,2),
+    total_revenue DECIMAL(12,2),
+    completed_payments_count INTEGER,
+    avg_cleanliness DECIMAL(3,2),
+    avg_accuracy DECIMAL(3,2),
+    avg_location DECIMAL(3,2),
+    avg_value DECIMAL(3,2)
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT 
+        u.id AS host_id,
+        (u.first_name || ' ' || u.second_name) AS host_name,
+        u.email AS host_email,
+        COUNT(DISTINCT l.id)::INTEGER AS listings_count,
+        COUNT(DISTINCT b.booking_id)::INTEGER AS total_bookings,
+        COALESCE(AVG(r.score) FILTER (WHERE r.status = 'published'), 0.00)::DECIMAL(3,2) AS average_rating,
+        COALESCE(SUM(p.amount) FILTER (WHERE p.payment_status = 'completed'), 0.00) AS total_revenue,
+        COUNT(DISTINCT p.payment_id) FILTER (WHERE p.payment_status = 'completed')::INTEGER AS completed_payments_count,
+        COALESCE(AVG(r.cleanliness_score) FILTER (WHERE r.status = 'published'), 0.00)::DECIMAL(3,2) AS avg_cleanliness,
+        COALESCE(AVG(r.accuracy_score) FILTER (WHERE r.status = 'published'), 0.00)::DECIMAL(3,2) AS avg_accuracy,
+        COALESCE(AVG(r.location_score) FILTER (WHERE r.status = 'published'), 0.00)::DECIMAL(3,2) AS avg_location,
+        COALESCE(AVG(r.value_score) FILTER (WHERE r.status = 'published'), 0.00)::DECIMAL(3,2) AS avg_value
+    FROM users u
+    LEFT JOIN listings l ON u.id = l.host_id
+    LEFT JOIN bookings b ON l.id = b.listing_id AND b.host_id = u.id
+    LEFT JOIN reviews r ON b.booking_id = r.booking_id
+    LEFT JOIN payments p ON b.booking_id = p.booking_id
+    WHERE EXISTS (SELECT 1 FROM listings lst WHERE lst.host_id = u.id)
+    GROUP BY u.id, u.first_name, u.second_name, u.email
+    ORDER BY total_revenue DESC, average_rating DESC;
+END;
+$$ LANGUAGE plpgsql;
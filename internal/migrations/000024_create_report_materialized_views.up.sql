@@ -0,0 +1,147 @@
+-- отчеты по объявлениям и хостам читаются из материализованных представлений,
+-- которые периодически обновляет приложение через refresh_report_views()
+CREATE MATERIALIZED VIEW IF NOT EXISTS mv_listings_statistics AS
+SELECT
+    l.id AS listing_id,
+    l.address,
+    l.host_id,
+    (u.first_name || ' ' || u.second_name) AS host_name,
+    l.price_per_night,
+    l.average_rating,
+    l.reviews_count,
+    l.bookings_count,
+    COALESCE(SUM(p.amount) FILTER (WHERE p.payment_status = 'completed'), 0.00)::DECIMAL(12,2) AS total_revenue,
+    l.is_available,
+    l.avg_cleanliness,
+    l.avg_accuracy,
+    l.avg_location,
+    l.avg_value
+FROM listings l
+JOIN users u ON l.host_id = u.id
+LEFT JOIN bookings b ON l.id = b.listing_id
+LEFT JOIN payments p ON b.booking_id = p.booking_id
+GROUP BY l.id, u.first_name, u.second_name;
+
+-- уникальный индекс нужен для REFRESH ... CONCURRENTLY
+CREATE UNIQUE INDEX IF NOT EXISTS uq_mv_listings_statistics_listing_id ON mv_listings_statistics(listing_id);
+CREATE INDEX IF NOT EXISTS idx_mv_listings_statistics_revenue ON mv_listings_statistics(total_revenue DESC, average_rating DESC);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS mv_hosts_performance AS
+SELECT
+    u.id AS host_id,
+    (u.first_name || ' ' || u.second_name) AS host_name,
+    u.email AS host_email,
+    COUNT(DISTINCT l.id)::INTEGER AS listings_count,
+    COUNT(DISTINCT b.booking_id)::INTEGER AS total_bookings,
+    COALESCE(AVG(r.score), 0.00)::DECIMAL(3,2) AS average_rating,
+    COALESCE(SUM(p.amount) FILTER (WHERE p.payment_status = 'completed'), 0.00)::DECIMAL(12,2) AS total_revenue,
+    COUNT(DISTINCT p.payment_id) FILTER (WHERE p.payment_status = 'completed')::INTEGER AS completed_payments_count,
+    COALESCE(AVG(r.cleanliness_score) FILTER (WHERE r.status = 'published'), 0.00)::DECIMAL(3,2) AS avg_cleanliness,
+    COALESCE(AVG(r.accuracy_score) FILTER (WHERE r.status = 'published'), 0.00)::DECIMAL(3,2) AS avg_accuracy,
+    COALESCE(AVG(r.location_score) FILTER (WHERE r.status = 'published'), 0.00)::DECIMAL(3,2) AS avg_location,
+    COALESCE(AVG(r.value_score) FILTER (WHERE r.status = 'published'), 0.00)::DECIMAL(3,2) AS avg_value
+FROM users u
+JOIN listings l ON u.id = l.host_id
+LEFT JOIN bookings b ON l.id = b.listing_id AND b.host_id = u.id
+LEFT JOIN reviews r ON b.booking_id = r.booking_id
+LEFT JOIN payments p ON b.booking_id = p.booking_id
+GROUP BY u.id, u.first_name, u.second_name, u.email;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_mv_hosts_performance_host_id ON mv_hosts_performance(host_id);
+CREATE INDEX IF NOT EXISTS idx_mv_hosts_performance_revenue ON mv_hosts_performance(total_revenue DESC, average_rating DESC);
+
+-- момент, на который актуальны данные представления
+CREATE TABLE IF NOT EXISTS report_refreshes (
+    view_name TEXT PRIMARY KEY,
+    refreshed_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO report_refreshes (view_name) VALUES ('mv_listings_statistics'), ('mv_hosts_performance')
+ON CONFLICT (view_name) DO NOTHING;
+
+CREATE OR REPLACE FUNCTION refresh_report_views()
+RETURNS VOID AS $$
+DECLARE
+    v_view_name TEXT;
+BEGIN
+    FOR v_view_name IN SELECT view_name FROM report_refreshes ORDER BY view_name LOOP
+        EXECUTE format('REFRESH MATERIALIZED VIEW CONCURRENTLY %I', v_view_name);
+        -- now() - начало транзакции, то есть снимок, который увидело обновление
+        UPDATE report_refreshes SET refreshed_at = now() WHERE view_name = v_view_name;
+    END LOOP;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION get_listings_statistics_report()
+RETURNS TABLE (
+    listing_id INTEGER,
+    address TEXT,
+    host_id INTEGER,
+    host_name TEXT,
+    price_per_night DECIMAL(10,2),
+    average_rating DECIMAL(3,2),
+    reviews_count INTEGER,
+    bookings_count INTEGER,
+    total_revenue DECIMAL(12,2),
+    is_available BOOLEAN,
+    avg_cleanliness DECIMAL(3,2),
+    avg_accuracy DECIMAL(3,2),
+    avg_location DECIMAL(3,2),
+    avg_value DECIMAL(3,2)
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT
+        mv.listing_id,
+        mv.address,
+        mv.host_id,
+        mv.host_name,
+        mv.price_per_night,
+        mv.average_rating,
+        mv.reviews_count,
+        mv.bookings_count,
+        mv.total_revenue,
+        mv.is_available,
+        mv.avg_cleanliness,
+        mv.avg_accuracy,
+        mv.avg_location,
+        mv.avg_value
+    FROM mv_listings_statistics mv
+    ORDER BY mv.total_revenue DESC, mv.average_rating DESC;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION get_hosts_performance_report()
+RETURNS TABLE (
+    host_id INTEGER,
+    host_name TEXT,
+    host_email TEXT,
+    listings_count INTEGER,
+    total_bookings INTEGER,
+    average_rating DECIMAL(3,2),
+    total_revenue DECIMAL(12,2),
+    completed_payments_count INTEGER,
+    avg_cleanliness DECIMAL(3,2),
+    avg_accuracy DECIMAL(3,2),
+    avg_location DECIMAL(3,2),
+    avg_value DECIMAL(3,2)
+) AS $$
+BEGIN
+    RETURN QUERY
+    SELECT
+        mv.host_id,
+        mv.host_name,
+        mv.host_email,
+        mv.listings_count,
+        mv.total_bookings,
+        mv.average_rating,
+        mv.total_revenue,
+        mv.completed_payments_count,
+        mv.avg_cleanliness,
+        mv.avg_accuracy,
+        mv.avg_location,
+        mv.avg_value
+    FROM mv_hosts_performance mv
+    ORDER BY mv.total_revenue DESC, mv.average_rating DESC;
+END;
+$$ LANGUAGE plpgsql;
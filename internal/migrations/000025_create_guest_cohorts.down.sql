@@ -0,0 +1,19 @@
+DROP FUNCTION IF EXISTS get_guest_cohort_report(TIMESTAMPTZ, TIMESTAMPTZ);
+
+CREATE OR REPLACE FUNCTION get_guest_total_spent(guest_id_param INTEGER)
+RETURNS DECIMAL(12,2) AS $$
+DECLARE
+    total_spent DECIMAL(12,2);
+BEGIN
+    SELECT COALESCE(SUM(p.amount), 0.00)
+    INTO total_spent
+    FROM payments p
+    JOIN bookings b ON p.booking_id = b.booking_id
+    WHERE b.guest_id = guest_id_param
+      AND p.payment_status = 'completed';
+    
+    RETURN total_spent;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP VIEW IF EXISTS guest_completed_payments;
@@ -0,0 +1,106 @@
+-- завершенные платежи гостей; общая логика для get_guest_total_spent и когортного отчета
+CREATE OR REPLACE VIEW guest_completed_payments AS
+SELECT
+    b.guest_id,
+    b.booking_id,
+    p.payment_id,
+    p.amount,
+    COALESCE(p.paid_at, b.in_date) AS paid_at
+FROM payments p
+JOIN bookings b ON p.booking_id = b.booking_id
+WHERE p.payment_status = 'completed';
+
+CREATE OR REPLACE FUNCTION get_guest_total_spent(guest_id_param INTEGER)
+RETURNS DECIMAL(12,2) AS $$
+DECLARE
+    total_spent DECIMAL(12,2);
+BEGIN
+    SELECT COALESCE(SUM(gp.amount), 0.00)
+    INTO total_spent
+    FROM guest_completed_payments gp
+    WHERE gp.guest_id = guest_id_param;
+    
+    RETURN total_spent;
+END;
+$$ LANGUAGE plpgsql;
+
+-- когорты гостей по месяцу первого бронирования: для каждого следующего месяца
+-- доля гостей когорты, забронировавших снова, и накопленные траты когорты
+CREATE OR REPLACE FUNCTION get_guest_cohort_report(
+    start_date_param TIMESTAMPTZ DEFAULT NULL,
+    end_date_param TIMESTAMPTZ DEFAULT NULL
+)
+RETURNS TABLE (
+    cohort_month DATE,
+    cohort_size INTEGER,
+    month_offset INTEGER,
+    active_guests INTEGER,
+    retention_rate DECIMAL(5,4),
+    cumulative_spend DECIMAL(12,2)
+) AS $$
+BEGIN
+    RETURN QUERY
+    WITH first_bookings AS (
+        SELECT b.guest_id, date_trunc('month', MIN(b.in_date))::DATE AS cohort_start
+        FROM bookings b
+        GROUP BY b.guest_id
+    ),
+    cohorts AS (
+        SELECT fb.guest_id, fb.cohort_start
+        FROM first_bookings fb
+        WHERE (start_date_param IS NULL OR fb.cohort_start >= date_trunc('month', start_date_param)::DATE)
+          AND (end_date_param IS NULL OR fb.cohort_start <= end_date_param::DATE)
+    ),
+    cohort_sizes AS (
+        SELECT c.cohort_start, COUNT(*)::INTEGER AS guests
+        FROM cohorts c
+        GROUP BY c.cohort_start
+    ),
+    last_month AS (
+        SELECT GREATEST(date_trunc('month', MAX(b.in_date)), date_trunc('month', CURRENT_TIMESTAMP))::DATE AS month_start
+        FROM bookings b
+    ),
+    cells AS (
+        SELECT cs.cohort_start, cs.guests, offs.n AS month_offset
+        FROM cohort_sizes cs
+        CROSS JOIN last_month lm
+        CROSS JOIN LATERAL generate_series(
+            0,
+            (EXTRACT(YEAR FROM age(lm.month_start, cs.cohort_start)) * 12
+                + EXTRACT(MONTH FROM age(lm.month_start, cs.cohort_start)))::INTEGER
+        ) AS offs(n)
+    ),
+    activity AS (
+        SELECT
+            c.cohort_start,
+            (EXTRACT(YEAR FROM age(date_trunc('month', b.in_date)::DATE, c.cohort_start)) * 12
+                + EXTRACT(MONTH FROM age(date_trunc('month', b.in_date)::DATE, c.cohort_start)))::INTEGER AS month_offset,
+            COUNT(DISTINCT b.guest_id) AS guests
+        FROM cohorts c
+        JOIN bookings b ON b.guest_id = c.guest_id
+        GROUP BY 1, 2
+    ),
+    spend AS (
+        -- предоплата до первого заезда относится к нулевому месяцу
+        SELECT
+            c.cohort_start,
+            GREATEST((EXTRACT(YEAR FROM age(date_trunc('month', gp.paid_at)::DATE, c.cohort_start)) * 12
+                + EXTRACT(MONTH FROM age(date_trunc('month', gp.paid_at)::DATE, c.cohort_start)))::INTEGER, 0) AS month_offset,
+            SUM(gp.amount) AS amount
+        FROM cohorts c
+        JOIN guest_completed_payments gp ON gp.guest_id = c.guest_id
+        GROUP BY 1, 2
+    )
+    SELECT
+        cl.cohort_start AS cohort_month,
+        cl.guests AS cohort_size,
+        cl.month_offset,
+        COALESCE(a.guests, 0)::INTEGER AS active_guests,
+        (COALESCE(a.guests, 0)::DECIMAL / cl.guests)::DECIMAL(5,4) AS retention_rate,
+        COALESCE(SUM(s.amount) OVER (PARTITION BY cl.cohort_start ORDER BY cl.month_offset), 0.00)::DECIMAL(12,2) AS cumulative_spend
+    FROM cells cl
+    LEFT JOIN activity a ON a.cohort_start = cl.cohort_start AND a.month_offset = cl.month_offset
+    LEFT JOIN spend s ON s.cohort_start = cl.cohort_start AND s.month_offset = cl.month_offset
+    ORDER BY 1, 3;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,4 @@
+DROP FUNCTION IF EXISTS get_payment_failures_report(TIMESTAMPTZ, TIMESTAMPTZ);
+DROP FUNCTION IF EXISTS get_hosts_cancellation_report(TIMESTAMPTZ, TIMESTAMPTZ);
+DROP FUNCTION IF EXISTS get_listings_cancellation_report(TIMESTAMPTZ, TIMESTAMPTZ);
+DROP FUNCTION IF EXISTS get_booking_cancellations(TIMESTAMPTZ, TIMESTAMPTZ);
@@ -0,0 +1,191 @@
+-- статуса у бронирований нет: отмена - это удаление брони, которое видно по DELETE в audit_log.
+-- Возврат, созданный cancel_booking_with_refund, связывается с бронью через INSERT платежа в аудите,
+-- так как после удаления брони booking_id у платежа обнуляется
+CREATE OR REPLACE FUNCTION get_booking_cancellations(
+    start_date_param TIMESTAMPTZ DEFAULT NULL,
+    end_date_param TIMESTAMPTZ DEFAULT NULL
+)
+RETURNS TABLE (
+    booking_id INTEGER,
+    listing_id INTEGER,
+    host_id INTEGER,
+    guest_id INTEGER,
+    in_date TIMESTAMPTZ,
+    cancelled_at TIMESTAMPTZ,
+    lead_time_days DECIMAL(8,2),
+    refunded_amount DECIMAL(12,2)
+) AS $$
+BEGIN
+    RETURN QUERY
+    WITH deleted AS (
+        SELECT DISTINCT ON (a.record_id)
+            a.record_id::INTEGER AS deleted_booking_id,
+            a.old_data,
+            (a.old_data->>'in_date')::TIMESTAMPTZ AS deleted_in_date,
+            a.changed_at::TIMESTAMPTZ AS deleted_at
+        FROM audit_log a
+        WHERE a.table_name = 'bookings' AND a.action = 'DELETE'
+        ORDER BY a.record_id, a.id DESC
+    ),
+    refunds AS (
+        SELECT (a.new_data->>'booking_id')::INTEGER AS refunded_booking_id, SUM(p.amount) AS amount
+        FROM payments p
+        JOIN audit_log a ON a.table_name = 'payments' AND a.action = 'INSERT' AND a.record_id = p.payment_id
+        WHERE p.payment_status = 'refunded'
+        GROUP BY 1
+    )
+    SELECT
+        d.deleted_booking_id,
+        (d.old_data->>'listing_id')::INTEGER,
+        (d.old_data->>'host_id')::INTEGER,
+        (d.old_data->>'guest_id')::INTEGER,
+        d.deleted_in_date,
+        d.deleted_at,
+        -- отрицательное значение - отмена уже после заезда
+        (EXTRACT(EPOCH FROM (d.deleted_in_date - d.deleted_at)) / 86400)::DECIMAL(8,2),
+        COALESCE(r.amount, 0.00)::DECIMAL(12,2)
+    FROM deleted d
+    LEFT JOIN refunds r ON r.refunded_booking_id = d.deleted_booking_id
+    WHERE (start_date_param IS NULL OR d.deleted_in_date >= start_date_param)
+      AND (end_date_param IS NULL OR d.deleted_in_date <= end_date_param)
+    ORDER BY 6 DESC;
+END;
+$$ LANGUAGE plpgsql;
+
+-- доля отмен считается от всех броней с заездом в периоде, включая отмененные
+CREATE OR REPLACE FUNCTION get_listings_cancellation_report(
+    start_date_param TIMESTAMPTZ DEFAULT NULL,
+    end_date_param TIMESTAMPTZ DEFAULT NULL
+)
+RETURNS TABLE (
+    listing_id INTEGER,
+    address TEXT,
+    host_id INTEGER,
+    bookings_count INTEGER,
+    cancellations_count INTEGER,
+    cancellation_rate DECIMAL(5,4),
+    avg_lead_time_days DECIMAL(8,2),
+    refunded_amount DECIMAL(12,2)
+) AS $$
+BEGIN
+    RETURN QUERY
+    WITH kept AS (
+        SELECT b.listing_id AS kept_listing_id, COUNT(*) AS cnt
+        FROM bookings b
+        WHERE (start_date_param IS NULL OR b.in_date >= start_date_param)
+          AND (end_date_param IS NULL OR b.in_date <= end_date_param)
+        GROUP BY 1
+    ),
+    cancelled AS (
+        SELECT bc.listing_id AS cancelled_listing_id, COUNT(*) AS cnt,
+            AVG(bc.lead_time_days) AS lead_time, SUM(bc.refunded_amount) AS refunded
+        FROM get_booking_cancellations(start_date_param, end_date_param) bc
+        GROUP BY 1
+    )
+    SELECT
+        l.id,
+        l.address,
+        l.host_id,
+        (COALESCE(k.cnt, 0) + COALESCE(c.cnt, 0))::INTEGER,
+        COALESCE(c.cnt, 0)::INTEGER,
+        (COALESCE(c.cnt, 0)::DECIMAL / (COALESCE(k.cnt, 0) + COALESCE(c.cnt, 0)))::DECIMAL(5,4),
+        COALESCE(c.lead_time, 0.00)::DECIMAL(8,2),
+        COALESCE(c.refunded, 0.00)::DECIMAL(12,2)
+    FROM listings l
+    LEFT JOIN kept k ON k.kept_listing_id = l.id
+    LEFT JOIN cancelled c ON c.cancelled_listing_id = l.id
+    WHERE k.cnt IS NOT NULL OR c.cnt IS NOT NULL
+    ORDER BY 6 DESC, 5 DESC, 1;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION get_hosts_cancellation_report(
+    start_date_param TIMESTAMPTZ DEFAULT NULL,
+    end_date_param TIMESTAMPTZ DEFAULT NULL
+)
+RETURNS TABLE (
+    host_id INTEGER,
+    host_name TEXT,
+    bookings_count INTEGER,
+    cancellations_count INTEGER,
+    cancellation_rate DECIMAL(5,4),
+    avg_lead_time_days DECIMAL(8,2),
+    refunded_amount DECIMAL(12,2)
+) AS $$
+BEGIN
+    RETURN QUERY
+    WITH kept AS (
+        SELECT b.host_id AS kept_host_id, COUNT(*) AS cnt
+        FROM bookings b
+        WHERE (start_date_param IS NULL OR b.in_date >= start_date_param)
+          AND (end_date_param IS NULL OR b.in_date <= end_date_param)
+        GROUP BY 1
+    ),
+    cancelled AS (
+        SELECT bc.host_id AS cancelled_host_id, COUNT(*) AS cnt,
+            AVG(bc.lead_time_days) AS lead_time, SUM(bc.refunded_amount) AS refunded
+        FROM get_booking_cancellations(start_date_param, end_date_param) bc
+        GROUP BY 1
+    )
+    SELECT
+        u.id,
+        (u.first_name || ' ' || u.second_name),
+        (COALESCE(k.cnt, 0) + COALESCE(c.cnt, 0))::INTEGER,
+        COALESCE(c.cnt, 0)::INTEGER,
+        (COALESCE(c.cnt, 0)::DECIMAL / (COALESCE(k.cnt, 0) + COALESCE(c.cnt, 0)))::DECIMAL(5,4),
+        COALESCE(c.lead_time, 0.00)::DECIMAL(8,2),
+        COALESCE(c.refunded, 0.00)::DECIMAL(12,2)
+    FROM users u
+    LEFT JOIN kept k ON k.kept_host_id = u.id
+    LEFT JOIN cancelled c ON c.cancelled_host_id = u.id
+    WHERE k.cnt IS NOT NULL OR c.cnt IS NOT NULL
+    ORDER BY 5 DESC, 4 DESC, 1;
+END;
+$$ LANGUAGE plpgsql;
+
+-- неуспешные платежи по способу оплаты; доля ошибок считается от завершившихся попыток,
+-- записи возвратов в нее не входят. Дата платежа - paid_at, а без нее - время создания из аудита.
+-- Суммы нет: у неуспешного платежа amount всегда обнуляется
+CREATE OR REPLACE FUNCTION get_payment_failures_report(
+    start_date_param TIMESTAMPTZ DEFAULT NULL,
+    end_date_param TIMESTAMPTZ DEFAULT NULL
+)
+RETURNS TABLE (
+    payment_method TEXT,
+    payments_count INTEGER,
+    completed_count INTEGER,
+    failed_count INTEGER,
+    pending_count INTEGER,
+    refunded_count INTEGER,
+    failure_rate DECIMAL(5,4)
+) AS $$
+BEGIN
+    RETURN QUERY
+    WITH dated_payments AS (
+        SELECT p.payment_method AS method, p.payment_status AS status,
+            COALESCE(p.paid_at, pa.changed_at::TIMESTAMPTZ) AS happened_at
+        FROM payments p
+        LEFT JOIN LATERAL (
+            SELECT a.changed_at
+            FROM audit_log a
+            WHERE a.table_name = 'payments' AND a.action = 'INSERT' AND a.record_id = p.payment_id
+            ORDER BY a.id
+            LIMIT 1
+        ) pa ON p.paid_at IS NULL
+    )
+    SELECT
+        dp.method,
+        COUNT(*)::INTEGER,
+        COUNT(*) FILTER (WHERE dp.status = 'completed')::INTEGER,
+        COUNT(*) FILTER (WHERE dp.status = 'failed')::INTEGER,
+        COUNT(*) FILTER (WHERE dp.status = 'pending')::INTEGER,
+        COUNT(*) FILTER (WHERE dp.status = 'refunded')::INTEGER,
+        COALESCE(COUNT(*) FILTER (WHERE dp.status = 'failed')::DECIMAL
+            / NULLIF(COUNT(*) FILTER (WHERE dp.status IN ('completed', 'failed')), 0), 0)::DECIMAL(5,4)
+    FROM dated_payments dp
+    WHERE (start_date_param IS NULL OR dp.happened_at >= start_date_param)
+      AND (end_date_param IS NULL OR dp.happened_at <= end_date_param)
+    GROUP BY dp.method
+    ORDER BY 7 DESC, 1;
+END;
+$$ LANGUAGE plpgsql;
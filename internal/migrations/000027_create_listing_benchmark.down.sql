@@ -0,0 +1 @@
+DROP FUNCTION IF EXISTS get_listing_comparables(INTEGER, TIMESTAMPTZ, TIMESTAMPTZ, DOUBLE PRECISION);
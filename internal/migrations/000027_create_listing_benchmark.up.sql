@@ -0,0 +1,90 @@
+-- объявление и похожие на него: то же число комнат и кроватей, общие удобства и, если известно, близкое расположение
+-- с координатами у обоих объявлений сравнивается расстояние, без них - город; radius_km_param = NULL отключает отбор по месту
+-- первой строкой возвращается само объявление (is_target), загрузка считается так же, как в get_listings_occupancy_report
+CREATE OR REPLACE FUNCTION get_listing_comparables(
+    listing_id_param INTEGER,
+    start_date_param TIMESTAMPTZ,
+    end_date_param TIMESTAMPTZ,
+    radius_km_param DOUBLE PRECISION
+)
+RETURNS TABLE (
+    listing_id INTEGER,
+    is_target BOOLEAN,
+    price_per_night DECIMAL(10,2),
+    average_rating DECIMAL(3,2),
+    reviews_count INTEGER,
+    bookings_count INTEGER,
+    available_nights INTEGER,
+    booked_nights INTEGER,
+    occupancy_rate DECIMAL(5,4),
+    shared_amenities INTEGER,
+    amenity_similarity DECIMAL(5,4),
+    distance_km DOUBLE PRECISION
+) AS $$
+DECLARE
+    period_nights INTEGER := GREATEST(end_date_param::DATE - start_date_param::DATE, 0);
+BEGIN
+    RETURN QUERY
+    WITH target AS (
+        SELECT l.id, l.rooms_number, l.beds_number, l.city, l.latitude, l.longitude,
+            ARRAY(SELECT la.amenity_id FROM listing_amenities la WHERE la.listing_id = l.id) AS amenity_ids
+        FROM listings l
+        WHERE l.id = listing_id_param
+    ),
+    candidates AS (
+        SELECT
+            l.id,
+            l.id = t.id AS is_target,
+            COUNT(la.amenity_id) FILTER (WHERE la.amenity_id = ANY(t.amenity_ids))::INTEGER AS shared,
+            COUNT(la.amenity_id)::INTEGER AS own,
+            cardinality(t.amenity_ids) AS target_own,
+            CASE WHEN t.latitude IS NOT NULL AND l.latitude IS NOT NULL
+                THEN earth_distance(ll_to_earth(t.latitude, t.longitude), ll_to_earth(l.latitude, l.longitude)) / 1000
+            END AS distance
+        FROM target t
+        JOIN listings l ON l.rooms_number = t.rooms_number AND l.beds_number = t.beds_number
+        LEFT JOIN listing_amenities la ON la.listing_id = l.id
+        WHERE l.id = t.id
+           OR radius_km_param IS NULL
+           OR CASE
+                WHEN t.latitude IS NOT NULL AND l.latitude IS NOT NULL THEN
+                    earth_box(ll_to_earth(t.latitude, t.longitude), radius_km_param * 1000) @> ll_to_earth(l.latitude, l.longitude)
+                    AND earth_distance(ll_to_earth(t.latitude, t.longitude), ll_to_earth(l.latitude, l.longitude)) <= radius_km_param * 1000
+                WHEN t.city IS NOT NULL AND l.city IS NOT NULL THEN lower(l.city) = lower(t.city)
+                ELSE TRUE
+              END
+        GROUP BY l.id, t.id, t.amenity_ids, t.latitude, t.longitude, l.latitude, l.longitude
+    ),
+    booking_nights AS (
+        SELECT
+            b.listing_id,
+            SUM(GREATEST(LEAST(b.out_date, end_date_param)::DATE - GREATEST(b.in_date, start_date_param)::DATE, 0)) AS nights
+        FROM bookings b
+        JOIN candidates c ON c.id = b.listing_id
+        WHERE b.in_date < end_date_param
+          AND b.out_date > start_date_param
+        GROUP BY b.listing_id
+    )
+    SELECT
+        l.id AS listing_id,
+        c.is_target,
+        l.price_per_night,
+        l.average_rating,
+        l.reviews_count,
+        l.bookings_count,
+        period_nights AS available_nights,
+        LEAST(COALESCE(bn.nights, 0), period_nights)::INTEGER AS booked_nights,
+        COALESCE(LEAST(bn.nights, period_nights)::DECIMAL / NULLIF(period_nights, 0), 0)::DECIMAL(5,4) AS occupancy_rate,
+        c.shared AS shared_amenities,
+        -- коэффициент Жаккара по наборам удобств; два объявления без удобств считаются одинаковыми
+        COALESCE(c.shared::DECIMAL / NULLIF(c.own + c.target_own - c.shared, 0), 1)::DECIMAL(5,4) AS amenity_similarity,
+        c.distance AS distance_km
+    FROM candidates c
+    JOIN listings l ON l.id = c.id
+    LEFT JOIN booking_nights bn ON bn.listing_id = c.id
+    -- если у объявления есть удобства, у похожего должно быть хотя бы одно общее
+    WHERE c.is_target OR c.target_own = 0 OR c.shared > 0
+    -- сортировка по номерам колонок: их имена совпадают с OUT-параметрами функции
+    ORDER BY 2 DESC, 11 DESC, 12 NULLS LAST, 1;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,22 @@
+CREATE OR REPLACE VIEW hosts_analytics AS
+SELECT 
+    u.id AS host_id,
+    (u.first_name || ' ' || u.second_name) AS host_name,
+    u.email AS host_email,
+    COUNT(DISTINCT l.id) AS total_listings,
+    COUNT(DISTINCT b.booking_id) AS total_bookings,
+    COUNT(DISTINCT b.booking_id) FILTER (WHERE b.out_date > CURRENT_TIMESTAMP) AS active_bookings,
+    COALESCE(AVG(r.score), 0.00) AS average_rating,
+    COUNT(DISTINCT r.id) AS total_reviews,
+    COALESCE(SUM(p.amount) FILTER (WHERE p.payment_status = 'completed'), 0.00) AS total_revenue,
+    COALESCE(AVG(p.amount) FILTER (WHERE p.payment_status = 'completed'), 0.00) AS avg_booking_revenue,
+    COUNT(DISTINCT p.payment_id) FILTER (WHERE p.payment_status = 'completed') AS completed_payments_count,
+    COUNT(DISTINCT p.payment_id) FILTER (WHERE p.payment_status = 'pending') AS pending_payments_count,
+    COUNT(DISTINCT p.payment_id) FILTER (WHERE p.payment_status = 'failed') AS failed_payments_count
+FROM users u
+LEFT JOIN listings l ON u.id = l.host_id
+LEFT JOIN bookings b ON l.id = b.listing_id AND b.host_id = u.id
+LEFT JOIN reviews r ON b.booking_id = r.booking_id
+LEFT JOIN payments p ON b.booking_id = p.booking_id
+WHERE EXISTS (SELECT 1 FROM listings WHERE host_id = u.id)
+GROUP BY u.id, u.first_name, u.second_name, u.email;
@@ -0,0 +1,28 @@
+-- до раскрытия отзывы не влияют на показатели хоста в отчете
+DROP MATERIALIZED VIEW IF EXISTS mv_hosts_performance;
+
+CREATE MATERIALIZED VIEW mv_hosts_performance AS
+SELECT
+    u.id AS host_id,
+    (u.first_name || ' ' || u.second_name) AS host_name,
+    u.email AS host_email,
+    COUNT(DISTINCT l.id)::INTEGER AS listings_count,
+    COUNT(DISTINCT b.booking_id)::INTEGER AS total_bookings,
+    COALESCE(AVG(r.score) FILTER (WHERE r.status = 'published'), 0.00)::DECIMAL(3,2) AS average_rating,
+    COALESCE(SUM(p.amount) FILTER (WHERE p.payment_status = 'completed'), 0.00)::DECIMAL(12,2) AS total_revenue,
+    COUNT(DISTINCT p.payment_id) FILTER (WHERE p.payment_status = 'completed')::INTEGER AS completed_payments_count,
+    COALESCE(AVG(r.cleanliness_score) FILTER (WHERE r.status = 'published'), 0.00)::DECIMAL(3,2) AS avg_cleanliness,
+    COALESCE(AVG(r.accuracy_score) FILTER (WHERE r.status = 'published'), 0.00)::DECIMAL(3,2) AS avg_accuracy,
+    COALESCE(AVG(r.location_score) FILTER (WHERE r.status = 'published'), 0.00)::DECIMAL(3,2) AS avg_location,
+    COALESCE(AVG(r.value_score) FILTER (WHERE r.status = 'published'), 0.00)::DECIMAL(3,2) AS avg_value
+FROM users u
+JOIN listings l ON u.id = l.host_id
+LEFT JOIN bookings b ON l.id = b.listing_id AND b.host_id = u.id
+LEFT JOIN reviews r ON b.booking_id = r.booking_id AND is_booking_reviews_revealed(r.booking_id)
+LEFT JOIN payments p ON b.booking_id = p.booking_id
+GROUP BY u.id, u.first_name, u.second_name, u.email;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_mv_hosts_performance_host_id ON mv_hosts_performance(host_id);
+CREATE INDEX IF NOT EXISTS idx_mv_hosts_performance_revenue ON mv_hosts_performance(total_revenue DESC, average_rating DESC);
+
+UPDATE report_refreshes SET refreshed_at = now() WHERE view_name = 'mv_hosts_performance';
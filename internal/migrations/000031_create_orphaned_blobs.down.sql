@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_orphaned_blobs_last_attempt_at;
+DROP TABLE IF EXISTS orphaned_blobs;
@@ -0,0 +1,11 @@
+-- ключи файлов удаленных изображений; строка удаляется, когда файл удален из хранилища.
+-- ключи пишутся в одной транзакции с удалением изображения, поэтому не теряются при сбоях хранилища
+CREATE TABLE IF NOT EXISTS orphaned_blobs (
+    storage_key TEXT PRIMARY KEY,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP,
+    last_attempt_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_orphaned_blobs_last_attempt_at ON orphaned_blobs(last_attempt_at NULLS FIRST);
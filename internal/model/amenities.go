@@ -1,8 +1,63 @@
 package model
 
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Labels хранит переводы названия по коду языка: {"ru": "Кухня", "en": "Kitchen"}
+type Labels map[string]string
+
+func (l Labels) Resolve(locale string, fallback string) string {
+	if label, ok := l[locale]; ok && label != "" {
+		return label
+	}
+	return fallback
+}
+
+func (l Labels) Value() (driver.Value, error) {
+	if l == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (l *Labels) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*l = Labels{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported labels type %T", src)
+	}
+	return json.Unmarshal(data, l)
+}
+
+type AmenityCategory struct {
+	Key       string `json:"key" db:"key"`
+	Name      string `json:"name" db:"name"`
+	Label     string `json:"label" db:"-"`
+	Labels    Labels `json:"labels" db:"labels"`
+	SortOrder int    `json:"sort_order" db:"sort_order"`
+}
+
 type Amenity struct {
-	ID   int    `json:"id" db:"id"`
-	Name string `json:"name" db:"name"`
+	ID       int     `json:"id" db:"id"`
+	Name     string  `json:"name" db:"name"`
+	Category *string `json:"category,omitempty" db:"category"`
+	IconKey  *string `json:"icon_key,omitempty" db:"icon_key"`
+	Label    string  `json:"label" db:"-"`
+	Labels   Labels  `json:"labels,omitempty" db:"labels"`
 }
 
 type ListingAmenity struct {
@@ -0,0 +1,118 @@
+package model
+
+import "time"
+
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+// поля, по которым разрешена сортировка; совпадают с колонками представлений
+var (
+	ListingsAnalyticsSortFields = []string{"listing_id", "price_per_night", "average_rating", "reviews_count",
+		"bookings_count", "total_revenue", "avg_payment_amount", "active_bookings_count", "favorites_count"}
+	HostsAnalyticsSortFields = []string{"host_id", "total_listings", "total_bookings", "active_bookings",
+		"average_rating", "total_reviews", "total_revenue", "avg_booking_revenue", "failed_payments_count"}
+	BookingsAnalyticsSortFields = []string{"booking_id", "in_date", "out_date", "duration_days", "total_price",
+		"payment_amount", "paid_at", "review_score"}
+)
+
+// AnalyticsFilter общий для всех представлений; каждое учитывает только свои поля
+type AnalyticsFilter struct {
+	HostID        *int
+	GuestID       *int
+	ListingID     *int
+	IsAvailable   *bool
+	BookingStatus *string
+	PaymentStatus *string
+	StartDate     *time.Time
+	EndDate       *time.Time
+	Sort          string
+	Order         string
+	Limit         int
+	Offset        int
+}
+
+type ListingSummary struct {
+	ListingID           int     `json:"listing_id" db:"listing_id"`
+	Address             string  `json:"address" db:"address"`
+	HostID              int     `json:"host_id" db:"host_id"`
+	HostName            string  `json:"host_name" db:"host_name"`
+	PricePerNight       float64 `json:"price_per_night" db:"price_per_night"`
+	RoomsNumber         int     `json:"rooms_number" db:"rooms_number"`
+	BedsNumber          int     `json:"beds_number" db:"beds_number"`
+	IsAvailable         bool    `json:"is_available" db:"is_available"`
+	AverageRating       float64 `json:"average_rating" db:"average_rating"`
+	ReviewsCount        int     `json:"reviews_count" db:"reviews_count"`
+	BookingsCount       int     `json:"bookings_count" db:"bookings_count"`
+	TotalRevenue        float64 `json:"total_revenue" db:"total_revenue"`
+	AvgPaymentAmount    float64 `json:"avg_payment_amount" db:"avg_payment_amount"`
+	ActiveBookingsCount int     `json:"active_bookings_count" db:"active_bookings_count"`
+	FavoritesCount      int     `json:"favorites_count" db:"favorites_count"`
+	AvgCleanliness      float64 `json:"avg_cleanliness" db:"avg_cleanliness"`
+	AvgAccuracy         float64 `json:"avg_accuracy" db:"avg_accuracy"`
+	AvgLocation         float64 `json:"avg_location" db:"avg_location"`
+	AvgValue            float64 `json:"avg_value" db:"avg_value"`
+}
+
+type HostAnalytics struct {
+	HostID                 int     `json:"host_id" db:"host_id"`
+	HostName               string  `json:"host_name" db:"host_name"`
+	HostEmail              string  `json:"host_email" db:"host_email"`
+	TotalListings          int     `json:"total_listings" db:"total_listings"`
+	TotalBookings          int     `json:"total_bookings" db:"total_bookings"`
+	ActiveBookings         int     `json:"active_bookings" db:"active_bookings"`
+	AverageRating          float64 `json:"average_rating" db:"average_rating"`
+	TotalReviews           int     `json:"total_reviews" db:"total_reviews"`
+	TotalRevenue           float64 `json:"total_revenue" db:"total_revenue"`
+	AvgBookingRevenue      float64 `json:"avg_booking_revenue" db:"avg_booking_revenue"`
+	CompletedPaymentsCount int     `json:"completed_payments_count" db:"completed_payments_count"`
+	PendingPaymentsCount   int     `json:"pending_payments_count" db:"pending_payments_count"`
+	FailedPaymentsCount    int     `json:"failed_payments_count" db:"failed_payments_count"`
+}
+
+type BookingPaymentAnalytics struct {
+	BookingID      int        `json:"booking_id" db:"booking_id"`
+	ListingID      int        `json:"listing_id" db:"listing_id"`
+	ListingAddress string     `json:"listing_address" db:"listing_address"`
+	PricePerNight  float64    `json:"price_per_night" db:"price_per_night"`
+	HostID         int        `json:"host_id" db:"host_id"`
+	HostName       string     `json:"host_name" db:"host_name"`
+	GuestID        int        `json:"guest_id" db:"guest_id"`
+	GuestName      string     `json:"guest_name" db:"guest_name"`
+	InDate         time.Time  `json:"in_date" db:"in_date"`
+	OutDate        time.Time  `json:"out_date" db:"out_date"`
+	DurationDays   int        `json:"duration_days" db:"duration_days"`
+	TotalPrice     float64    `json:"total_price" db:"total_price"`
+	IsPaid         bool       `json:"is_paid" db:"is_paid"`
+	PaymentID      *int       `json:"payment_id,omitempty" db:"payment_id"`
+	PaymentAmount  *float64   `json:"payment_amount,omitempty" db:"payment_amount"`
+	PaymentMethod  *string    `json:"payment_method,omitempty" db:"payment_method"`
+	PaymentStatus  *string    `json:"payment_status,omitempty" db:"payment_status"`
+	PaidAt         *time.Time `json:"paid_at,omitempty" db:"paid_at"`
+	ReviewID       *int       `json:"review_id,omitempty" db:"review_id"`
+	ReviewScore    *int       `json:"review_score,omitempty" db:"review_score"`
+	ReviewText     *string    `json:"review_text,omitempty" db:"review_text"`
+	BookingStatus  string     `json:"booking_status" db:"booking_status"`
+}
+
+type ListingsAnalyticsPage struct {
+	Items  []ListingSummary `json:"items"`
+	Total  int              `json:"total"`
+	Limit  int              `json:"limit"`
+	Offset int              `json:"offset"`
+}
+
+type HostsAnalyticsPage struct {
+	Items  []HostAnalytics `json:"items"`
+	Total  int             `json:"total"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
+}
+
+type BookingsAnalyticsPage struct {
+	Items  []BookingPaymentAnalytics `json:"items"`
+	Total  int                       `json:"total"`
+	Limit  int                       `json:"limit"`
+	Offset int                       `json:"offset"`
+}
@@ -0,0 +1,44 @@
+package model
+
+// ListingComparable строка get_listing_comparables: само объявление или похожее на него
+type ListingComparable struct {
+	ListingID         int      `json:"listing_id" db:"listing_id"`
+	IsTarget          bool     `json:"-" db:"is_target"`
+	PricePerNight     float64  `json:"price_per_night" db:"price_per_night"`
+	AverageRating     float64  `json:"average_rating" db:"average_rating"`
+	ReviewsCount      int      `json:"reviews_count" db:"reviews_count"`
+	BookingsCount     int      `json:"bookings_count" db:"bookings_count"`
+	AvailableNights   int      `json:"available_nights" db:"available_nights"`
+	BookedNights      int      `json:"booked_nights" db:"booked_nights"`
+	OccupancyRate     float64  `json:"occupancy_rate" db:"occupancy_rate"`
+	SharedAmenities   int      `json:"shared_amenities" db:"shared_amenities"`
+	AmenitySimilarity float64  `json:"amenity_similarity" db:"amenity_similarity"`
+	DistanceKm        *float64 `json:"distance_km,omitempty" db:"distance_km"`
+}
+
+// BenchmarkPercentiles место объявления среди похожих, 0-100; nil, если сравнивать не с чем
+type BenchmarkPercentiles struct {
+	PricePerNight *float64 `json:"price_per_night"`
+	AverageRating *float64 `json:"average_rating"`
+	BookingsCount *float64 `json:"bookings_count"`
+	OccupancyRate *float64 `json:"occupancy_rate"`
+}
+
+type PriceRange struct {
+	Min    float64 `json:"min"`
+	Median float64 `json:"median"`
+	Max    float64 `json:"max"`
+}
+
+// ListingBenchmark сравнение объявления с похожими; загрузка считается за последние PeriodDays дней
+type ListingBenchmark struct {
+	ListingID        int                  `json:"listing_id"`
+	PeriodDays       int                  `json:"period_days"`
+	RadiusKm         *float64             `json:"radius_km,omitempty"`
+	LocationFiltered bool                 `json:"location_filtered"`
+	ComparablesCount int                  `json:"comparables_count"`
+	Listing          ListingComparable    `json:"listing"`
+	Percentiles      BenchmarkPercentiles `json:"percentiles"`
+	SuggestedPrice   *PriceRange          `json:"suggested_price,omitempty"`
+	Comparables      []ListingComparable  `json:"comparables"`
+}
@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+type Conversation struct {
+	ID                  int        `json:"id" db:"id"`
+	ListingID           int        `json:"listing_id" db:"listing_id"`
+	GuestID             int        `json:"guest_id" db:"guest_id"`
+	HostID              int        `json:"host_id" db:"host_id"`
+	BookingID           *int       `json:"booking_id,omitempty" db:"booking_id"`
+	LastMessageAt       *time.Time `json:"last_message_at,omitempty" db:"last_message_at"`
+	LastMessageText     *string    `json:"last_message_text,omitempty" db:"last_message_text"`
+	LastMessageSenderID *int       `json:"last_message_sender_id,omitempty" db:"last_message_sender_id"`
+	UnreadCount         int        `json:"unread_count" db:"unread_count"`
+	CreatedAt           time.Time  `json:"created_at" db:"created_at"`
+}
+
+type Message struct {
+	ID             int        `json:"id" db:"id"`
+	ConversationID int        `json:"conversation_id" db:"conversation_id"`
+	SenderID       int        `json:"sender_id" db:"sender_id"`
+	Text           string     `json:"text" db:"text"`
+	ReadAt         *time.Time `json:"read_at,omitempty" db:"read_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+}
+
+// IsParticipant проверяет, что пользователь - гость или хозяин в переписке
+func (c *Conversation) IsParticipant(userID int) bool {
+	return c.GuestID == userID || c.HostID == userID
+}
+
+// Recipient возвращает второго участника переписки
+func (c *Conversation) Recipient(senderID int) int {
+	if senderID == c.GuestID {
+		return c.HostID
+	}
+	return c.GuestID
+}
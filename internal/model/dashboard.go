@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+// HostDashboard сводка для кабинета хоста; показатели "за период" считаются за последние PeriodDays дней
+type HostDashboard struct {
+	HostID                int                     `json:"host_id" db:"-"`
+	PeriodDays            int                     `json:"period_days" db:"-"`
+	TotalRevenue          float64                 `json:"total_revenue" db:"total_revenue"`
+	PeriodRevenue         float64                 `json:"period_revenue" db:"period_revenue"`
+	AverageRating         float64                 `json:"average_rating" db:"average_rating"`
+	PendingPaymentsCount  int                     `json:"pending_payments_count" db:"pending_payments_count"`
+	PendingPaymentsAmount float64                 `json:"pending_payments_amount" db:"pending_payments_amount"`
+	AvailableNights       int                     `json:"available_nights" db:"-"`
+	BookedNights          int                     `json:"booked_nights" db:"-"`
+	OccupancyRate         float64                 `json:"occupancy_rate" db:"-"`
+	UpcomingCheckIns      []HostDashboardMovement `json:"upcoming_check_ins" db:"-"`
+	UpcomingCheckOuts     []HostDashboardMovement `json:"upcoming_check_outs" db:"-"`
+	Listings              []HostDashboardListing  `json:"listings" db:"-"`
+}
+
+// HostDashboardMovement заезд или выезд гостя в ближайшие дни
+type HostDashboardMovement struct {
+	BookingID      int       `json:"booking_id" db:"booking_id"`
+	ListingID      int       `json:"listing_id" db:"listing_id"`
+	ListingAddress string    `json:"listing_address" db:"listing_address"`
+	GuestID        int       `json:"guest_id" db:"guest_id"`
+	GuestName      string    `json:"guest_name" db:"guest_name"`
+	InDate         time.Time `json:"in_date" db:"in_date" example:"2025-12-12T14:00:00+03:00"`
+	OutDate        time.Time `json:"out_date" db:"out_date" example:"2025-12-15T14:00:00+03:00"`
+}
+
+type HostDashboardListing struct {
+	ListingID       int     `json:"listing_id" db:"listing_id"`
+	Title           string  `json:"title" db:"title"`
+	Address         string  `json:"address" db:"address"`
+	PricePerNight   float64 `json:"price_per_night" db:"price_per_night"`
+	IsAvailable     bool    `json:"is_available" db:"is_available"`
+	AverageRating   float64 `json:"average_rating" db:"average_rating"`
+	ReviewsCount    int     `json:"reviews_count" db:"reviews_count"`
+	ActiveBookings  int     `json:"active_bookings" db:"active_bookings"`
+	TotalRevenue    float64 `json:"total_revenue" db:"total_revenue"`
+	PeriodRevenue   float64 `json:"period_revenue" db:"period_revenue"`
+	AvailableNights int     `json:"available_nights" db:"available_nights"`
+	BookedNights    int     `json:"booked_nights" db:"booked_nights"`
+	OccupancyRate   float64 `json:"occupancy_rate" db:"-"`
+}
@@ -1,7 +1,38 @@
 package model
 
+import "time"
+
+const DefaultWishlistName = "Избранное"
+
 type Favorite struct {
-	ID        int `json:"id" db:"id"`
-	UserID    int `json:"user_id" db:"user_id"`
-	ListingID int `json:"listing_id" db:"listing_id"`
+	ID         int          `json:"id" db:"id"`
+	UserID     int          `json:"user_id" db:"user_id"`
+	ListingID  int          `json:"listing_id" db:"listing_id"`
+	WishlistID int          `json:"wishlist_id" db:"wishlist_id"`
+	Note       *string      `json:"note,omitempty" db:"note"`
+	CreatedAt  time.Time    `json:"created_at" db:"created_at"`
+	Listing    *ListingCard `json:"listing,omitempty" db:"-"`
+}
+
+type Wishlist struct {
+	ID         int        `json:"id" db:"id"`
+	UserID     int        `json:"user_id" db:"user_id"`
+	Name       string     `json:"name" db:"name"`
+	IsDefault  bool       `json:"is_default" db:"is_default"`
+	ShareToken *string    `json:"share_token,omitempty" db:"share_token"`
+	ItemsCount int        `json:"items_count" db:"items_count"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+	Items      []Favorite `json:"items,omitempty" db:"-"`
+}
+
+// ListingCard - краткая карточка объявления для списков и подборок
+type ListingCard struct {
+	ListingID       int     `json:"listing_id" db:"listing_id"`
+	Address         string  `json:"address" db:"address"`
+	PricePerNight   float64 `json:"price_per_night" db:"price_per_night"`
+	IsAvailable     bool    `json:"is_available" db:"is_available"`
+	AverageRating   float64 `json:"average_rating" db:"average_rating"`
+	ReviewsCount    int     `json:"reviews_count" db:"reviews_count"`
+	PrimaryImageURL *string `json:"primary_image_url,omitempty" db:"primary_image_url"`
 }
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+type GuestReview struct {
+	ID        int       `json:"id" db:"id"`
+	BookingID int       `json:"booking_id" db:"booking_id"`
+	HostID    int       `json:"host_id" db:"host_id"`
+	GuestID   int       `json:"guest_id" db:"guest_id"`
+	Text      string    `json:"text" db:"text"`
+	Score     int       `json:"score" db:"score"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	Revealed  bool      `json:"revealed" db:"-"`
+}
+
+type GuestReputation struct {
+	GuestID       int     `json:"guest_id" db:"guest_id"`
+	AverageRating float64 `json:"average_rating" db:"average_rating"`
+	ReviewsCount  int     `json:"reviews_count" db:"reviews_count"`
+}
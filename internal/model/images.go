@@ -2,11 +2,42 @@ package model
 
 import "time"
 
+const (
+	ImageProcessingPending    = "pending"
+	ImageProcessingProcessing = "processing"
+	ImageProcessingReady      = "ready"
+	ImageProcessingFailed     = "failed"
+
+	ImageVariantThumbnail = "thumbnail"
+	ImageVariantMedium    = "medium"
+	ImageVariantLarge     = "large"
+)
+
 type Image struct {
-	ImageID    int       `json:"image_id" db:"image_id"`
-	ListingID  int       `json:"listing_id" db:"listing_id"`
-	ImageURL   string    `json:"image_url" db:"image_url"`
-	IsPrimary  bool      `json:"is_primary" db:"is_primary"`
-	OrderIndex int       `json:"order_index" db:"order_index"`
-	UploadedAt time.Time `json:"uploaded_at" db:"uploaded_at"`
+	ImageID          int            `json:"image_id" db:"image_id"`
+	ListingID        int            `json:"listing_id" db:"listing_id"`
+	ImageURL         string         `json:"image_url" db:"image_url"`
+	IsPrimary        bool           `json:"is_primary" db:"is_primary"`
+	OrderIndex       int            `json:"order_index" db:"order_index"`
+	UploadedAt       time.Time      `json:"uploaded_at" db:"uploaded_at"`
+	StorageKey       *string        `json:"-" db:"storage_key"`
+	ContentType      *string        `json:"content_type,omitempty" db:"content_type"`
+	SizeBytes        *int64         `json:"size_bytes,omitempty" db:"size_bytes"`
+	ProcessingStatus *string        `json:"processing_status,omitempty" db:"processing_status"`
+	ProcessingError  *string        `json:"-" db:"processing_error"`
+	ProcessedAt      *time.Time     `json:"processed_at,omitempty" db:"processed_at"`
+	Variants         []ImageVariant `json:"variants,omitempty" db:"-"`
+}
+
+type ImageVariant struct {
+	ID          int       `json:"-" db:"id"`
+	ImageID     int       `json:"-" db:"image_id"`
+	Variant     string    `json:"variant" db:"variant"`
+	StorageKey  string    `json:"-" db:"storage_key"`
+	ImageURL    string    `json:"image_url" db:"image_url"`
+	ContentType string    `json:"content_type" db:"content_type"`
+	Width       int       `json:"width" db:"width"`
+	Height      int       `json:"height" db:"height"`
+	SizeBytes   int64     `json:"size_bytes" db:"size_bytes"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 }
@@ -0,0 +1,49 @@
+package model
+
+type ListingsSearchFilter struct {
+	Query         *string
+	MinPrice      *float64
+	MaxPrice      *float64
+	MinRooms      *int
+	MinBeds       *int
+	AvailableOnly bool
+	AmenityIDs    []int
+	City          *string
+	Region        *string
+	Country       *string
+	Latitude      *float64
+	Longitude     *float64
+	RadiusKm      *float64
+	Bounds        *GeoBounds
+	Limit         int
+	Offset        int
+}
+
+// GeoBounds - прямоугольник видимой области карты; MinLongitude > MaxLongitude означает,
+// что область пересекает 180-й меридиан
+type GeoBounds struct {
+	MinLatitude  float64
+	MinLongitude float64
+	MaxLatitude  float64
+	MaxLongitude float64
+}
+
+func (b GeoBounds) CrossesAntimeridian() bool {
+	return b.MinLongitude > b.MaxLongitude
+}
+
+type AmenityFacet struct {
+	AmenityID     int     `json:"amenity_id" db:"amenity_id"`
+	Name          string  `json:"name" db:"name"`
+	Label         string  `json:"label" db:"-"`
+	Labels        Labels  `json:"-" db:"labels"`
+	Category      *string `json:"category,omitempty" db:"category"`
+	IconKey       *string `json:"icon_key,omitempty" db:"icon_key"`
+	ListingsCount int     `json:"listings_count" db:"listings_count"`
+}
+
+type ListingsSearchResult struct {
+	Listings      []Listing      `json:"listings"`
+	Total         int            `json:"total"`
+	AmenityFacets []AmenityFacet `json:"amenity_facets"`
+}
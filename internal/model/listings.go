@@ -1,11 +1,23 @@
 package model
 
 type Listing struct {
-	ID            int     `json:"id" db:"id"`
-	HostID        int     `json:"host_id" db:"host_id"`
-	Address       string  `json:"address" db:"address"`
-	PricePerNight float64 `json:"price_per_night" db:"price_per_night"`
-	IsAvailable   bool    `json:"is_available" db:"is_available"`
-	RoomsNumber   int     `json:"rooms_number" db:"rooms_number"`
-	BedsNumber    int     `json:"beds_number" db:"beds_number"`
+	ID            int      `json:"id" db:"id"`
+	HostID        int      `json:"host_id" db:"host_id"`
+	Title         string   `json:"title" db:"title"`
+	Description   string   `json:"description" db:"description"`
+	Address       string   `json:"address" db:"address"`
+	Street        *string  `json:"street,omitempty" db:"street"`
+	City          *string  `json:"city,omitempty" db:"city"`
+	Region        *string  `json:"region,omitempty" db:"region"`
+	PostalCode    *string  `json:"postal_code,omitempty" db:"postal_code"`
+	Country       *string  `json:"country,omitempty" db:"country"`
+	PricePerNight float64  `json:"price_per_night" db:"price_per_night"`
+	IsAvailable   bool     `json:"is_available" db:"is_available"`
+	RoomsNumber   int      `json:"rooms_number" db:"rooms_number"`
+	BedsNumber    int      `json:"beds_number" db:"beds_number"`
+	Latitude      *float64 `json:"latitude,omitempty" db:"latitude"`
+	Longitude     *float64 `json:"longitude,omitempty" db:"longitude"`
+	DistanceKm    *float64 `json:"distance_km,omitempty" db:"distance_km"`
+	SearchRank    *float64 `json:"search_rank,omitempty" db:"search_rank"`
+	Snippet       *string  `json:"snippet,omitempty" db:"snippet"`
 }
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+const (
+	NotificationTypePriceDrop    = "price_drop"
+	NotificationTypeAvailability = "availability"
+	NotificationTypeMessage      = "message"
+)
+
+type Notification struct {
+	ID          int        `json:"id" db:"id"`
+	UserID      int        `json:"user_id" db:"user_id"`
+	ListingID   int        `json:"listing_id" db:"listing_id"`
+	Type        string     `json:"type" db:"type"`
+	Message     string     `json:"message" db:"message"`
+	OldPrice    *float64   `json:"old_price,omitempty" db:"old_price"`
+	NewPrice    *float64   `json:"new_price,omitempty" db:"new_price"`
+	IsRead      bool       `json:"is_read" db:"is_read"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty" db:"delivered_at"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+
+	DeliveryAttempts int `json:"-" db:"delivery_attempts"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+const (
+	DiscrepancyPaidWithoutPayment        = "paid_without_payment"
+	DiscrepancyCompletedPaymentNotMarked = "completed_payment_not_marked_paid"
+	DiscrepancyAmountMismatch            = "amount_mismatch"
+	DiscrepancyOrphanedPayment           = "orphaned_payment"
+	DiscrepancyDuplicateCompletedPayment = "duplicate_completed_payment"
+)
+
+type PaymentDiscrepancy struct {
+	Type           string   `json:"type" db:"discrepancy_type"`
+	BookingID      *int     `json:"booking_id,omitempty" db:"booking_id"`
+	PaymentID      *int     `json:"payment_id,omitempty" db:"payment_id"`
+	ExpectedAmount *float64 `json:"expected_amount,omitempty" db:"expected_amount"`
+	ActualAmount   float64  `json:"actual_amount" db:"actual_amount"`
+	PaymentsCount  int      `json:"payments_count" db:"payments_count"`
+	Fixable        bool     `json:"fixable" db:"-"`
+	Fixed          bool     `json:"fixed" db:"-"`
+}
+
+type PaymentReconciliationReport struct {
+	GeneratedAt   time.Time            `json:"generated_at"`
+	TotalCount    int                  `json:"total_count"`
+	FixedCount    int                  `json:"fixed_count"`
+	CountsByType  map[string]int       `json:"counts_by_type"`
+	Discrepancies []PaymentDiscrepancy `json:"discrepancies"`
+}
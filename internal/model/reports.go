@@ -3,16 +3,36 @@ package model
 import "time"
 
 type ListingStatisticsReport struct {
-	ListingID     int     `json:"listing_id" db:"listing_id"`
-	Address       string  `json:"address" db:"address"`
-	HostID        int     `json:"host_id" db:"host_id"`
-	HostName      string  `json:"host_name" db:"host_name"`
-	PricePerNight float64 `json:"price_per_night" db:"price_per_night"`
-	AverageRating float64 `json:"average_rating" db:"average_rating"`
-	ReviewsCount  int     `json:"reviews_count" db:"reviews_count"`
+	ListingID      int     `json:"listing_id" db:"listing_id"`
+	Address        string  `json:"address" db:"address"`
+	HostID         int     `json:"host_id" db:"host_id"`
+	HostName       string  `json:"host_name" db:"host_name"`
+	PricePerNight  float64 `json:"price_per_night" db:"price_per_night"`
+	AverageRating  float64 `json:"average_rating" db:"average_rating"`
+	ReviewsCount   int     `json:"reviews_count" db:"reviews_count"`
+	BookingsCount  int     `json:"bookings_count" db:"bookings_count"`
+	TotalRevenue   float64 `json:"total_revenue" db:"total_revenue"`
+	IsAvailable    bool    `json:"is_available" db:"is_available"`
+	AvgCleanliness float64 `json:"avg_cleanliness" db:"avg_cleanliness"`
+	AvgAccuracy    float64 `json:"avg_accuracy" db:"avg_accuracy"`
+	AvgLocation    float64 `json:"avg_location" db:"avg_location"`
+	AvgValue       float64 `json:"avg_value" db:"avg_value"`
+}
+
+// материализованные представления отчетов
+const (
+	ReportViewListingsStatistics = "mv_listings_statistics"
+	ReportViewHostsPerformance   = "mv_hosts_performance"
+)
+
+type CityStatisticsReport struct {
+	Country       *string `json:"country" db:"country"`
+	City          *string `json:"city" db:"city"`
+	ListingsCount int     `json:"listings_count" db:"listings_count"`
 	BookingsCount int     `json:"bookings_count" db:"bookings_count"`
 	TotalRevenue  float64 `json:"total_revenue" db:"total_revenue"`
-	IsAvailable   bool    `json:"is_available" db:"is_available"`
+	AverageRating float64 `json:"average_rating" db:"average_rating"`
+	ReviewsCount  int     `json:"reviews_count" db:"reviews_count"`
 }
 
 type HostPerformanceReport struct {
@@ -24,6 +44,10 @@ type HostPerformanceReport struct {
 	AverageRating          float64 `json:"average_rating" db:"average_rating"`
 	TotalRevenue           float64 `json:"total_revenue" db:"total_revenue"`
 	CompletedPaymentsCount int     `json:"completed_payments_count" db:"completed_payments_count"`
+	AvgCleanliness         float64 `json:"avg_cleanliness" db:"avg_cleanliness"`
+	AvgAccuracy            float64 `json:"avg_accuracy" db:"avg_accuracy"`
+	AvgLocation            float64 `json:"avg_location" db:"avg_location"`
+	AvgValue               float64 `json:"avg_value" db:"avg_value"`
 }
 
 type BookingReport struct {
@@ -54,6 +78,126 @@ type PaymentSummaryReport struct {
 	MaxAmount         float64 `json:"max_amount" db:"max_amount"`
 }
 
+// ListingOccupancyReport загрузка объявления за период; ADR - средняя цена занятой ночи, RevPAR - выручка на доступную ночь
+type ListingOccupancyReport struct {
+	ListingID       int     `json:"listing_id" db:"listing_id"`
+	Address         string  `json:"address" db:"address"`
+	HostID          int     `json:"host_id" db:"host_id"`
+	HostName        string  `json:"host_name" db:"host_name"`
+	AvailableNights int     `json:"available_nights" db:"available_nights"`
+	BookedNights    int     `json:"booked_nights" db:"booked_nights"`
+	OccupancyRate   float64 `json:"occupancy_rate" db:"occupancy_rate"`
+	Revenue         float64 `json:"revenue" db:"revenue"`
+	ADR             float64 `json:"adr" db:"adr"`
+	RevPAR          float64 `json:"revpar" db:"revpar"`
+}
+
+type HostOccupancyReport struct {
+	HostID          int     `json:"host_id" db:"host_id"`
+	HostName        string  `json:"host_name" db:"host_name"`
+	ListingsCount   int     `json:"listings_count" db:"listings_count"`
+	AvailableNights int     `json:"available_nights" db:"available_nights"`
+	BookedNights    int     `json:"booked_nights" db:"booked_nights"`
+	OccupancyRate   float64 `json:"occupancy_rate" db:"occupancy_rate"`
+	Revenue         float64 `json:"revenue" db:"revenue"`
+	ADR             float64 `json:"adr" db:"adr"`
+	RevPAR          float64 `json:"revpar" db:"revpar"`
+}
+
+const (
+	TimeseriesMetricRevenue  = "revenue"
+	TimeseriesMetricRefunds  = "refunds"
+	TimeseriesMetricBookings = "bookings"
+
+	TimeseriesGranularityDay   = "day"
+	TimeseriesGranularityWeek  = "week"
+	TimeseriesGranularityMonth = "month"
+)
+
+type TimeseriesFilter struct {
+	Metric      string
+	Granularity string
+	From        *time.Time
+	To          *time.Time
+	HostID      *int
+	ListingID   *int
+}
+
+// TimeseriesPoint интервал ряда: Amount - сумма платежей или стоимость броней, EventsCount - их количество
+type TimeseriesPoint struct {
+	Bucket      time.Time `json:"bucket" db:"bucket" example:"2025-01-01T00:00:00Z"`
+	Amount      float64   `json:"amount" db:"amount"`
+	EventsCount int       `json:"events_count" db:"events_count"`
+}
+
+type TimeseriesReport struct {
+	Metric      string            `json:"metric"`
+	Granularity string            `json:"granularity"`
+	From        time.Time         `json:"from"`
+	To          time.Time         `json:"to"`
+	HostID      *int              `json:"host_id,omitempty"`
+	ListingID   *int              `json:"listing_id,omitempty"`
+	Points      []TimeseriesPoint `json:"points"`
+}
+
+// GuestCohortCell ячейка когортного отчета: когорта - месяц первого бронирования гостя,
+// MonthOffset - номер месяца после него
+type GuestCohortCell struct {
+	CohortMonth     time.Time `json:"cohort_month" db:"cohort_month" example:"2025-01-01T00:00:00Z"`
+	CohortSize      int       `json:"cohort_size" db:"cohort_size"`
+	MonthOffset     int       `json:"month_offset" db:"month_offset"`
+	ActiveGuests    int       `json:"active_guests" db:"active_guests"`
+	RetentionRate   float64   `json:"retention_rate" db:"retention_rate"`
+	CumulativeSpend float64   `json:"cumulative_spend" db:"cumulative_spend"`
+}
+
+// GuestCohort строка матрицы; i-й элемент каждого массива относится к i-му месяцу после первого бронирования
+type GuestCohort struct {
+	CohortMonth     string    `json:"cohort_month" example:"2025-01"`
+	CohortSize      int       `json:"cohort_size"`
+	ActiveGuests    []int     `json:"active_guests"`
+	RetentionRates  []float64 `json:"retention_rates"`
+	CumulativeSpend []float64 `json:"cumulative_spend"`
+}
+
+type GuestCohortReport struct {
+	MonthOffsets []int         `json:"month_offsets"`
+	Cohorts      []GuestCohort `json:"cohorts"`
+}
+
+// ListingCancellationReport отмены восстанавливаются из audit_log; AvgLeadTimeDays - сколько дней до заезда в среднем отменяли
+type ListingCancellationReport struct {
+	ListingID          int     `json:"listing_id" db:"listing_id"`
+	Address            string  `json:"address" db:"address"`
+	HostID             int     `json:"host_id" db:"host_id"`
+	BookingsCount      int     `json:"bookings_count" db:"bookings_count"`
+	CancellationsCount int     `json:"cancellations_count" db:"cancellations_count"`
+	CancellationRate   float64 `json:"cancellation_rate" db:"cancellation_rate"`
+	AvgLeadTimeDays    float64 `json:"avg_lead_time_days" db:"avg_lead_time_days"`
+	RefundedAmount     float64 `json:"refunded_amount" db:"refunded_amount"`
+}
+
+type HostCancellationReport struct {
+	HostID             int     `json:"host_id" db:"host_id"`
+	HostName           string  `json:"host_name" db:"host_name"`
+	BookingsCount      int     `json:"bookings_count" db:"bookings_count"`
+	CancellationsCount int     `json:"cancellations_count" db:"cancellations_count"`
+	CancellationRate   float64 `json:"cancellation_rate" db:"cancellation_rate"`
+	AvgLeadTimeDays    float64 `json:"avg_lead_time_days" db:"avg_lead_time_days"`
+	RefundedAmount     float64 `json:"refunded_amount" db:"refunded_amount"`
+}
+
+// PaymentFailureReport FailureRate - доля failed среди completed и failed платежей
+type PaymentFailureReport struct {
+	PaymentMethod  string  `json:"payment_method" db:"payment_method"`
+	PaymentsCount  int     `json:"payments_count" db:"payments_count"`
+	CompletedCount int     `json:"completed_count" db:"completed_count"`
+	FailedCount    int     `json:"failed_count" db:"failed_count"`
+	PendingCount   int     `json:"pending_count" db:"pending_count"`
+	RefundedCount  int     `json:"refunded_count" db:"refunded_count"`
+	FailureRate    float64 `json:"failure_rate" db:"failure_rate"`
+}
+
 type CreateBookingWithPaymentResult struct {
 	BookingID int `json:"booking_id" db:"p_booking_id"`
 	PaymentID int `json:"payment_id" db:"p_payment_id"`
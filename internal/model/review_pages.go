@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+const (
+	ReviewsSortNewest = "newest"
+	ReviewsSortScore  = "score"
+)
+
+type ReviewCursor struct {
+	ID        int
+	Score     int
+	CreatedAt time.Time
+}
+
+type ReviewsFilter struct {
+	ListingID       *int
+	UserID          *int
+	Sort            string
+	Cursor          *ReviewCursor
+	Limit           int
+	RevealAfterDays int
+}
+
+type ReviewsSummary struct {
+	Count     int         `json:"count"`
+	Average   float64     `json:"average"`
+	Histogram map[int]int `json:"histogram"`
+}
+
+type ReviewsPage struct {
+	Reviews    []Review       `json:"reviews"`
+	NextCursor *string        `json:"next_cursor,omitempty"`
+	Summary    ReviewsSummary `json:"summary"`
+}
@@ -1,9 +1,28 @@
 package model
 
+import "time"
+
+const (
+	ReviewStatusPending   = "pending"
+	ReviewStatusPublished = "published"
+	ReviewStatusHidden    = "hidden"
+)
+
 type Review struct {
-	ID        int    `json:"id" db:"id"`
-	BookingID int    `json:"booking_id" db:"booking_id"`
-	UserID    int    `json:"user_id" db:"user_id"`
-	Text      string `json:"text" db:"text"`
-	Score     int    `json:"score" db:"score"`
+	ID             int        `json:"id" db:"id"`
+	BookingID      int        `json:"booking_id" db:"booking_id"`
+	UserID         int        `json:"user_id" db:"user_id"`
+	Text           string     `json:"text" db:"text"`
+	Score          int        `json:"score" db:"score"`
+	Cleanliness    *int       `json:"cleanliness_score,omitempty" db:"cleanliness_score"`
+	Accuracy       *int       `json:"accuracy_score,omitempty" db:"accuracy_score"`
+	Location       *int       `json:"location_score,omitempty" db:"location_score"`
+	Value          *int       `json:"value_score,omitempty" db:"value_score"`
+	Status         string     `json:"status" db:"status"`
+	FlagReason     *string    `json:"flag_reason,omitempty" db:"flag_reason"`
+	HostResponse   *string    `json:"host_response,omitempty" db:"host_response"`
+	HostResponseAt *time.Time `json:"host_response_at,omitempty" db:"host_response_at"`
+	CreatedAt      time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt      *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+	Revealed       bool       `json:"revealed" db:"-"`
 }
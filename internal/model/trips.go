@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// статусы поездки совпадают с booking_status представления bookings_payments_analytics
+const (
+	TripStatusUpcoming  = "upcoming"
+	TripStatusActive    = "active"
+	TripStatusCompleted = "completed"
+)
+
+// Trip бронирование гостя с последним платежом по нему
+type Trip struct {
+	BookingID      int          `json:"booking_id" db:"booking_id"`
+	ListingID      int          `json:"listing_id" db:"listing_id"`
+	HostID         int          `json:"host_id" db:"host_id"`
+	HostName       string       `json:"host_name" db:"host_name"`
+	InDate         time.Time    `json:"in_date" db:"in_date" example:"2025-12-12T14:00:00+03:00"`
+	OutDate        time.Time    `json:"out_date" db:"out_date" example:"2025-12-15T14:00:00+03:00"`
+	DurationDays   int          `json:"duration_days" db:"duration_days"`
+	TotalPrice     float64      `json:"total_price" db:"total_price"`
+	IsPaid         bool         `json:"is_paid" db:"is_paid"`
+	PaymentStatus  *string      `json:"payment_status,omitempty" db:"payment_status"`
+	PaymentAmount  *float64     `json:"payment_amount,omitempty" db:"payment_amount"`
+	PaidAt         *time.Time   `json:"paid_at,omitempty" db:"paid_at"`
+	ReviewID       *int         `json:"review_id,omitempty" db:"review_id"`
+	Status         string       `json:"status" db:"booking_status"`
+	ReviewDeadline *time.Time   `json:"review_deadline,omitempty" db:"-"`
+	Listing        *ListingCard `json:"listing,omitempty" db:"-"`
+}
+
+type GuestTrips struct {
+	GuestID        int     `json:"guest_id"`
+	TotalSpent     float64 `json:"total_spent"`
+	Upcoming       []Trip  `json:"upcoming"`
+	Current        []Trip  `json:"current"`
+	Past           []Trip  `json:"past"`
+	PendingReviews []Trip  `json:"pending_reviews"`
+}
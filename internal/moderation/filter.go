@@ -0,0 +1,103 @@
+package moderation
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// слова ищутся целиком; "*" в конце разрешает любое окончание (основа слова)
+var (
+	defaultBannedWords = []string{
+		"fuck*", "shit*", "bitch*", "asshole*", "bastard*", "dick",
+		"бля*", "сука", "суки", "хуй*", "пизд*", "ебат*", "мудак*",
+	}
+	defaultSpamWords = []string{
+		"casino", "viagra", "crypto giveaway", "free money", "click here", "whatsapp",
+	}
+	linkPattern = regexp.MustCompile(`(?i)(https?://|www\.)\S+`)
+)
+
+type WordListFilter struct {
+	bannedWords   []*regexp.Regexp
+	spamWords     []*regexp.Regexp
+	maxLinks      int
+	maxRepeatRune int
+}
+
+func NewWordListFilter(bannedWords, spamWords []string) *WordListFilter {
+	return &WordListFilter{
+		bannedWords:   compileWordPatterns(bannedWords),
+		spamWords:     compileWordPatterns(spamWords),
+		maxLinks:      1,
+		maxRepeatRune: 6,
+	}
+}
+
+func NewDefaultFilter() *WordListFilter {
+	return NewWordListFilter(defaultBannedWords, defaultSpamWords)
+}
+
+func (f *WordListFilter) Check(text string) (flagged bool, reason string) {
+	lower := strings.ToLower(text)
+
+	for _, word := range f.bannedWords {
+		if word.MatchString(lower) {
+			return true, "profanity"
+		}
+	}
+
+	for _, word := range f.spamWords {
+		if word.MatchString(lower) {
+			return true, "spam"
+		}
+	}
+
+	if len(linkPattern.FindAllString(text, -1)) > f.maxLinks {
+		return true, "spam: too many links"
+	}
+
+	if hasLongRepeat(lower, f.maxRepeatRune) {
+		return true, "spam: repeated characters"
+	}
+
+	return false, ""
+}
+
+// compileWordPatterns строит выражения с границами слов; \b в Go понимает только ASCII,
+// поэтому граница задается явно через буквы и цифры Unicode
+func compileWordPatterns(words []string) []*regexp.Regexp {
+	patterns := make([]*regexp.Regexp, 0, len(words))
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		suffix := `(?:$|[^\p{L}\p{N}])`
+		if stem, ok := strings.CutSuffix(word, "*"); ok {
+			word = stem
+			suffix = ``
+		}
+		if word == "" {
+			continue
+		}
+		patterns = append(patterns, regexp.MustCompile(`(?:^|[^\p{L}\p{N}])`+regexp.QuoteMeta(word)+suffix))
+	}
+
+	return patterns
+}
+
+func hasLongRepeat(text string, limit int) bool {
+	var prev rune
+	count := 0
+	for _, r := range text {
+		if r == prev && !unicode.IsSpace(r) {
+			count++
+			if count >= limit {
+				return true
+			}
+			continue
+		}
+		prev = r
+		count = 1
+	}
+
+	return false
+}
@@ -0,0 +1,94 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"github.com/Rissochek/db-cw/internal/utils"
+	"go.uber.org/zap"
+)
+
+var emailSubjects = map[string]string{
+	model.NotificationTypePriceDrop:    "Цена на избранное жилье снизилась",
+	model.NotificationTypeAvailability: "Избранное жилье снова доступно",
+}
+
+type EmailConfig struct {
+	Host     string
+	Port     string
+	From     string
+	Username string
+	Password string
+}
+
+type EmailChannel struct {
+	config EmailConfig
+}
+
+func NewEmailChannel(config EmailConfig) *EmailChannel {
+	return &EmailChannel{
+		config: config,
+	}
+}
+
+// NewEmailChannelFromEnv по умолчанию смотрит на локальный SMTP-приемник (mailpit) без авторизации
+func NewEmailChannelFromEnv() *EmailChannel {
+	return NewEmailChannel(EmailConfig{
+		Host:     utils.GetKeyFromEnvOrDefault("SMTP_HOST", "localhost"),
+		Port:     utils.GetKeyFromEnvOrDefault("SMTP_PORT", "1025"),
+		From:     utils.GetKeyFromEnvOrDefault("SMTP_FROM", "noreply@db-cw.local"),
+		Username: utils.GetKeyFromEnvOrDefault("SMTP_USERNAME", ""),
+		Password: utils.GetKeyFromEnvOrDefault("SMTP_PASSWORD", ""),
+	})
+}
+
+func (ec *EmailChannel) Deliver(ctx context.Context, user *model.User, notification *model.Notification) error {
+	if user.Email == "" {
+		return fmt.Errorf("user %d has no email", user.ID)
+	}
+
+	// адрес попадает в заголовок To, поэтому принимаем только корректный адрес без переводов строк
+	to, err := mail.ParseAddress(user.Email)
+	if err != nil || strings.ContainsAny(to.Address, "\r\n") {
+		return fmt.Errorf("user %d has invalid email", user.ID)
+	}
+
+	var auth smtp.Auth
+	if ec.config.Username != "" {
+		auth = smtp.PlainAuth("", ec.config.Username, ec.config.Password, ec.config.Host)
+	}
+
+	subject, ok := emailSubjects[notification.Type]
+	if !ok {
+		subject = "Уведомление"
+	}
+
+	addr := net.JoinHostPort(ec.config.Host, ec.config.Port)
+	if err := smtp.SendMail(addr, auth, ec.config.From, []string{to.Address}, ec.message(to, subject, notification.Message)); err != nil {
+		zap.S().Errorf("failed to send email to %v: %v", to.Address, err)
+		return fmt.Errorf("failed to send email")
+	}
+
+	return nil
+}
+
+func (ec *EmailChannel) message(to *mail.Address, subject string, body string) []byte {
+	var sb strings.Builder
+	sb.WriteString("From: " + ec.config.From + "\r\n")
+	sb.WriteString("To: " + (&mail.Address{Address: to.Address}).String() + "\r\n")
+	sb.WriteString("Subject: " + mime.BEncoding.Encode("UTF-8", subject) + "\r\n")
+	sb.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	sb.WriteString("MIME-Version: 1.0\r\n")
+	sb.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	sb.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	sb.WriteString("\r\n")
+	sb.WriteString(body + "\r\n")
+	return []byte(sb.String())
+}
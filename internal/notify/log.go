@@ -0,0 +1,20 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"go.uber.org/zap"
+)
+
+// LogChannel только пишет уведомление в лог; используется, когда почта не настроена
+type LogChannel struct{}
+
+func NewLogChannel() *LogChannel {
+	return &LogChannel{}
+}
+
+func (lc *LogChannel) Deliver(ctx context.Context, user *model.User, notification *model.Notification) error {
+	zap.S().Infof("notification %d for %v: %v", notification.ID, user.Email, notification.Message)
+	return nil
+}
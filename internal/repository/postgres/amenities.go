@@ -6,13 +6,15 @@ import (
 	"fmt"
 
 	"github.com/Rissochek/db-cw/internal/model"
+	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
 )
 
 func (pg *Postgres) CreateAmenity(ctx context.Context, amenity *model.Amenity) error {
-	query := `INSERT INTO amenities (name) VALUES ($1) RETURNING id`
+	query := `INSERT INTO amenities (name, category, icon_key, labels) VALUES ($1, $2, $3, $4) RETURNING id`
 
-	err := pg.conn.QueryRowxContext(ctx, query, amenity.Name).Scan(&amenity.ID)
+	err := pg.conn.QueryRowxContext(ctx, query, amenity.Name, amenity.Category, amenity.IconKey,
+		amenity.Labels).Scan(&amenity.ID)
 	if err != nil {
 		zap.S().Errorf("failed to create amenity: %v", err)
 		return fmt.Errorf("failed to create amenity")
@@ -23,7 +25,7 @@ func (pg *Postgres) CreateAmenity(ctx context.Context, amenity *model.Amenity) e
 
 func (pg *Postgres) GetAmenityByID(ctx context.Context, id int) (*model.Amenity, error) {
 	var amenity model.Amenity
-	query := `SELECT id, name FROM amenities WHERE id = $1`
+	query := `SELECT id, name, category, icon_key, labels FROM amenities WHERE id = $1`
 	err := pg.conn.GetContext(ctx, &amenity, query, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -36,10 +38,14 @@ func (pg *Postgres) GetAmenityByID(ctx context.Context, id int) (*model.Amenity,
 	return &amenity, nil
 }
 
-func (pg *Postgres) GetAllAmenities(ctx context.Context) ([]model.Amenity, error) {
+func (pg *Postgres) GetAllAmenities(ctx context.Context, category *string) ([]model.Amenity, error) {
 	var amenities []model.Amenity
-	query := `SELECT id, name FROM amenities ORDER BY id`
-	err := pg.conn.SelectContext(ctx, &amenities, query)
+	query := `SELECT a.id, a.name, a.category, a.icon_key, a.labels 
+		FROM amenities a
+		LEFT JOIN amenity_categories c ON c.key = a.category
+		WHERE $1::text IS NULL OR a.category = $1
+		ORDER BY c.sort_order NULLS LAST, a.id`
+	err := pg.conn.SelectContext(ctx, &amenities, query, category)
 	if err != nil {
 		zap.S().Errorf("failed to get all amenities: %v", err)
 		return nil, fmt.Errorf("failed to get all amenities")
@@ -48,9 +54,10 @@ func (pg *Postgres) GetAllAmenities(ctx context.Context) ([]model.Amenity, error
 }
 
 func (pg *Postgres) UpdateAmenity(ctx context.Context, amenity *model.Amenity) error {
-	query := `UPDATE amenities SET name = $1 WHERE id = $2`
+	query := `UPDATE amenities SET name = $1, category = $2, icon_key = $3, labels = $4 WHERE id = $5`
 
-	result, err := pg.conn.ExecContext(ctx, query, amenity.Name, amenity.ID)
+	result, err := pg.conn.ExecContext(ctx, query, amenity.Name, amenity.Category, amenity.IconKey,
+		amenity.Labels, amenity.ID)
 	if err != nil {
 		zap.S().Errorf("failed to update amenity: %v", err)
 		return fmt.Errorf("failed to update amenity")
@@ -130,10 +137,11 @@ func (pg *Postgres) RemoveAmenityFromListing(ctx context.Context, listingID int,
 
 func (pg *Postgres) GetAmenitiesByListingID(ctx context.Context, listingID int) ([]model.Amenity, error) {
 	var amenities []model.Amenity
-	query := `SELECT a.id, a.name FROM amenities a
+	query := `SELECT a.id, a.name, a.category, a.icon_key, a.labels FROM amenities a
 		INNER JOIN listing_amenities la ON a.id = la.amenity_id
+		LEFT JOIN amenity_categories c ON c.key = a.category
 		WHERE la.listing_id = $1
-		ORDER BY a.id`
+		ORDER BY c.sort_order NULLS LAST, a.id`
 	err := pg.conn.SelectContext(ctx, &amenities, query, listingID)
 	if err != nil {
 		zap.S().Errorf("failed to get amenities for listing %d: %v", listingID, err)
@@ -180,3 +188,80 @@ func (pg *Postgres) CreateListingAmenities(ctx context.Context, listingAmenities
 
 	return nil
 }
+
+func (pg *Postgres) ReplaceListingAmenities(ctx context.Context, listingID int, amenityIDs []int) error {
+	tx, err := pg.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		zap.S().Errorf("failed to begin transaction: %v", err)
+		return fmt.Errorf("failed to replace listing amenities")
+	}
+	defer tx.Rollback()
+
+	if len(amenityIDs) == 0 {
+		_, err := tx.ExecContext(ctx, `DELETE FROM listing_amenities WHERE listing_id = $1`, listingID)
+		if err != nil {
+			zap.S().Errorf("failed to clear amenities of listing %d: %v", listingID, err)
+			return fmt.Errorf("failed to replace listing amenities")
+		}
+	} else {
+		query, args, err := sqlx.In(`SELECT COUNT(*) FROM amenities WHERE id IN (?)`, amenityIDs)
+		if err != nil {
+			zap.S().Errorf("failed to build query: %v", err)
+			return fmt.Errorf("failed to replace listing amenities")
+		}
+
+		var found int
+		if err := tx.GetContext(ctx, &found, tx.Rebind(query), args...); err != nil {
+			zap.S().Errorf("failed to check amenities: %v", err)
+			return fmt.Errorf("failed to replace listing amenities")
+		}
+		if found != len(amenityIDs) {
+			return fmt.Errorf("amenity not found")
+		}
+
+		query, args, err = sqlx.In(`DELETE FROM listing_amenities WHERE listing_id = ? AND amenity_id NOT IN (?)`,
+			listingID, amenityIDs)
+		if err != nil {
+			zap.S().Errorf("failed to build query: %v", err)
+			return fmt.Errorf("failed to replace listing amenities")
+		}
+
+		if _, err := tx.ExecContext(ctx, tx.Rebind(query), args...); err != nil {
+			zap.S().Errorf("failed to remove amenities of listing %d: %v", listingID, err)
+			return fmt.Errorf("failed to replace listing amenities")
+		}
+
+		stmt, err := tx.PreparexContext(ctx, `INSERT INTO listing_amenities (listing_id, amenity_id) VALUES ($1, $2) 
+			ON CONFLICT DO NOTHING`)
+		if err != nil {
+			zap.S().Errorf("failed to prepare statement: %v", err)
+			return fmt.Errorf("failed to replace listing amenities")
+		}
+		defer stmt.Close()
+
+		for _, amenityID := range amenityIDs {
+			if _, err := stmt.ExecContext(ctx, listingID, amenityID); err != nil {
+				zap.S().Errorf("failed to add amenity %d to listing %d: %v", amenityID, listingID, err)
+				return fmt.Errorf("failed to replace listing amenities")
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		zap.S().Errorf("failed to commit transaction: %v", err)
+		return fmt.Errorf("failed to replace listing amenities")
+	}
+
+	return nil
+}
+
+func (pg *Postgres) GetAmenityCategories(ctx context.Context) ([]model.AmenityCategory, error) {
+	var categories []model.AmenityCategory
+	query := `SELECT key, name, labels, sort_order FROM amenity_categories ORDER BY sort_order, key`
+	err := pg.conn.SelectContext(ctx, &categories, query)
+	if err != nil {
+		zap.S().Errorf("failed to get amenity categories: %v", err)
+		return nil, fmt.Errorf("failed to get amenity categories")
+	}
+	return categories, nil
+}
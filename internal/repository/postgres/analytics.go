@@ -0,0 +1,211 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"go.uber.org/zap"
+)
+
+func (pg *Postgres) GetListingsAnalytics(ctx context.Context, filter model.AnalyticsFilter) ([]model.ListingSummary, error) {
+	where, args := listingsAnalyticsConditions(filter)
+	orderBy := analyticsOrderBy(filter, model.ListingsAnalyticsSortFields, "listing_id")
+
+	listings := make([]model.ListingSummary, 0, filter.Limit)
+	if err := pg.selectAnalyticsPage(ctx, &listings, listingsAnalyticsColumns, "listings_summary", where, orderBy, args, filter); err != nil {
+		zap.S().Errorf("failed to get listings analytics: %v", err)
+		return nil, fmt.Errorf("failed to get listings analytics")
+	}
+
+	return listings, nil
+}
+
+func (pg *Postgres) CountListingsAnalytics(ctx context.Context, filter model.AnalyticsFilter) (int, error) {
+	where, args := listingsAnalyticsConditions(filter)
+
+	total, err := pg.countAnalytics(ctx, "listings_summary", where, args)
+	if err != nil {
+		zap.S().Errorf("failed to count listings analytics: %v", err)
+		return 0, fmt.Errorf("failed to get listings analytics")
+	}
+
+	return total, nil
+}
+
+func (pg *Postgres) GetHostsAnalytics(ctx context.Context, filter model.AnalyticsFilter) ([]model.HostAnalytics, error) {
+	where, args := hostsAnalyticsConditions(filter)
+	orderBy := analyticsOrderBy(filter, model.HostsAnalyticsSortFields, "host_id")
+
+	hosts := make([]model.HostAnalytics, 0, filter.Limit)
+	if err := pg.selectAnalyticsPage(ctx, &hosts, hostsAnalyticsColumns, "hosts_analytics", where, orderBy, args, filter); err != nil {
+		zap.S().Errorf("failed to get hosts analytics: %v", err)
+		return nil, fmt.Errorf("failed to get hosts analytics")
+	}
+
+	return hosts, nil
+}
+
+func (pg *Postgres) CountHostsAnalytics(ctx context.Context, filter model.AnalyticsFilter) (int, error) {
+	where, args := hostsAnalyticsConditions(filter)
+
+	total, err := pg.countAnalytics(ctx, "hosts_analytics", where, args)
+	if err != nil {
+		zap.S().Errorf("failed to count hosts analytics: %v", err)
+		return 0, fmt.Errorf("failed to get hosts analytics")
+	}
+
+	return total, nil
+}
+
+// колонки перечислены явно, чтобы новые колонки представлений не ломали сканирование в модели
+const listingsAnalyticsColumns = `listing_id, address, host_id, host_name, price_per_night, rooms_number,
+	beds_number, is_available, average_rating, reviews_count, bookings_count, total_revenue,
+	avg_payment_amount, active_bookings_count, favorites_count, avg_cleanliness, avg_accuracy,
+	avg_location, avg_value`
+
+const hostsAnalyticsColumns = `host_id, host_name, host_email, total_listings, total_bookings, active_bookings,
+	average_rating, total_reviews, total_revenue, avg_booking_revenue, completed_payments_count,
+	pending_payments_count, failed_payments_count`
+
+// текст и оценка отзыва отдаются только для опубликованных и уже раскрытых отзывов,
+// независимо от того, как определено представление
+const bookingsAnalyticsColumns = `booking_id, listing_id, listing_address, price_per_night, host_id, host_name,
+	guest_id, guest_name, in_date, out_date, duration_days, total_price, is_paid,
+	payment_id, payment_amount, payment_method, payment_status, paid_at, review_id,
+	CASE WHEN ` + bookingsAnalyticsReviewVisible + ` THEN review_score END AS review_score,
+	CASE WHEN ` + bookingsAnalyticsReviewVisible + ` THEN review_text END AS review_text,
+	booking_status`
+
+const bookingsAnalyticsReviewVisible = `is_booking_reviews_revealed(bookings_payments_analytics.booking_id)
+	AND EXISTS (SELECT 1 FROM reviews r WHERE r.id = bookings_payments_analytics.review_id AND r.status = 'published')`
+
+func (pg *Postgres) GetBookingsAnalytics(ctx context.Context, filter model.AnalyticsFilter) ([]model.BookingPaymentAnalytics, error) {
+	where, args := bookingsAnalyticsConditions(filter)
+	// у бронирования может быть несколько платежей, поэтому payment_id входит в ключ сортировки
+	orderBy := analyticsOrderBy(filter, model.BookingsAnalyticsSortFields, "booking_id, payment_id")
+
+	bookings := make([]model.BookingPaymentAnalytics, 0, filter.Limit)
+	if err := pg.selectAnalyticsPage(ctx, &bookings, bookingsAnalyticsColumns, "bookings_payments_analytics", where, orderBy, args, filter); err != nil {
+		zap.S().Errorf("failed to get bookings analytics: %v", err)
+		return nil, fmt.Errorf("failed to get bookings analytics")
+	}
+
+	return bookings, nil
+}
+
+func (pg *Postgres) CountBookingsAnalytics(ctx context.Context, filter model.AnalyticsFilter) (int, error) {
+	where, args := bookingsAnalyticsConditions(filter)
+
+	total, err := pg.countAnalytics(ctx, "bookings_payments_analytics", where, args)
+	if err != nil {
+		zap.S().Errorf("failed to count bookings analytics: %v", err)
+		return 0, fmt.Errorf("failed to get bookings analytics")
+	}
+
+	return total, nil
+}
+
+func (pg *Postgres) selectAnalyticsPage(ctx context.Context, dest any, columns string, view string, where string,
+	orderBy string, args []any, filter model.AnalyticsFilter) error {
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE %s ORDER BY %s LIMIT $%d OFFSET $%d`,
+		columns, view, where, orderBy, len(args)-1, len(args))
+
+	return pg.conn.SelectContext(ctx, dest, query, args...)
+}
+
+func (pg *Postgres) countAnalytics(ctx context.Context, view string, where string, args []any) (int, error) {
+	var total int
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s`, view, where)
+	err := pg.conn.GetContext(ctx, &total, query, args...)
+	return total, err
+}
+
+// analyticsOrderBy подставляет в ORDER BY только поля из белого списка, остальное заменяется ключом
+func analyticsOrderBy(filter model.AnalyticsFilter, allowed []string, key string) string {
+	if !slices.Contains(allowed, filter.Sort) {
+		return key
+	}
+
+	direction := "ASC NULLS FIRST"
+	if filter.Order == model.SortOrderDesc {
+		direction = "DESC NULLS LAST"
+	}
+
+	return fmt.Sprintf("%s %s, %s", filter.Sort, direction, key)
+}
+
+func listingsAnalyticsConditions(filter model.AnalyticsFilter) (string, []any) {
+	args := []any{}
+	conditions := []string{"TRUE"}
+
+	if filter.HostID != nil {
+		args = append(args, *filter.HostID)
+		conditions = append(conditions, fmt.Sprintf(`host_id = $%d`, len(args)))
+	}
+
+	if filter.IsAvailable != nil {
+		args = append(args, *filter.IsAvailable)
+		conditions = append(conditions, fmt.Sprintf(`is_available = $%d`, len(args)))
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+func hostsAnalyticsConditions(filter model.AnalyticsFilter) (string, []any) {
+	args := []any{}
+	conditions := []string{"TRUE"}
+
+	if filter.HostID != nil {
+		args = append(args, *filter.HostID)
+		conditions = append(conditions, fmt.Sprintf(`host_id = $%d`, len(args)))
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+func bookingsAnalyticsConditions(filter model.AnalyticsFilter) (string, []any) {
+	args := []any{}
+	conditions := []string{"TRUE"}
+
+	if filter.HostID != nil {
+		args = append(args, *filter.HostID)
+		conditions = append(conditions, fmt.Sprintf(`host_id = $%d`, len(args)))
+	}
+
+	if filter.GuestID != nil {
+		args = append(args, *filter.GuestID)
+		conditions = append(conditions, fmt.Sprintf(`guest_id = $%d`, len(args)))
+	}
+
+	if filter.ListingID != nil {
+		args = append(args, *filter.ListingID)
+		conditions = append(conditions, fmt.Sprintf(`listing_id = $%d`, len(args)))
+	}
+
+	if filter.BookingStatus != nil {
+		args = append(args, *filter.BookingStatus)
+		conditions = append(conditions, fmt.Sprintf(`booking_status = $%d`, len(args)))
+	}
+
+	if filter.PaymentStatus != nil {
+		args = append(args, *filter.PaymentStatus)
+		conditions = append(conditions, fmt.Sprintf(`payment_status = $%d`, len(args)))
+	}
+
+	// как в get_bookings_report: бронирование целиком внутри периода
+	if filter.StartDate != nil {
+		args = append(args, *filter.StartDate)
+		conditions = append(conditions, fmt.Sprintf(`in_date >= $%d`, len(args)))
+	}
+
+	if filter.EndDate != nil {
+		args = append(args, *filter.EndDate)
+		conditions = append(conditions, fmt.Sprintf(`out_date <= $%d`, len(args)))
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
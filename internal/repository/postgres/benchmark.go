@@ -0,0 +1,21 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"go.uber.org/zap"
+)
+
+func (pg *Postgres) GetListingComparables(ctx context.Context, listingID int, startDate, endDate time.Time, radiusKm *float64) ([]model.ListingComparable, error) {
+	var comparables []model.ListingComparable
+	query := `SELECT * FROM get_listing_comparables($1, $2, $3, $4)`
+	err := pg.conn.SelectContext(ctx, &comparables, query, listingID, startDate, endDate, radiusKm)
+	if err != nil {
+		zap.S().Errorf("failed to get comparables for listing %d: %v", listingID, err)
+		return nil, fmt.Errorf("failed to get listing benchmark")
+	}
+	return comparables, nil
+}
@@ -0,0 +1,140 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"go.uber.org/zap"
+)
+
+// conversationColumns ожидает id зрителя в параметре $1 для подсчета непрочитанных
+const conversationColumns = `c.id, c.listing_id, c.guest_id, c.host_id, c.booking_id, c.last_message_at, c.created_at,
+	lm.text AS last_message_text, lm.sender_id AS last_message_sender_id,
+	(SELECT COUNT(*) FROM messages m
+		WHERE m.conversation_id = c.id AND m.sender_id <> $1 AND m.read_at IS NULL) AS unread_count`
+
+const conversationLastMessageJoin = `LEFT JOIN LATERAL (
+		SELECT m.text, m.sender_id FROM messages m
+		WHERE m.conversation_id = c.id
+		ORDER BY m.created_at DESC, m.id DESC
+		LIMIT 1
+	) lm ON TRUE`
+
+// GetOrCreateConversation возвращает существующую переписку по объявлению, гостю и бронированию или создает новую;
+// непрочитанные сообщения считаются для гостя
+func (pg *Postgres) GetOrCreateConversation(ctx context.Context, conversation *model.Conversation) error {
+	query := `INSERT INTO conversations (listing_id, guest_id, host_id, booking_id) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (listing_id, guest_id, COALESCE(booking_id, 0)) DO NOTHING`
+
+	_, err := pg.conn.ExecContext(ctx, query, conversation.ListingID, conversation.GuestID, conversation.HostID,
+		conversation.BookingID)
+	if err != nil {
+		zap.S().Errorf("failed to create conversation: %v", err)
+		return fmt.Errorf("failed to create conversation")
+	}
+
+	query = `SELECT ` + conversationColumns + ` FROM conversations c ` + conversationLastMessageJoin + `
+		WHERE c.listing_id = $2 AND c.guest_id = $1 AND COALESCE(c.booking_id, 0) = COALESCE($3::INTEGER, 0)`
+
+	err = pg.conn.GetContext(ctx, conversation, query, conversation.GuestID, conversation.ListingID,
+		conversation.BookingID)
+	if err != nil {
+		zap.S().Errorf("failed to get conversation: %v", err)
+		return fmt.Errorf("failed to create conversation")
+	}
+
+	return nil
+}
+
+func (pg *Postgres) GetConversationByID(ctx context.Context, id int, viewerID int) (*model.Conversation, error) {
+	var conversation model.Conversation
+	query := `SELECT ` + conversationColumns + ` FROM conversations c ` + conversationLastMessageJoin + `
+		WHERE c.id = $2`
+
+	err := pg.conn.GetContext(ctx, &conversation, query, viewerID, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			zap.S().Errorf("conversation with id %d not found", id)
+			return nil, fmt.Errorf("conversation not found")
+		}
+		zap.S().Errorf("failed to get conversation: %v", err)
+		return nil, fmt.Errorf("failed to get conversation")
+	}
+
+	return &conversation, nil
+}
+
+func (pg *Postgres) GetConversationsByUserID(ctx context.Context, userID int, limit, offset int) ([]model.Conversation, error) {
+	query := `SELECT ` + conversationColumns + ` FROM conversations c ` + conversationLastMessageJoin + `
+		WHERE c.guest_id = $1 OR c.host_id = $1
+		ORDER BY COALESCE(c.last_message_at, c.created_at) DESC, c.id DESC
+		LIMIT $2 OFFSET $3`
+
+	conversations := make([]model.Conversation, 0, limit)
+	err := pg.conn.SelectContext(ctx, &conversations, query, userID, limit, offset)
+	if err != nil {
+		zap.S().Errorf("failed to get conversations for user %d: %v", userID, err)
+		return nil, fmt.Errorf("failed to get conversations")
+	}
+
+	return conversations, nil
+}
+
+func (pg *Postgres) CreateMessage(ctx context.Context, message *model.Message) error {
+	query := `WITH m AS (
+			INSERT INTO messages (conversation_id, sender_id, text) VALUES ($1, $2, $3)
+			RETURNING id, created_at
+		)
+		UPDATE conversations c SET last_message_at = m.created_at
+		FROM m
+		WHERE c.id = $1
+		RETURNING m.id, m.created_at`
+
+	err := pg.conn.QueryRowxContext(ctx, query, message.ConversationID, message.SenderID, message.Text).
+		Scan(&message.ID, &message.CreatedAt)
+	if err != nil {
+		zap.S().Errorf("failed to create message: %v", err)
+		return fmt.Errorf("failed to create message")
+	}
+
+	return nil
+}
+
+func (pg *Postgres) GetMessagesByConversationID(ctx context.Context, conversationID int, limit, offset int) ([]model.Message, error) {
+	query := `SELECT id, conversation_id, sender_id, text, read_at, created_at
+		FROM messages
+		WHERE conversation_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3`
+
+	messages := make([]model.Message, 0, limit)
+	err := pg.conn.SelectContext(ctx, &messages, query, conversationID, limit, offset)
+	if err != nil {
+		zap.S().Errorf("failed to get messages for conversation %d: %v", conversationID, err)
+		return nil, fmt.Errorf("failed to get messages")
+	}
+
+	return messages, nil
+}
+
+// MarkConversationRead отмечает прочитанными все сообщения собеседника и возвращает их количество
+func (pg *Postgres) MarkConversationRead(ctx context.Context, conversationID int, readerID int) (int, error) {
+	query := `UPDATE messages SET read_at = CURRENT_TIMESTAMP
+		WHERE conversation_id = $1 AND sender_id <> $2 AND read_at IS NULL`
+
+	result, err := pg.conn.ExecContext(ctx, query, conversationID, readerID)
+	if err != nil {
+		zap.S().Errorf("failed to mark conversation %d read: %v", conversationID, err)
+		return 0, fmt.Errorf("failed to update messages")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		zap.S().Errorf("failed to get rows affected: %v", err)
+		return 0, fmt.Errorf("failed to update messages")
+	}
+
+	return int(rowsAffected), nil
+}
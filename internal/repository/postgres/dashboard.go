@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"go.uber.org/zap"
+)
+
+// GetHostDashboardSummary считает выручку, рейтинг и ожидающие платежи хоста одним запросом
+func (pg *Postgres) GetHostDashboardSummary(ctx context.Context, hostID int, periodStart time.Time) (*model.HostDashboard, error) {
+	query := `SELECT
+			get_host_total_revenue($1) AS total_revenue,
+			COALESCE(SUM(p.amount) FILTER (WHERE p.payment_status = 'completed' AND p.paid_at >= $2), 0.00) AS period_revenue,
+			get_host_average_rating($1) AS average_rating,
+			COUNT(p.payment_id) FILTER (WHERE p.payment_status = 'pending') AS pending_payments_count,
+			COALESCE(SUM(p.amount) FILTER (WHERE p.payment_status = 'pending'), 0.00) AS pending_payments_amount
+		FROM bookings b
+		JOIN payments p ON p.booking_id = b.booking_id
+		WHERE b.host_id = $1`
+
+	var dashboard model.HostDashboard
+	err := pg.conn.GetContext(ctx, &dashboard, query, hostID, periodStart)
+	if err != nil {
+		zap.S().Errorf("failed to get dashboard summary for host %d: %v", hostID, err)
+		return nil, fmt.Errorf("failed to get host dashboard")
+	}
+
+	return &dashboard, nil
+}
+
+// GetHostDashboardListings возвращает показатели всех объявлений хоста;
+// занятые ночи считаются только внутри периода, как в отчете по загрузке
+func (pg *Postgres) GetHostDashboardListings(ctx context.Context, hostID int, periodStart, periodEnd time.Time) ([]model.HostDashboardListing, error) {
+	query := `WITH host_bookings AS (
+			SELECT b.booking_id, b.listing_id, b.in_date, b.out_date
+			FROM bookings b
+			WHERE b.host_id = $1
+		),
+		revenue AS (
+			SELECT hb.listing_id,
+				SUM(p.amount) AS total,
+				COALESCE(SUM(p.amount) FILTER (WHERE p.paid_at >= $2), 0.00) AS period
+			FROM host_bookings hb
+			JOIN payments p ON p.booking_id = hb.booking_id
+			WHERE p.payment_status = 'completed'
+			GROUP BY hb.listing_id
+		),
+		nights AS (
+			SELECT hb.listing_id,
+				SUM(GREATEST(LEAST(hb.out_date, $3)::DATE - GREATEST(hb.in_date, $2)::DATE, 0)) AS booked,
+				COUNT(*) FILTER (WHERE hb.out_date > CURRENT_TIMESTAMP) AS active
+			FROM host_bookings hb
+			GROUP BY hb.listing_id
+		)
+		SELECT l.id AS listing_id, l.title, l.address, l.price_per_night, l.is_available,
+			l.average_rating, l.reviews_count,
+			COALESCE(n.active, 0) AS active_bookings,
+			COALESCE(r.total, 0.00) AS total_revenue,
+			COALESCE(r.period, 0.00) AS period_revenue,
+			$3::DATE - $2::DATE AS available_nights,
+			LEAST(COALESCE(n.booked, 0), $3::DATE - $2::DATE) AS booked_nights
+		FROM listings l
+		LEFT JOIN revenue r ON r.listing_id = l.id
+		LEFT JOIN nights n ON n.listing_id = l.id
+		WHERE l.host_id = $1
+		ORDER BY l.id`
+
+	listings := []model.HostDashboardListing{}
+	err := pg.conn.SelectContext(ctx, &listings, query, hostID, periodStart, periodEnd)
+	if err != nil {
+		zap.S().Errorf("failed to get dashboard listings for host %d: %v", hostID, err)
+		return nil, fmt.Errorf("failed to get host dashboard")
+	}
+
+	return listings, nil
+}
+
+// GetHostUpcomingMovements возвращает брони хоста, у которых заезд или выезд попадает в окно
+func (pg *Postgres) GetHostUpcomingMovements(ctx context.Context, hostID int, from, to time.Time) ([]model.HostDashboardMovement, error) {
+	query := `SELECT b.booking_id, b.listing_id, l.address AS listing_address, b.guest_id,
+			(g.first_name || ' ' || g.second_name) AS guest_name, b.in_date, b.out_date
+		FROM bookings b
+		JOIN listings l ON l.id = b.listing_id
+		JOIN users g ON g.id = b.guest_id
+		WHERE b.host_id = $1
+		  AND ((b.in_date >= $2 AND b.in_date < $3) OR (b.out_date >= $2 AND b.out_date < $3))
+		ORDER BY b.in_date, b.booking_id`
+
+	var movements []model.HostDashboardMovement
+	err := pg.conn.SelectContext(ctx, &movements, query, hostID, from, to)
+	if err != nil {
+		zap.S().Errorf("failed to get upcoming movements for host %d: %v", hostID, err)
+		return nil, fmt.Errorf("failed to get host dashboard")
+	}
+
+	return movements, nil
+}
@@ -10,9 +10,11 @@ import (
 )
 
 func (pg *Postgres) CreateFavorite(ctx context.Context, favorite *model.Favorite) error {
-	query := `INSERT INTO favorites (user_id, listing_id) VALUES ($1, $2) RETURNING id`
+	query := `INSERT INTO favorites (user_id, listing_id, wishlist_id, note) VALUES ($1, $2, $3, $4) 
+		RETURNING id, wishlist_id, created_at`
 
-	err := pg.conn.QueryRowxContext(ctx, query, favorite.UserID, favorite.ListingID).Scan(&favorite.ID)
+	err := pg.conn.QueryRowxContext(ctx, query, favorite.UserID, favorite.ListingID, nullableID(favorite.WishlistID),
+		favorite.Note).Scan(&favorite.ID, &favorite.WishlistID, &favorite.CreatedAt)
 	if err != nil {
 		zap.S().Errorf("failed to create favorite: %v", err)
 		return fmt.Errorf("failed to create favorite")
@@ -23,7 +25,7 @@ func (pg *Postgres) CreateFavorite(ctx context.Context, favorite *model.Favorite
 
 func (pg *Postgres) GetFavoriteByID(ctx context.Context, id int) (*model.Favorite, error) {
 	var favorite model.Favorite
-	query := `SELECT id, user_id, listing_id FROM favorites WHERE id = $1`
+	query := `SELECT id, user_id, listing_id, wishlist_id, note, created_at FROM favorites WHERE id = $1`
 	err := pg.conn.GetContext(ctx, &favorite, query, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -38,7 +40,7 @@ func (pg *Postgres) GetFavoriteByID(ctx context.Context, id int) (*model.Favorit
 
 func (pg *Postgres) GetFavoritesByUserID(ctx context.Context, userID int) ([]model.Favorite, error) {
 	var favorites []model.Favorite
-	query := `SELECT id, user_id, listing_id FROM favorites WHERE user_id = $1 ORDER BY id`
+	query := `SELECT id, user_id, listing_id, wishlist_id, note, created_at FROM favorites WHERE user_id = $1 ORDER BY id`
 	err := pg.conn.SelectContext(ctx, &favorites, query, userID)
 	if err != nil {
 		zap.S().Errorf("failed to get favorites for user %d: %v", userID, err)
@@ -47,10 +49,10 @@ func (pg *Postgres) GetFavoritesByUserID(ctx context.Context, userID int) ([]mod
 	return favorites, nil
 }
 
-func (pg *Postgres) GetFavoriteByUserAndListing(ctx context.Context, userID int, listingID int) (*model.Favorite, error) {
+func (pg *Postgres) GetFavoriteByWishlistAndListing(ctx context.Context, wishlistID int, listingID int) (*model.Favorite, error) {
 	var favorite model.Favorite
-	query := `SELECT id, user_id, listing_id FROM favorites WHERE user_id = $1 AND listing_id = $2`
-	err := pg.conn.GetContext(ctx, &favorite, query, userID, listingID)
+	query := `SELECT id, user_id, listing_id, wishlist_id, note, created_at FROM favorites WHERE wishlist_id = $1 AND listing_id = $2`
+	err := pg.conn.GetContext(ctx, &favorite, query, wishlistID, listingID)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, nil
@@ -112,7 +114,7 @@ func (pg *Postgres) CreateFavorites(ctx context.Context, favorites []model.Favor
 		return nil
 	}
 
-	query := `INSERT INTO favorites (user_id, listing_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	query := `INSERT INTO favorites (user_id, listing_id, wishlist_id, note) VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING`
 
 	zap.S().Infof("start adding %v favorites", len(favorites))
 	tx, err := pg.conn.BeginTxx(ctx, nil)
@@ -130,7 +132,8 @@ func (pg *Postgres) CreateFavorites(ctx context.Context, favorites []model.Favor
 	defer stmt.Close()
 
 	for i := range favorites {
-		_, err := stmt.ExecContext(ctx, favorites[i].UserID, favorites[i].ListingID)
+		_, err := stmt.ExecContext(ctx, favorites[i].UserID, favorites[i].ListingID, nullableID(favorites[i].WishlistID),
+			favorites[i].Note)
 		if err != nil {
 			zap.S().Errorf("failed to insert favorite at index %d: %v", i, err)
 			return fmt.Errorf("failed to create favorites")
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/Rissochek/db-cw/internal/model"
+	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
 )
 
@@ -77,6 +78,38 @@ func (pg *Postgres) GetListingsStatisticsReport(ctx context.Context) ([]model.Li
 	return reports, nil
 }
 
+// GetReportRefreshedAt возвращает момент, на который актуально материализованное представление отчета
+func (pg *Postgres) GetReportRefreshedAt(ctx context.Context, viewName string) (time.Time, error) {
+	var refreshedAt time.Time
+	query := `SELECT refreshed_at FROM report_refreshes WHERE view_name = $1`
+	err := pg.conn.GetContext(ctx, &refreshedAt, query, viewName)
+	if err != nil {
+		zap.S().Errorf("failed to get refresh time of %s: %v", viewName, err)
+		return time.Time{}, fmt.Errorf("failed to get report refresh time")
+	}
+	return refreshedAt, nil
+}
+
+func (pg *Postgres) RefreshReportViews(ctx context.Context) error {
+	_, err := pg.conn.ExecContext(ctx, `SELECT refresh_report_views()`)
+	if err != nil {
+		zap.S().Errorf("failed to refresh report views: %v", err)
+		return fmt.Errorf("failed to refresh report views")
+	}
+	return nil
+}
+
+func (pg *Postgres) GetCitiesStatisticsReport(ctx context.Context, country *string) ([]model.CityStatisticsReport, error) {
+	var reports []model.CityStatisticsReport
+	query := `SELECT * FROM get_cities_statistics_report($1)`
+	err := pg.conn.SelectContext(ctx, &reports, query, country)
+	if err != nil {
+		zap.S().Errorf("failed to get cities statistics report: %v", err)
+		return nil, fmt.Errorf("failed to get cities statistics report")
+	}
+	return reports, nil
+}
+
 func (pg *Postgres) GetHostsPerformanceReport(ctx context.Context) ([]model.HostPerformanceReport, error) {
 	var reports []model.HostPerformanceReport
 	query := `SELECT * FROM get_hosts_performance_report()`
@@ -99,6 +132,84 @@ func (pg *Postgres) GetBookingsReport(ctx context.Context, startDate, endDate *t
 	return reports, nil
 }
 
+func (pg *Postgres) GetListingsOccupancyReport(ctx context.Context, startDate, endDate time.Time) ([]model.ListingOccupancyReport, error) {
+	var reports []model.ListingOccupancyReport
+	query := `SELECT * FROM get_listings_occupancy_report($1, $2)`
+	err := pg.conn.SelectContext(ctx, &reports, query, startDate, endDate)
+	if err != nil {
+		zap.S().Errorf("failed to get listings occupancy report: %v", err)
+		return nil, fmt.Errorf("failed to get listings occupancy report")
+	}
+	return reports, nil
+}
+
+func (pg *Postgres) GetHostsOccupancyReport(ctx context.Context, startDate, endDate time.Time) ([]model.HostOccupancyReport, error) {
+	var reports []model.HostOccupancyReport
+	query := `SELECT * FROM get_hosts_occupancy_report($1, $2)`
+	err := pg.conn.SelectContext(ctx, &reports, query, startDate, endDate)
+	if err != nil {
+		zap.S().Errorf("failed to get hosts occupancy report: %v", err)
+		return nil, fmt.Errorf("failed to get hosts occupancy report")
+	}
+	return reports, nil
+}
+
+func (pg *Postgres) GetTimeseriesReport(ctx context.Context, filter model.TimeseriesFilter) ([]model.TimeseriesPoint, error) {
+	var points []model.TimeseriesPoint
+	query := `SELECT * FROM get_timeseries_report($1, $2, $3, $4, $5, $6)`
+	err := pg.conn.SelectContext(ctx, &points, query, filter.Metric, filter.Granularity, filter.From, filter.To,
+		filter.HostID, filter.ListingID)
+	if err != nil {
+		zap.S().Errorf("failed to get timeseries report: %v", err)
+		return nil, fmt.Errorf("failed to get timeseries report")
+	}
+	return points, nil
+}
+
+func (pg *Postgres) GetGuestCohortReport(ctx context.Context, startDate, endDate *time.Time) ([]model.GuestCohortCell, error) {
+	var cells []model.GuestCohortCell
+	query := `SELECT * FROM get_guest_cohort_report($1, $2)`
+	err := pg.conn.SelectContext(ctx, &cells, query, startDate, endDate)
+	if err != nil {
+		zap.S().Errorf("failed to get guest cohort report: %v", err)
+		return nil, fmt.Errorf("failed to get guest cohort report")
+	}
+	return cells, nil
+}
+
+func (pg *Postgres) GetListingsCancellationReport(ctx context.Context, startDate, endDate *time.Time) ([]model.ListingCancellationReport, error) {
+	var reports []model.ListingCancellationReport
+	query := `SELECT * FROM get_listings_cancellation_report($1, $2)`
+	err := pg.conn.SelectContext(ctx, &reports, query, startDate, endDate)
+	if err != nil {
+		zap.S().Errorf("failed to get listings cancellation report: %v", err)
+		return nil, fmt.Errorf("failed to get listings cancellation report")
+	}
+	return reports, nil
+}
+
+func (pg *Postgres) GetHostsCancellationReport(ctx context.Context, startDate, endDate *time.Time) ([]model.HostCancellationReport, error) {
+	var reports []model.HostCancellationReport
+	query := `SELECT * FROM get_hosts_cancellation_report($1, $2)`
+	err := pg.conn.SelectContext(ctx, &reports, query, startDate, endDate)
+	if err != nil {
+		zap.S().Errorf("failed to get hosts cancellation report: %v", err)
+		return nil, fmt.Errorf("failed to get hosts cancellation report")
+	}
+	return reports, nil
+}
+
+func (pg *Postgres) GetPaymentFailuresReport(ctx context.Context, startDate, endDate *time.Time) ([]model.PaymentFailureReport, error) {
+	var reports []model.PaymentFailureReport
+	query := `SELECT * FROM get_payment_failures_report($1, $2)`
+	err := pg.conn.SelectContext(ctx, &reports, query, startDate, endDate)
+	if err != nil {
+		zap.S().Errorf("failed to get payment failures report: %v", err)
+		return nil, fmt.Errorf("failed to get payment failures report")
+	}
+	return reports, nil
+}
+
 func (pg *Postgres) GetPaymentsSummaryReport(ctx context.Context, startDate, endDate *time.Time) ([]model.PaymentSummaryReport, error) {
 	var reports []model.PaymentSummaryReport
 	query := `SELECT * FROM get_payments_summary_report($1, $2)`
@@ -109,3 +220,97 @@ func (pg *Postgres) GetPaymentsSummaryReport(ctx context.Context, startDate, end
 	}
 	return reports, nil
 }
+
+func (pg *Postgres) StreamListingsStatisticsReport(ctx context.Context, fn func(model.ListingStatisticsReport) error) error {
+	query := `SELECT * FROM get_listings_statistics_report()`
+	if err := streamRows(ctx, pg.conn, query, nil, fn); err != nil {
+		zap.S().Errorf("failed to stream listings statistics report: %v", err)
+		return fmt.Errorf("failed to get listings statistics report")
+	}
+	return nil
+}
+
+func (pg *Postgres) StreamHostsPerformanceReport(ctx context.Context, fn func(model.HostPerformanceReport) error) error {
+	query := `SELECT * FROM get_hosts_performance_report()`
+	if err := streamRows(ctx, pg.conn, query, nil, fn); err != nil {
+		zap.S().Errorf("failed to stream hosts performance report: %v", err)
+		return fmt.Errorf("failed to get hosts performance report")
+	}
+	return nil
+}
+
+func (pg *Postgres) StreamBookingsReport(ctx context.Context, startDate, endDate *time.Time, fn func(model.BookingReport) error) error {
+	query := `SELECT * FROM get_bookings_report($1, $2)`
+	if err := streamRows(ctx, pg.conn, query, []any{startDate, endDate}, fn); err != nil {
+		zap.S().Errorf("failed to stream bookings report: %v", err)
+		return fmt.Errorf("failed to get bookings report")
+	}
+	return nil
+}
+
+func (pg *Postgres) StreamListingsOccupancyReport(ctx context.Context, startDate, endDate time.Time, fn func(model.ListingOccupancyReport) error) error {
+	query := `SELECT * FROM get_listings_occupancy_report($1, $2)`
+	if err := streamRows(ctx, pg.conn, query, []any{startDate, endDate}, fn); err != nil {
+		zap.S().Errorf("failed to stream listings occupancy report: %v", err)
+		return fmt.Errorf("failed to get listings occupancy report")
+	}
+	return nil
+}
+
+func (pg *Postgres) StreamCitiesStatisticsReport(ctx context.Context, country *string, fn func(model.CityStatisticsReport) error) error {
+	query := `SELECT * FROM get_cities_statistics_report($1)`
+	if err := streamRows(ctx, pg.conn, query, []any{country}, fn); err != nil {
+		zap.S().Errorf("failed to stream cities statistics report: %v", err)
+		return fmt.Errorf("failed to get cities statistics report")
+	}
+	return nil
+}
+
+func (pg *Postgres) StreamPaymentsSummaryReport(ctx context.Context, startDate, endDate *time.Time, fn func(model.PaymentSummaryReport) error) error {
+	query := `SELECT * FROM get_payments_summary_report($1, $2)`
+	if err := streamRows(ctx, pg.conn, query, []any{startDate, endDate}, fn); err != nil {
+		zap.S().Errorf("failed to stream payments summary report: %v", err)
+		return fmt.Errorf("failed to get payments summary report")
+	}
+	return nil
+}
+
+func (pg *Postgres) StreamHostsOccupancyReport(ctx context.Context, startDate, endDate time.Time, fn func(model.HostOccupancyReport) error) error {
+	query := `SELECT * FROM get_hosts_occupancy_report($1, $2)`
+	if err := streamRows(ctx, pg.conn, query, []any{startDate, endDate}, fn); err != nil {
+		zap.S().Errorf("failed to stream hosts occupancy report: %v", err)
+		return fmt.Errorf("failed to get hosts occupancy report")
+	}
+	return nil
+}
+
+func (pg *Postgres) StreamTimeseriesReport(ctx context.Context, filter model.TimeseriesFilter, fn func(model.TimeseriesPoint) error) error {
+	query := `SELECT * FROM get_timeseries_report($1, $2, $3, $4, $5, $6)`
+	args := []any{filter.Metric, filter.Granularity, filter.From, filter.To, filter.HostID, filter.ListingID}
+	if err := streamRows(ctx, pg.conn, query, args, fn); err != nil {
+		zap.S().Errorf("failed to stream timeseries report: %v", err)
+		return fmt.Errorf("failed to get timeseries report")
+	}
+	return nil
+}
+
+// streamRows отдает строки результата по одной, не загружая весь отчет в память
+func streamRows[T any](ctx context.Context, conn *sqlx.DB, query string, args []any, fn func(T) error) error {
+	rows, err := conn.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row T
+		if err := rows.StructScan(&row); err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
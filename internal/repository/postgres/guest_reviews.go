@@ -0,0 +1,164 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+func (pg *Postgres) CreateGuestReview(ctx context.Context, review *model.GuestReview) error {
+	query := `INSERT INTO guest_reviews (booking_id, host_id, guest_id, text, score) VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at`
+
+	err := pg.conn.QueryRowxContext(ctx, query, review.BookingID, review.HostID, review.GuestID,
+		review.Text, review.Score).Scan(&review.ID, &review.CreatedAt)
+	if err != nil {
+		zap.S().Errorf("failed to create guest review: %v", err)
+		return fmt.Errorf("failed to create guest review")
+	}
+
+	return nil
+}
+
+func (pg *Postgres) GetGuestReviewByID(ctx context.Context, id int) (*model.GuestReview, error) {
+	var review model.GuestReview
+
+	query := `SELECT id, booking_id, host_id, guest_id, text, score, created_at FROM guest_reviews WHERE id = $1`
+
+	err := pg.conn.GetContext(ctx, &review, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			zap.S().Errorf("guest review with id %d not found", id)
+			return nil, fmt.Errorf("guest review not found")
+		}
+		zap.S().Errorf("failed to get guest review: %v", err)
+		return nil, fmt.Errorf("failed to get guest review")
+	}
+
+	return &review, nil
+}
+
+func (pg *Postgres) GetGuestReviewByBookingID(ctx context.Context, bookingID int) (*model.GuestReview, error) {
+	var review model.GuestReview
+
+	query := `SELECT id, booking_id, host_id, guest_id, text, score, created_at FROM guest_reviews WHERE booking_id = $1`
+
+	err := pg.conn.GetContext(ctx, &review, query, bookingID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		zap.S().Errorf("failed to get guest review: %v", err)
+		return nil, fmt.Errorf("failed to get guest review")
+	}
+
+	return &review, nil
+}
+
+func (pg *Postgres) DeleteGuestReview(ctx context.Context, id int, revealAfterDays int) error {
+	tx, err := pg.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		zap.S().Errorf("failed to begin transaction: %v", err)
+		return fmt.Errorf("failed to delete guest review")
+	}
+	defer tx.Rollback()
+
+	var bookingID int
+	err = tx.GetContext(ctx, &bookingID, `SELECT booking_id FROM guest_reviews WHERE id = $1`, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			zap.S().Errorf("guest review with id %d not found", id)
+			return fmt.Errorf("guest review not found")
+		}
+		zap.S().Errorf("failed to get guest review: %v", err)
+		return fmt.Errorf("failed to delete guest review")
+	}
+
+	if err := checkBookingReviewsNotRevealed(ctx, tx, bookingID, revealAfterDays); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM guest_reviews WHERE id = $1`, id); err != nil {
+		zap.S().Errorf("failed to delete guest review: %v", err)
+		return fmt.Errorf("failed to delete guest review")
+	}
+
+	if err := tx.Commit(); err != nil {
+		zap.S().Errorf("failed to commit transaction: %v", err)
+		return fmt.Errorf("failed to delete guest review")
+	}
+
+	return nil
+}
+
+// checkBookingReviewsNotRevealed блокирует бронирование до конца транзакции и запрещает менять отзывы
+// после раскрытия: иначе сторона, уже прочитавшая встречный отзыв, могла бы переписать свой
+func checkBookingReviewsNotRevealed(ctx context.Context, tx *sqlx.Tx, bookingID int, revealAfterDays int) error {
+	_, err := tx.ExecContext(ctx, `SELECT 1 FROM bookings WHERE booking_id = $1 FOR NO KEY UPDATE`, bookingID)
+	if err != nil {
+		zap.S().Errorf("failed to lock booking %d: %v", bookingID, err)
+		return fmt.Errorf("failed to check reviews visibility")
+	}
+
+	// проверка отдельным запросом, чтобы видеть отзывы, закоммиченные пока ждали блокировку
+	var revealed bool
+	err = tx.GetContext(ctx, &revealed, `SELECT is_booking_reviews_revealed($1, $2)`, bookingID, revealAfterDays)
+	if err != nil {
+		zap.S().Errorf("failed to check reviews visibility for booking_id %d: %v", bookingID, err)
+		return fmt.Errorf("failed to check reviews visibility")
+	}
+
+	if revealed {
+		return fmt.Errorf("review window is closed: reviews are already revealed and can no longer be changed")
+	}
+
+	return nil
+}
+
+func (pg *Postgres) IsBookingReviewsRevealed(ctx context.Context, bookingID int, revealAfterDays int) (bool, error) {
+	var revealed bool
+	query := `SELECT is_booking_reviews_revealed($1, $2)`
+	err := pg.conn.GetContext(ctx, &revealed, query, bookingID, revealAfterDays)
+	if err != nil {
+		zap.S().Errorf("failed to check reviews visibility for booking_id %d: %v", bookingID, err)
+		return false, fmt.Errorf("failed to check reviews visibility")
+	}
+	return revealed, nil
+}
+
+// GetReviewRevealAfterDays возвращает срок на отзыв после выезда, по истечении которого отзывы раскрываются
+func (pg *Postgres) GetReviewRevealAfterDays(ctx context.Context) (int, error) {
+	var days int
+	err := pg.conn.GetContext(ctx, &days, `SELECT review_reveal_after_days()`)
+	if err != nil {
+		zap.S().Errorf("failed to get review reveal period: %v", err)
+		return 0, fmt.Errorf("failed to get review settings")
+	}
+	return days, nil
+}
+
+func (pg *Postgres) GetGuestReputation(ctx context.Context, guestID int, revealAfterDays int) (*model.GuestReputation, error) {
+	var reputation model.GuestReputation
+	query := `SELECT * FROM get_guest_reputation($1, $2)`
+	err := pg.conn.GetContext(ctx, &reputation, query, guestID, revealAfterDays)
+	if err != nil {
+		zap.S().Errorf("failed to get guest reputation for guest_id %d: %v", guestID, err)
+		return nil, fmt.Errorf("failed to get guest reputation")
+	}
+	return &reputation, nil
+}
+
+// RefreshRevealedReviewsStats пересчитывает рейтинги объявлений, отзывы которых раскрылись после since
+func (pg *Postgres) RefreshRevealedReviewsStats(ctx context.Context, since *time.Time) (int, error) {
+	var updated int
+	err := pg.conn.GetContext(ctx, &updated, `SELECT refresh_revealed_reviews_stats($1)`, since)
+	if err != nil {
+		zap.S().Errorf("failed to refresh revealed reviews stats: %v", err)
+		return 0, fmt.Errorf("failed to refresh revealed reviews stats")
+	}
+	return updated, nil
+}
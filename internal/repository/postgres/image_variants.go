@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"go.uber.org/zap"
+)
+
+// ClaimPendingImage забирает одно ожидающее изображение; SKIP LOCKED позволяет запускать несколько воркеров
+func (pg *Postgres) ClaimPendingImage(ctx context.Context) (*model.Image, error) {
+	var image model.Image
+	query := `UPDATE images SET processing_status = 'processing', processing_started_at = CURRENT_TIMESTAMP
+		WHERE image_id = (
+			SELECT image_id FROM images
+			WHERE processing_status = 'pending'
+			ORDER BY image_id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING image_id, listing_id, image_url, is_primary, order_index, uploaded_at, storage_key, content_type,
+		size_bytes, processing_status, processing_error, processed_at`
+	err := pg.conn.GetContext(ctx, &image, query)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		zap.S().Errorf("failed to claim pending image: %v", err)
+		return nil, fmt.Errorf("failed to claim pending image")
+	}
+	return &image, nil
+}
+
+// ResetStaleImageProcessing возвращает в очередь изображения, которые дольше lease числятся в обработке:
+// их воркер упал или был остановлен, а живые воркеры за это время успевают закончить
+func (pg *Postgres) ResetStaleImageProcessing(ctx context.Context, lease time.Duration) error {
+	query := `UPDATE images SET processing_status = 'pending', processing_started_at = NULL
+		WHERE processing_status = 'processing'
+		  AND (processing_started_at IS NULL OR processing_started_at < CURRENT_TIMESTAMP - make_interval(secs => $1))`
+
+	if _, err := pg.conn.ExecContext(ctx, query, lease.Seconds()); err != nil {
+		zap.S().Errorf("failed to reset image processing: %v", err)
+		return fmt.Errorf("failed to reset image processing")
+	}
+
+	return nil
+}
+
+func (pg *Postgres) UpdateImageProcessingStatus(ctx context.Context, imageID int, status string, processingError *string) error {
+	query := `UPDATE images
+		SET processing_status = $1, processing_error = $2, processed_at = CURRENT_TIMESTAMP
+		WHERE image_id = $3`
+
+	result, err := pg.conn.ExecContext(ctx, query, status, processingError, imageID)
+	if err != nil {
+		zap.S().Errorf("failed to update image processing status: %v", err)
+		return fmt.Errorf("failed to update image processing status")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		zap.S().Errorf("failed to get rows affected: %v", err)
+		return fmt.Errorf("failed to update image processing status")
+	}
+
+	if rowsAffected == 0 {
+		zap.S().Errorf("image with id %d not found", imageID)
+		return fmt.Errorf("image not found")
+	}
+
+	return nil
+}
+
+func (pg *Postgres) SaveImageVariants(ctx context.Context, variants []model.ImageVariant) error {
+	if len(variants) == 0 {
+		return nil
+	}
+
+	query := `INSERT INTO image_variants (image_id, variant, storage_key, image_url, content_type, width, height, size_bytes)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (image_id, variant) DO UPDATE
+		SET storage_key = EXCLUDED.storage_key, image_url = EXCLUDED.image_url, content_type = EXCLUDED.content_type,
+			width = EXCLUDED.width, height = EXCLUDED.height, size_bytes = EXCLUDED.size_bytes,
+			created_at = CURRENT_TIMESTAMP`
+
+	tx, err := pg.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		zap.S().Errorf("failed to begin transaction: %v", err)
+		return fmt.Errorf("failed to save image variants")
+	}
+	defer tx.Rollback()
+
+	for i := range variants {
+		_, err := tx.ExecContext(ctx, query, variants[i].ImageID, variants[i].Variant, variants[i].StorageKey,
+			variants[i].ImageURL, variants[i].ContentType, variants[i].Width, variants[i].Height, variants[i].SizeBytes)
+		if err != nil {
+			zap.S().Errorf("failed to save %s variant for image %d: %v", variants[i].Variant, variants[i].ImageID, err)
+			return fmt.Errorf("failed to save image variants")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		zap.S().Errorf("failed to commit transaction: %v", err)
+		return fmt.Errorf("failed to save image variants")
+	}
+
+	return nil
+}
+
+func (pg *Postgres) GetImageVariantsByImageID(ctx context.Context, imageID int) ([]model.ImageVariant, error) {
+	var variants []model.ImageVariant
+	query := `SELECT id, image_id, variant, storage_key, image_url, content_type, width, height, size_bytes, created_at
+		FROM image_variants WHERE image_id = $1 ORDER BY width`
+	err := pg.conn.SelectContext(ctx, &variants, query, imageID)
+	if err != nil {
+		zap.S().Errorf("failed to get variants for image %d: %v", imageID, err)
+		return nil, fmt.Errorf("failed to get image variants")
+	}
+	return variants, nil
+}
+
+func (pg *Postgres) GetImageVariantsByListingID(ctx context.Context, listingID int) ([]model.ImageVariant, error) {
+	var variants []model.ImageVariant
+	query := `SELECT v.id, v.image_id, v.variant, v.storage_key, v.image_url, v.content_type, v.width, v.height,
+		v.size_bytes, v.created_at
+		FROM image_variants v
+		JOIN images i ON i.image_id = v.image_id
+		WHERE i.listing_id = $1
+		ORDER BY v.image_id, v.width`
+	err := pg.conn.SelectContext(ctx, &variants, query, listingID)
+	if err != nil {
+		zap.S().Errorf("failed to get image variants for listing %d: %v", listingID, err)
+		return nil, fmt.Errorf("failed to get image variants")
+	}
+	return variants, nil
+}
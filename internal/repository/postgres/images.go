@@ -6,26 +6,54 @@ import (
 	"fmt"
 
 	"github.com/Rissochek/db-cw/internal/model"
+	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
 )
 
 func (pg *Postgres) CreateImage(ctx context.Context, image *model.Image) error {
-	query := `INSERT INTO images (listing_id, image_url, is_primary, order_index, uploaded_at) 
-		VALUES ($1, $2, $3, $4, $5) RETURNING image_id`
+	// новое изображение становится основным, если у объявления основного еще нет
+	query := `INSERT INTO images (listing_id, image_url, is_primary, order_index, uploaded_at, storage_key, content_type, size_bytes, 
+		processing_status) 
+		VALUES ($1, $2, $3 OR NOT EXISTS (SELECT 1 FROM images WHERE listing_id = $1 AND is_primary), $4, $5, $6, $7, $8, $9) 
+		RETURNING image_id, is_primary`
 
-	err := pg.conn.QueryRowxContext(ctx, query, image.ListingID, image.ImageURL, image.IsPrimary,
-		image.OrderIndex, image.UploadedAt).Scan(&image.ImageID)
+	tx, err := pg.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		zap.S().Errorf("failed to begin transaction: %v", err)
+		return fmt.Errorf("failed to create image")
+	}
+	defer tx.Rollback()
+
+	if err := lockListingGallery(ctx, tx, image.ListingID); err != nil {
+		return fmt.Errorf("failed to create image")
+	}
+
+	if image.IsPrimary {
+		if err := demotePrimaryImage(ctx, tx, image.ListingID, 0); err != nil {
+			return fmt.Errorf("failed to create image")
+		}
+	}
+
+	err = tx.QueryRowxContext(ctx, query, image.ListingID, image.ImageURL, image.IsPrimary,
+		image.OrderIndex, image.UploadedAt, image.StorageKey, image.ContentType, image.SizeBytes,
+		image.ProcessingStatus).Scan(&image.ImageID, &image.IsPrimary)
 	if err != nil {
 		zap.S().Errorf("failed to create image: %v", err)
 		return fmt.Errorf("failed to create image")
 	}
 
+	if err := tx.Commit(); err != nil {
+		zap.S().Errorf("failed to commit transaction: %v", err)
+		return fmt.Errorf("failed to create image")
+	}
+
 	return nil
 }
 
 func (pg *Postgres) GetImageByID(ctx context.Context, imageID int) (*model.Image, error) {
 	var image model.Image
-	query := `SELECT image_id, listing_id, image_url, is_primary, order_index, uploaded_at 
+	query := `SELECT image_id, listing_id, image_url, is_primary, order_index, uploaded_at, storage_key, content_type, size_bytes, 
+		processing_status, processing_error, processed_at 
 		FROM images WHERE image_id = $1`
 	err := pg.conn.GetContext(ctx, &image, query, imageID)
 	if err != nil {
@@ -41,7 +69,8 @@ func (pg *Postgres) GetImageByID(ctx context.Context, imageID int) (*model.Image
 
 func (pg *Postgres) GetImagesByListingID(ctx context.Context, listingID int) ([]model.Image, error) {
 	var images []model.Image
-	query := `SELECT image_id, listing_id, image_url, is_primary, order_index, uploaded_at 
+	query := `SELECT image_id, listing_id, image_url, is_primary, order_index, uploaded_at, storage_key, content_type, size_bytes, 
+		processing_status, processing_error, processed_at 
 		FROM images WHERE listing_id = $1 ORDER BY order_index, image_id`
 	err := pg.conn.SelectContext(ctx, &images, query, listingID)
 	if err != nil {
@@ -56,7 +85,24 @@ func (pg *Postgres) UpdateImage(ctx context.Context, image *model.Image) error {
 		SET listing_id = $1, image_url = $2, is_primary = $3, order_index = $4, uploaded_at = $5 
 		WHERE image_id = $6`
 
-	result, err := pg.conn.ExecContext(ctx, query, image.ListingID, image.ImageURL, image.IsPrimary,
+	tx, err := pg.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		zap.S().Errorf("failed to begin transaction: %v", err)
+		return fmt.Errorf("failed to update image")
+	}
+	defer tx.Rollback()
+
+	if err := lockListingGallery(ctx, tx, image.ListingID); err != nil {
+		return fmt.Errorf("failed to update image")
+	}
+
+	if image.IsPrimary {
+		if err := demotePrimaryImage(ctx, tx, image.ListingID, image.ImageID); err != nil {
+			return fmt.Errorf("failed to update image")
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, query, image.ListingID, image.ImageURL, image.IsPrimary,
 		image.OrderIndex, image.UploadedAt, image.ImageID)
 	if err != nil {
 		zap.S().Errorf("failed to update image: %v", err)
@@ -74,27 +120,194 @@ func (pg *Postgres) UpdateImage(ctx context.Context, image *model.Image) error {
 		return fmt.Errorf("image not found")
 	}
 
+	promotedID, err := promoteFirstImage(ctx, tx, image.ListingID)
+	if err != nil {
+		return fmt.Errorf("failed to update image")
+	}
+	if promotedID == image.ImageID {
+		image.IsPrimary = true
+	}
+
+	if err := tx.Commit(); err != nil {
+		zap.S().Errorf("failed to commit transaction: %v", err)
+		return fmt.Errorf("failed to update image")
+	}
+
 	return nil
 }
 
 func (pg *Postgres) DeleteImage(ctx context.Context, imageID int) error {
-	query := `DELETE FROM images WHERE image_id = $1`
+	query := `DELETE FROM images WHERE image_id = $1 RETURNING listing_id`
 
-	result, err := pg.conn.ExecContext(ctx, query, imageID)
+	tx, err := pg.conn.BeginTxx(ctx, nil)
 	if err != nil {
-		zap.S().Errorf("failed to delete image: %v", err)
+		zap.S().Errorf("failed to begin transaction: %v", err)
 		return fmt.Errorf("failed to delete image")
 	}
+	defer tx.Rollback()
 
-	rowsAffected, err := result.RowsAffected()
+	var listingID int
+	err = tx.QueryRowxContext(ctx, `SELECT listing_id FROM images WHERE image_id = $1`, imageID).Scan(&listingID)
 	if err != nil {
-		zap.S().Errorf("failed to get rows affected: %v", err)
+		if err == sql.ErrNoRows {
+			zap.S().Errorf("image with id %d not found", imageID)
+			return fmt.Errorf("image not found")
+		}
+		zap.S().Errorf("failed to get image listing: %v", err)
 		return fmt.Errorf("failed to delete image")
 	}
 
-	if rowsAffected == 0 {
-		zap.S().Errorf("image with id %d not found", imageID)
-		return fmt.Errorf("image not found")
+	if err := lockListingGallery(ctx, tx, listingID); err != nil {
+		return fmt.Errorf("failed to delete image")
+	}
+
+	if err := queueImageBlobs(ctx, tx, imageID); err != nil {
+		return fmt.Errorf("failed to delete image")
+	}
+
+	err = tx.QueryRowxContext(ctx, query, imageID).Scan(&listingID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			zap.S().Errorf("image with id %d not found", imageID)
+			return fmt.Errorf("image not found")
+		}
+		zap.S().Errorf("failed to delete image: %v", err)
+		return fmt.Errorf("failed to delete image")
+	}
+
+	if _, err := promoteFirstImage(ctx, tx, listingID); err != nil {
+		return fmt.Errorf("failed to delete image")
+	}
+
+	if err := compactImageOrder(ctx, tx, listingID); err != nil {
+		return fmt.Errorf("failed to delete image")
+	}
+
+	if err := tx.Commit(); err != nil {
+		zap.S().Errorf("failed to commit transaction: %v", err)
+		return fmt.Errorf("failed to delete image")
+	}
+
+	return nil
+}
+
+func (pg *Postgres) ReorderImages(ctx context.Context, listingID int, imageIDs []int) error {
+	tx, err := pg.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		zap.S().Errorf("failed to begin transaction: %v", err)
+		return fmt.Errorf("failed to reorder images")
+	}
+	defer tx.Rollback()
+
+	// блокируем галерею, чтобы состав не поменялся между проверкой и обновлением
+	var currentIDs []int
+	err = tx.SelectContext(ctx, &currentIDs, `SELECT image_id FROM images WHERE listing_id = $1 FOR UPDATE`, listingID)
+	if err != nil {
+		zap.S().Errorf("failed to lock images for listing %d: %v", listingID, err)
+		return fmt.Errorf("failed to reorder images")
+	}
+
+	if len(currentIDs) != len(imageIDs) {
+		return fmt.Errorf("invalid image order: expected %d image ids, got %d", len(currentIDs), len(imageIDs))
+	}
+
+	current := make(map[int]bool, len(currentIDs))
+	for _, id := range currentIDs {
+		current[id] = true
+	}
+	for _, id := range imageIDs {
+		if !current[id] {
+			return fmt.Errorf("invalid image order: image %d is missing or duplicated", id)
+		}
+		delete(current, id)
+	}
+
+	stmt, err := tx.PreparexContext(ctx, `UPDATE images SET order_index = $1 WHERE image_id = $2`)
+	if err != nil {
+		zap.S().Errorf("failed to prepare statement: %v", err)
+		return fmt.Errorf("failed to reorder images")
+	}
+	defer stmt.Close()
+
+	for i, id := range imageIDs {
+		if _, err := stmt.ExecContext(ctx, i, id); err != nil {
+			zap.S().Errorf("failed to set order of image %d: %v", id, err)
+			return fmt.Errorf("failed to reorder images")
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		zap.S().Errorf("failed to commit transaction: %v", err)
+		return fmt.Errorf("failed to reorder images")
+	}
+
+	return nil
+}
+
+// lockListingGallery сериализует изменения основного изображения объявления: без блокировки
+// два параллельных запроса снимают старое основное и оба пытаются занять uq_images_listing_primary.
+// NO KEY UPDATE не мешает вставкам, которые ссылаются на объявление по внешнему ключу
+func lockListingGallery(ctx context.Context, tx *sqlx.Tx, listingID int) error {
+	query := `SELECT id FROM listings WHERE id = $1 FOR NO KEY UPDATE`
+
+	if _, err := tx.ExecContext(ctx, query, listingID); err != nil {
+		zap.S().Errorf("failed to lock gallery of listing %d: %v", listingID, err)
+		return err
+	}
+
+	return nil
+}
+
+func demotePrimaryImage(ctx context.Context, tx *sqlx.Tx, listingID int, exceptImageID int) error {
+	query := `UPDATE images SET is_primary = FALSE WHERE listing_id = $1 AND is_primary AND image_id <> $2`
+
+	if _, err := tx.ExecContext(ctx, query, listingID, exceptImageID); err != nil {
+		zap.S().Errorf("failed to demote primary image of listing %d: %v", listingID, err)
+		return err
+	}
+
+	return nil
+}
+
+// promoteFirstImage делает основным первое по порядку изображение, если основного не осталось
+func promoteFirstImage(ctx context.Context, tx *sqlx.Tx, listingID int) (int, error) {
+	query := `UPDATE images SET is_primary = TRUE
+		WHERE image_id = (
+			SELECT image_id FROM images
+			WHERE listing_id = $1
+			ORDER BY order_index, image_id
+			LIMIT 1
+		)
+		AND NOT EXISTS (SELECT 1 FROM images WHERE listing_id = $1 AND is_primary)
+		RETURNING image_id`
+
+	var imageID int
+	err := tx.QueryRowxContext(ctx, query, listingID).Scan(&imageID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		zap.S().Errorf("failed to promote image of listing %d: %v", listingID, err)
+		return 0, err
+	}
+
+	return imageID, nil
+}
+
+func compactImageOrder(ctx context.Context, tx *sqlx.Tx, listingID int) error {
+	query := `UPDATE images i
+		SET order_index = r.position
+		FROM (
+			SELECT image_id, ROW_NUMBER() OVER (ORDER BY order_index, image_id) - 1 AS position
+			FROM images
+			WHERE listing_id = $1
+		) r
+		WHERE i.image_id = r.image_id
+		AND i.order_index IS DISTINCT FROM r.position`
+
+	if _, err := tx.ExecContext(ctx, query, listingID); err != nil {
+		zap.S().Errorf("failed to compact image order of listing %d: %v", listingID, err)
+		return err
 	}
 
 	return nil
@@ -0,0 +1,182 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"go.uber.org/zap"
+)
+
+func (pg *Postgres) SearchListings(ctx context.Context, filter model.ListingsSearchFilter) ([]model.Listing, error) {
+	where, args := listingsSearchConditions(filter)
+
+	// при поиске от точки выдача сортируется по расстоянию, при текстовом запросе - по релевантности
+	distance, rank, snippet := `NULL::DOUBLE PRECISION`, `NULL::REAL`, `NULL::TEXT`
+	orderBy := []string{}
+	if filter.Latitude != nil && filter.Longitude != nil {
+		args = append(args, *filter.Latitude, *filter.Longitude)
+		distance = fmt.Sprintf(`earth_distance(ll_to_earth($%d, $%d), ll_to_earth(l.latitude, l.longitude)) / 1000`,
+			len(args)-1, len(args))
+		orderBy = append(orderBy, `distance_km NULLS LAST`)
+	}
+	if filter.Query != nil {
+		args = append(args, *filter.Query)
+		tsQuery := fmt.Sprintf(`websearch_to_tsquery('russian', $%d)`, len(args))
+		rank = fmt.Sprintf(`ts_rank_cd(l.search_vector, %s)`, tsQuery)
+		snippet = fmt.Sprintf(`ts_headline('russian', concat_ws(' — ', NULLIF(l.title, ''), NULLIF(l.description, ''), l.address,
+			get_listing_reviews_text(l.id)),
+			%s, 'StartSel=<mark>, StopSel=</mark>, MaxWords=30, MinWords=10, MaxFragments=2')`, tsQuery)
+		orderBy = append(orderBy, `search_rank DESC`)
+	}
+	orderBy = append(orderBy, `l.id`)
+
+	args = append(args, filter.Limit, filter.Offset)
+	query := fmt.Sprintf(`SELECT l.id, l.host_id, l.title, l.description, l.address, l.street, l.city, l.region,
+			l.postal_code, l.country, l.price_per_night, l.is_available, l.rooms_number, l.beds_number,
+			l.latitude, l.longitude, %s AS distance_km, %s AS search_rank, %s AS snippet
+		FROM listings l
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d OFFSET $%d`, distance, rank, snippet, where, strings.Join(orderBy, ", "), len(args)-1, len(args))
+
+	listings := make([]model.Listing, 0, filter.Limit)
+	err := pg.conn.SelectContext(ctx, &listings, query, args...)
+	if err != nil {
+		zap.S().Errorf("failed to search listings: %v", err)
+		return nil, fmt.Errorf("failed to search listings")
+	}
+
+	return listings, nil
+}
+
+func (pg *Postgres) CountListings(ctx context.Context, filter model.ListingsSearchFilter) (int, error) {
+	where, args := listingsSearchConditions(filter)
+
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM listings l WHERE %s`, where)
+
+	var total int
+	err := pg.conn.GetContext(ctx, &total, query, args...)
+	if err != nil {
+		zap.S().Errorf("failed to count listings: %v", err)
+		return 0, fmt.Errorf("failed to search listings")
+	}
+
+	return total, nil
+}
+
+// GetAmenityFacets считает, у скольких подходящих под фильтр объявлений есть каждое удобство
+func (pg *Postgres) GetAmenityFacets(ctx context.Context, filter model.ListingsSearchFilter) ([]model.AmenityFacet, error) {
+	where, args := listingsSearchConditions(filter)
+
+	query := fmt.Sprintf(`SELECT a.id AS amenity_id, a.name, a.labels, a.category, a.icon_key,
+			COUNT(l.id) AS listings_count
+		FROM amenities a
+		LEFT JOIN amenity_categories c ON c.key = a.category
+		LEFT JOIN listing_amenities la ON la.amenity_id = a.id
+		LEFT JOIN listings l ON l.id = la.listing_id AND %s
+		GROUP BY a.id, c.sort_order
+		ORDER BY c.sort_order NULLS LAST, a.id`, where)
+
+	var facets []model.AmenityFacet
+	err := pg.conn.SelectContext(ctx, &facets, query, args...)
+	if err != nil {
+		zap.S().Errorf("failed to get amenity facets: %v", err)
+		return nil, fmt.Errorf("failed to search listings")
+	}
+
+	return facets, nil
+}
+
+func listingsSearchConditions(filter model.ListingsSearchFilter) (string, []any) {
+	args := []any{}
+	conditions := []string{"TRUE"}
+
+	if filter.Query != nil {
+		args = append(args, *filter.Query)
+		conditions = append(conditions, fmt.Sprintf(`l.search_vector @@ websearch_to_tsquery('russian', $%d)`, len(args)))
+	}
+
+	if filter.MinPrice != nil {
+		args = append(args, *filter.MinPrice)
+		conditions = append(conditions, fmt.Sprintf(`l.price_per_night >= $%d`, len(args)))
+	}
+
+	if filter.MaxPrice != nil {
+		args = append(args, *filter.MaxPrice)
+		conditions = append(conditions, fmt.Sprintf(`l.price_per_night <= $%d`, len(args)))
+	}
+
+	if filter.MinRooms != nil {
+		args = append(args, *filter.MinRooms)
+		conditions = append(conditions, fmt.Sprintf(`l.rooms_number >= $%d`, len(args)))
+	}
+
+	if filter.MinBeds != nil {
+		args = append(args, *filter.MinBeds)
+		conditions = append(conditions, fmt.Sprintf(`l.beds_number >= $%d`, len(args)))
+	}
+
+	if filter.AvailableOnly {
+		conditions = append(conditions, `l.is_available`)
+	}
+
+	if filter.City != nil {
+		args = append(args, *filter.City)
+		conditions = append(conditions, fmt.Sprintf(`lower(l.city) = lower($%d)`, len(args)))
+	}
+
+	if filter.Region != nil {
+		args = append(args, *filter.Region)
+		conditions = append(conditions, fmt.Sprintf(`lower(l.region) = lower($%d)`, len(args)))
+	}
+
+	if filter.Country != nil {
+		args = append(args, *filter.Country)
+		conditions = append(conditions, fmt.Sprintf(`lower(l.country) = lower($%d)`, len(args)))
+	}
+
+	// earth_box отбирает кандидатов по индексу, earth_distance отсекает углы куба
+	if filter.Latitude != nil && filter.Longitude != nil && filter.RadiusKm != nil {
+		args = append(args, *filter.Latitude, *filter.Longitude, *filter.RadiusKm*1000)
+		conditions = append(conditions, fmt.Sprintf(`earth_box(ll_to_earth($%[1]d, $%[2]d), $%[3]d) @> ll_to_earth(l.latitude, l.longitude)
+			AND earth_distance(ll_to_earth($%[1]d, $%[2]d), ll_to_earth(l.latitude, l.longitude)) <= $%[3]d`,
+			len(args)-2, len(args)-1, len(args)))
+	}
+
+	// область через 180-й меридиан делится на две: от min_lng до 180 и от -180 до max_lng
+	if bounds := filter.Bounds; bounds != nil {
+		ranges := [][2]float64{{bounds.MinLongitude, bounds.MaxLongitude}}
+		if bounds.CrossesAntimeridian() {
+			ranges = [][2]float64{{bounds.MinLongitude, 180}, {-180, bounds.MaxLongitude}}
+		}
+
+		boxes := make([]string, len(ranges))
+		for i, lngRange := range ranges {
+			args = append(args, lngRange[0], bounds.MinLatitude, lngRange[1], bounds.MaxLatitude)
+			boxes[i] = fmt.Sprintf(`point(l.longitude, l.latitude) <@ box(point($%d, $%d), point($%d, $%d))`,
+				len(args)-3, len(args)-2, len(args)-1, len(args))
+		}
+		conditions = append(conditions, "("+strings.Join(boxes, " OR ")+")")
+	}
+
+	// объявление должно иметь все выбранные удобства; повторы в фильтре убираем, иначе HAVING не сойдется
+	if len(filter.AmenityIDs) > 0 {
+		amenityIDs := slices.Compact(slices.Sorted(slices.Values(filter.AmenityIDs)))
+		placeholders := make([]string, len(amenityIDs))
+		for i, amenityID := range amenityIDs {
+			args = append(args, amenityID)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+		args = append(args, len(amenityIDs))
+		conditions = append(conditions, fmt.Sprintf(`l.id IN (
+			SELECT listing_id FROM listing_amenities
+			WHERE amenity_id IN (%s)
+			GROUP BY listing_id
+			HAVING COUNT(*) = $%d)`, strings.Join(placeholders, ", "), len(args)))
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
@@ -11,11 +11,13 @@ import (
 )
 
 func (pg *Postgres) CreateListing(ctx context.Context, listing *model.Listing) error {
-	query := `INSERT INTO listings (host_id, address, price_per_night, is_available, rooms_number, beds_number) 
-		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+	query := `INSERT INTO listings (host_id, title, description, address, street, city, region, postal_code, country,
+			price_per_night, is_available, rooms_number, beds_number, latitude, longitude)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15) RETURNING id`
 
-	err := pg.conn.QueryRowxContext(ctx, query, listing.HostID, listing.Address, listing.PricePerNight,
-		listing.IsAvailable, listing.RoomsNumber, listing.BedsNumber).Scan(&listing.ID)
+	err := pg.conn.QueryRowxContext(ctx, query, listing.HostID, listing.Title, listing.Description, listing.Address,
+		listing.Street, listing.City, listing.Region, listing.PostalCode, listing.Country, listing.PricePerNight,
+		listing.IsAvailable, listing.RoomsNumber, listing.BedsNumber, listing.Latitude, listing.Longitude).Scan(&listing.ID)
 	if err != nil {
 		zap.S().Errorf("failed to create listing: %v", err)
 		return fmt.Errorf("failed to create listing")
@@ -25,8 +27,9 @@ func (pg *Postgres) CreateListing(ctx context.Context, listing *model.Listing) e
 }
 
 func (pg *Postgres) CreateListings(ctx context.Context, listings []model.Listing) error {
-	query := `INSERT INTO listings (host_id, address, price_per_night, is_available, rooms_number, beds_number) 
-		VALUES ($1, $2, $3, $4, $5, $6)`
+	query := `INSERT INTO listings (host_id, title, description, address, street, city, region, postal_code, country,
+			price_per_night, is_available, rooms_number, beds_number, latitude, longitude)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
 
 	zap.S().Infof("start adding %v listings", len(listings))
 	tx, err := pg.conn.BeginTxx(ctx, nil)
@@ -44,8 +47,9 @@ func (pg *Postgres) CreateListings(ctx context.Context, listings []model.Listing
 	defer stmt.Close()
 
 	for i := range listings {
-		_, err := stmt.ExecContext(ctx, listings[i].HostID, listings[i].Address, listings[i].PricePerNight,
-			listings[i].IsAvailable, listings[i].RoomsNumber, listings[i].BedsNumber)
+		_, err := stmt.ExecContext(ctx, listings[i].HostID, listings[i].Title, listings[i].Description,
+			listings[i].Address, listings[i].Street, listings[i].City, listings[i].Region, listings[i].PostalCode,
+			listings[i].Country, listings[i].PricePerNight, listings[i].IsAvailable, listings[i].RoomsNumber, listings[i].BedsNumber, listings[i].Latitude, listings[i].Longitude)
 		if err != nil {
 			zap.S().Errorf("failed to insert listing at index %d: %v", i, err)
 			return fmt.Errorf("failed to create listings")
@@ -64,7 +68,8 @@ func (pg *Postgres) CreateListings(ctx context.Context, listings []model.Listing
 func (pg *Postgres) GetListingByID(ctx context.Context, id int) (*model.Listing, error) {
 	var listing model.Listing
 
-	query := `SELECT id, host_id, address, price_per_night, is_available, rooms_number, beds_number 
+	query := `SELECT id, host_id, title, description, address, street, city, region, postal_code, country,
+			price_per_night, is_available, rooms_number, beds_number, latitude, longitude
 		FROM listings WHERE id = $1`
 
 	err := pg.conn.GetContext(ctx, &listing, query, id)
@@ -81,7 +86,8 @@ func (pg *Postgres) GetListingByID(ctx context.Context, id int) (*model.Listing,
 }
 
 func (pg *Postgres) GetListingsByID(ctx context.Context, ids []int) ([]model.Listing, error) {
-	query, args, err := sqlx.In(`SELECT id, host_id, address, price_per_night, is_available, rooms_number, beds_number 
+	query, args, err := sqlx.In(`SELECT id, host_id, title, description, address, street, city, region, postal_code, country,
+			price_per_night, is_available, rooms_number, beds_number, latitude, longitude
 		FROM listings WHERE id IN (?)`, ids)
 	if err != nil {
 		zap.S().Errorf("failed to build query: %v", err)
@@ -102,11 +108,14 @@ func (pg *Postgres) GetListingsByID(ctx context.Context, ids []int) ([]model.Lis
 
 func (pg *Postgres) UpdateListing(ctx context.Context, listing *model.Listing) error {
 	query := `UPDATE listings
-		SET host_id = $1, address = $2, price_per_night = $3, is_available = $4, rooms_number = $5, beds_number = $6
-		WHERE id = $7`
-
-	result, err := pg.conn.ExecContext(ctx, query, listing.HostID, listing.Address, listing.PricePerNight,
-		listing.IsAvailable, listing.RoomsNumber, listing.BedsNumber, listing.ID)
+		SET host_id = $1, title = $2, description = $3, address = $4, street = $5, city = $6, region = $7,
+			postal_code = $8, country = $9, price_per_night = $10, is_available = $11, rooms_number = $12,
+			beds_number = $13, latitude = $14, longitude = $15
+		WHERE id = $16`
+
+	result, err := pg.conn.ExecContext(ctx, query, listing.HostID, listing.Title, listing.Description, listing.Address,
+		listing.Street, listing.City, listing.Region, listing.PostalCode, listing.Country, listing.PricePerNight,
+		listing.IsAvailable, listing.RoomsNumber, listing.BedsNumber, listing.Latitude, listing.Longitude, listing.ID)
 	if err != nil {
 		zap.S().Errorf("failed to update listing: %v", err)
 		return fmt.Errorf("failed to update listing")
@@ -160,6 +169,34 @@ func (pg *Postgres) UpdateListings(ctx context.Context, listings []model.Listing
 	return nil
 }
 
+// GetListingsWithoutCoordinates отдает объявления без координат постранично по id
+func (pg *Postgres) GetListingsWithoutCoordinates(ctx context.Context, afterID int, limit int) ([]model.Listing, error) {
+	query := `SELECT id, address FROM listings
+		WHERE latitude IS NULL AND id > $1
+		ORDER BY id
+		LIMIT $2`
+
+	var listings []model.Listing
+	if err := pg.conn.SelectContext(ctx, &listings, query, afterID, limit); err != nil {
+		zap.S().Errorf("failed to get listings without coordinates: %v", err)
+		return nil, fmt.Errorf("failed to get listings")
+	}
+
+	return listings, nil
+}
+
+// UpdateListingCoordinates не перезаписывает координаты, если их успели задать вручную
+func (pg *Postgres) UpdateListingCoordinates(ctx context.Context, id int, latitude, longitude float64) error {
+	query := `UPDATE listings SET latitude = $1, longitude = $2 WHERE id = $3 AND latitude IS NULL`
+
+	if _, err := pg.conn.ExecContext(ctx, query, latitude, longitude, id); err != nil {
+		zap.S().Errorf("failed to update coordinates of listing %d: %v", id, err)
+		return fmt.Errorf("failed to update listing")
+	}
+
+	return nil
+}
+
 func (pg *Postgres) DeleteListing(ctx context.Context, id int) error {
 	query := `DELETE FROM listings WHERE id = $1`
 
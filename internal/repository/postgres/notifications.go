@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"go.uber.org/zap"
+)
+
+// CreateListingNotifications создает уведомление каждому, у кого объявление в избранном
+func (pg *Postgres) CreateListingNotifications(ctx context.Context, notification model.Notification) ([]model.Notification, error) {
+	query := `INSERT INTO notifications (user_id, listing_id, type, message, old_price, new_price)
+		SELECT DISTINCT f.user_id, $1::INTEGER, $2, $3, $4::DECIMAL, $5::DECIMAL
+		FROM favorites f
+		WHERE f.listing_id = $1
+		RETURNING id, user_id, listing_id, type, message, old_price, new_price, is_read, delivered_at, created_at`
+
+	var notifications []model.Notification
+	err := pg.conn.SelectContext(ctx, &notifications, query, notification.ListingID, notification.Type,
+		notification.Message, notification.OldPrice, notification.NewPrice)
+	if err != nil {
+		zap.S().Errorf("failed to create notifications for listing %d: %v", notification.ListingID, err)
+		return nil, fmt.Errorf("failed to create notifications")
+	}
+
+	return notifications, nil
+}
+
+func (pg *Postgres) GetNotificationsByUserID(ctx context.Context, userID int, unreadOnly bool, limit, offset int) ([]model.Notification, error) {
+	query := `SELECT id, user_id, listing_id, type, message, old_price, new_price, is_read, delivered_at, created_at
+		FROM notifications
+		WHERE user_id = $1 AND (NOT $2 OR NOT is_read)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $3 OFFSET $4`
+
+	notifications := make([]model.Notification, 0, limit)
+	err := pg.conn.SelectContext(ctx, &notifications, query, userID, unreadOnly, limit, offset)
+	if err != nil {
+		zap.S().Errorf("failed to get notifications for user %d: %v", userID, err)
+		return nil, fmt.Errorf("failed to get notifications")
+	}
+
+	return notifications, nil
+}
+
+func (pg *Postgres) MarkNotificationRead(ctx context.Context, id int) error {
+	query := `UPDATE notifications SET is_read = TRUE WHERE id = $1`
+
+	result, err := pg.conn.ExecContext(ctx, query, id)
+	if err != nil {
+		zap.S().Errorf("failed to mark notification read: %v", err)
+		return fmt.Errorf("failed to update notification")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		zap.S().Errorf("failed to get rows affected: %v", err)
+		return fmt.Errorf("failed to update notification")
+	}
+
+	if rowsAffected == 0 {
+		zap.S().Errorf("notification with id %d not found", id)
+		return fmt.Errorf("notification not found")
+	}
+
+	return nil
+}
+
+func (pg *Postgres) MarkNotificationDelivered(ctx context.Context, id int) error {
+	query := `UPDATE notifications SET delivered_at = CURRENT_TIMESTAMP WHERE id = $1`
+
+	if _, err := pg.conn.ExecContext(ctx, query, id); err != nil {
+		zap.S().Errorf("failed to mark notification %d delivered: %v", id, err)
+		return fmt.Errorf("failed to update notification")
+	}
+
+	return nil
+}
+
+// MarkNotificationDeliveryFailed откладывает следующую попытку доставки на retryAfter
+func (pg *Postgres) MarkNotificationDeliveryFailed(ctx context.Context, id int, deliveryError string, retryAfter time.Duration) error {
+	query := `UPDATE notifications
+		SET delivery_attempts = delivery_attempts + 1, delivery_error = $2,
+			next_delivery_at = CURRENT_TIMESTAMP + make_interval(secs => $3)
+		WHERE id = $1`
+
+	if _, err := pg.conn.ExecContext(ctx, query, id, deliveryError, retryAfter.Seconds()); err != nil {
+		zap.S().Errorf("failed to mark notification %d delivery failed: %v", id, err)
+		return fmt.Errorf("failed to update notification")
+	}
+
+	return nil
+}
+
+// ClaimUndeliveredNotifications забирает уведомления, которым пора повторить доставку, и сдвигает их
+// next_delivery_at на lease, чтобы другой воркер не отправил их одновременно
+func (pg *Postgres) ClaimUndeliveredNotifications(ctx context.Context, maxAttempts int, lease time.Duration, limit int) ([]model.Notification, error) {
+	query := `UPDATE notifications SET next_delivery_at = CURRENT_TIMESTAMP + make_interval(secs => $2)
+		WHERE id IN (
+			SELECT id FROM notifications
+			WHERE delivered_at IS NULL
+			  AND delivery_attempts < $1
+			  AND next_delivery_at <= CURRENT_TIMESTAMP
+			ORDER BY next_delivery_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, user_id, listing_id, type, message, old_price, new_price, is_read, delivered_at, created_at,
+			delivery_attempts`
+
+	var notifications []model.Notification
+	err := pg.conn.SelectContext(ctx, &notifications, query, maxAttempts, lease.Seconds(), limit)
+	if err != nil {
+		zap.S().Errorf("failed to claim undelivered notifications: %v", err)
+		return nil, fmt.Errorf("failed to get undelivered notifications")
+	}
+
+	return notifications, nil
+}
+
+func (pg *Postgres) CreateNotification(ctx context.Context, notification *model.Notification) error {
+	query := `INSERT INTO notifications (user_id, listing_id, type, message, old_price, new_price)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, is_read, created_at`
+
+	err := pg.conn.QueryRowxContext(ctx, query, notification.UserID, notification.ListingID, notification.Type,
+		notification.Message, notification.OldPrice, notification.NewPrice).
+		Scan(&notification.ID, &notification.IsRead, &notification.CreatedAt)
+	if err != nil {
+		zap.S().Errorf("failed to create notification for user %d: %v", notification.UserID, err)
+		return fmt.Errorf("failed to create notification")
+	}
+
+	return nil
+}
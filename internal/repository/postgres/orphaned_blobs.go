@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+// queueImageBlobs запоминает файлы изображения и его вариантов до удаления строк,
+// чтобы их можно было дочистить, если хранилище недоступно
+func queueImageBlobs(ctx context.Context, tx *sqlx.Tx, imageID int) error {
+	query := `INSERT INTO orphaned_blobs (storage_key)
+		SELECT storage_key FROM image_variants WHERE image_id = $1
+		UNION
+		SELECT storage_key FROM images WHERE image_id = $1 AND storage_key IS NOT NULL
+		ON CONFLICT (storage_key) DO NOTHING`
+
+	if _, err := tx.ExecContext(ctx, query, imageID); err != nil {
+		zap.S().Errorf("failed to queue blobs of image %d: %v", imageID, err)
+		return err
+	}
+	return nil
+}
+
+// GetOrphanedBlobs возвращает ключи, которые не пытались удалять дольше retryAfter
+func (pg *Postgres) GetOrphanedBlobs(ctx context.Context, retryAfter time.Duration, limit int) ([]string, error) {
+	keys := make([]string, 0, limit)
+	query := `SELECT storage_key FROM orphaned_blobs
+		WHERE last_attempt_at IS NULL OR last_attempt_at < CURRENT_TIMESTAMP - make_interval(secs => $1)
+		ORDER BY last_attempt_at NULLS FIRST
+		LIMIT $2`
+
+	if err := pg.conn.SelectContext(ctx, &keys, query, retryAfter.Seconds(), limit); err != nil {
+		zap.S().Errorf("failed to get orphaned blobs: %v", err)
+		return nil, fmt.Errorf("failed to get orphaned blobs")
+	}
+	return keys, nil
+}
+
+func (pg *Postgres) DeleteOrphanedBlob(ctx context.Context, key string) error {
+	if _, err := pg.conn.ExecContext(ctx, `DELETE FROM orphaned_blobs WHERE storage_key = $1`, key); err != nil {
+		zap.S().Errorf("failed to delete orphaned blob %s: %v", key, err)
+		return fmt.Errorf("failed to delete orphaned blob")
+	}
+	return nil
+}
+
+func (pg *Postgres) MarkOrphanedBlobFailed(ctx context.Context, key string, deleteErr string) error {
+	query := `UPDATE orphaned_blobs
+		SET attempts = attempts + 1, last_error = $2, last_attempt_at = CURRENT_TIMESTAMP
+		WHERE storage_key = $1`
+
+	if _, err := pg.conn.ExecContext(ctx, query, key, deleteErr); err != nil {
+		zap.S().Errorf("failed to mark orphaned blob %s: %v", key, err)
+		return fmt.Errorf("failed to mark orphaned blob")
+	}
+	return nil
+}
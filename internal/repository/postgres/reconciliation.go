@@ -0,0 +1,20 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"go.uber.org/zap"
+)
+
+func (pg *Postgres) GetPaymentDiscrepancies(ctx context.Context) ([]model.PaymentDiscrepancy, error) {
+	var discrepancies []model.PaymentDiscrepancy
+	query := `SELECT * FROM get_payment_discrepancies()`
+	err := pg.conn.SelectContext(ctx, &discrepancies, query)
+	if err != nil {
+		zap.S().Errorf("failed to get payment discrepancies: %v", err)
+		return nil, fmt.Errorf("failed to get payment discrepancies")
+	}
+	return discrepancies, nil
+}
@@ -11,9 +11,11 @@ import (
 )
 
 func (pg *Postgres) CreateReview(ctx context.Context, review *model.Review) error {
-	query := `INSERT INTO reviews (booking_id, user_id, text, score) VALUES ($1, $2, $3, $4) RETURNING id`
+	query := `INSERT INTO reviews (booking_id, user_id, text, score, cleanliness_score, accuracy_score, location_score, value_score, status, flag_reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id, created_at`
 
-	err := pg.conn.QueryRowxContext(ctx, query, review.BookingID, review.UserID, review.Text, review.Score).Scan(&review.ID)
+	err := pg.conn.QueryRowxContext(ctx, query, review.BookingID, review.UserID, review.Text, review.Score,
+		review.Cleanliness, review.Accuracy, review.Location, review.Value, review.Status, review.FlagReason).Scan(&review.ID, &review.CreatedAt)
 	if err != nil {
 		zap.S().Errorf("failed to create review: %v", err)
 		return fmt.Errorf("failed to create review")
@@ -23,7 +25,9 @@ func (pg *Postgres) CreateReview(ctx context.Context, review *model.Review) erro
 }
 
 func (pg *Postgres) CreateReviews(ctx context.Context, reviews []model.Review) error {
-	query := `INSERT INTO reviews (booking_id, user_id, text, score) VALUES ($1, $2, $3, $4)`
+	// пустой статус у сгенерированных отзывов не должен обходить DEFAULT колонки
+	query := `INSERT INTO reviews (booking_id, user_id, text, score, cleanliness_score, accuracy_score, location_score, value_score, status, flag_reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE(NULLIF($9, ''), 'published'), $10)`
 
 	zap.S().Infof("start adding %v reviews", len(reviews))
 	tx, err := pg.conn.BeginTxx(ctx, nil)
@@ -41,7 +45,8 @@ func (pg *Postgres) CreateReviews(ctx context.Context, reviews []model.Review) e
 	defer stmt.Close()
 
 	for i := range reviews {
-		_, err := stmt.ExecContext(ctx, reviews[i].BookingID, reviews[i].UserID, reviews[i].Text, reviews[i].Score)
+		_, err := stmt.ExecContext(ctx, reviews[i].BookingID, reviews[i].UserID, reviews[i].Text, reviews[i].Score,
+			reviews[i].Cleanliness, reviews[i].Accuracy, reviews[i].Location, reviews[i].Value, reviews[i].Status, reviews[i].FlagReason)
 		if err != nil {
 			zap.S().Errorf("failed to insert review at index %d: %v", i, err)
 			return fmt.Errorf("failed to create reviews")
@@ -60,7 +65,7 @@ func (pg *Postgres) CreateReviews(ctx context.Context, reviews []model.Review) e
 func (pg *Postgres) GetReviewByID(ctx context.Context, id int) (*model.Review, error) {
 	var review model.Review
 
-	query := `SELECT id, booking_id, user_id, text, score FROM reviews WHERE id = $1`
+	query := `SELECT id, booking_id, user_id, text, score, cleanliness_score, accuracy_score, location_score, value_score, status, flag_reason, host_response, host_response_at, created_at, updated_at FROM reviews WHERE id = $1`
 
 	err := pg.conn.GetContext(ctx, &review, query, id)
 	if err != nil {
@@ -77,7 +82,7 @@ func (pg *Postgres) GetReviewByID(ctx context.Context, id int) (*model.Review, e
 
 func (pg *Postgres) GetReviewsByID(ctx context.Context, ids []int) ([]model.Review, error) {
 
-	query, args, err := sqlx.In(`SELECT id, booking_id, user_id, text, score FROM reviews WHERE id IN (?)`, ids)
+	query, args, err := sqlx.In(`SELECT id, booking_id, user_id, text, score, cleanliness_score, accuracy_score, location_score, value_score, status, flag_reason, host_response, host_response_at, created_at, updated_at FROM reviews WHERE id IN (?)`, ids)
 	if err != nil {
 		zap.S().Errorf("failed to build query: %v", err)
 		return nil, fmt.Errorf("failed to get reviews")
@@ -95,12 +100,25 @@ func (pg *Postgres) GetReviewsByID(ctx context.Context, ids []int) ([]model.Revi
 	return reviews, nil
 }
 
-func (pg *Postgres) UpdateReview(ctx context.Context, review *model.Review) error {
+func (pg *Postgres) UpdateReview(ctx context.Context, review *model.Review, revealAfterDays int) error {
 	query := `UPDATE reviews
-		SET booking_id = $1, user_id = $2, text = $3, score = $4
-		WHERE id = $5`
+		SET booking_id = $1, user_id = $2, text = $3, score = $4, cleanliness_score = $5, accuracy_score = $6,
+			location_score = $7, value_score = $8, status = $9, flag_reason = $10, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $11`
 
-	result, err := pg.conn.ExecContext(ctx, query, review.BookingID, review.UserID, review.Text, review.Score, review.ID)
+	tx, err := pg.conn.BeginTxx(ctx, nil)
+	if err != nil {
+		zap.S().Errorf("failed to begin transaction: %v", err)
+		return fmt.Errorf("failed to update review")
+	}
+	defer tx.Rollback()
+
+	if err := checkBookingReviewsNotRevealed(ctx, tx, review.BookingID, revealAfterDays); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, query, review.BookingID, review.UserID, review.Text, review.Score,
+		review.Cleanliness, review.Accuracy, review.Location, review.Value, review.Status, review.FlagReason, review.ID)
 	if err != nil {
 		zap.S().Errorf("failed to update review: %v", err)
 		return fmt.Errorf("failed to update review")
@@ -117,13 +135,19 @@ func (pg *Postgres) UpdateReview(ctx context.Context, review *model.Review) erro
 		return fmt.Errorf("review not found")
 	}
 
+	if err := tx.Commit(); err != nil {
+		zap.S().Errorf("failed to commit transaction: %v", err)
+		return fmt.Errorf("failed to update review")
+	}
+
 	return nil
 }
 
 func (pg *Postgres) UpdateReviews(ctx context.Context, reviews []model.Review) error {
 	query := `UPDATE reviews
-		SET booking_id = $1, user_id = $2, text = $3, score = $4
-		WHERE id = $5`
+		SET booking_id = $1, user_id = $2, text = $3, score = $4, cleanliness_score = $5, accuracy_score = $6,
+			location_score = $7, value_score = $8, status = $9, flag_reason = $10, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $11`
 
 	tx, err := pg.conn.BeginTxx(ctx, nil)
 	if err != nil {
@@ -140,7 +164,8 @@ func (pg *Postgres) UpdateReviews(ctx context.Context, reviews []model.Review) e
 	defer stmt.Close()
 
 	for i := range reviews {
-		_, err := stmt.ExecContext(ctx, reviews[i].BookingID, reviews[i].UserID, reviews[i].Text, reviews[i].Score, reviews[i].ID)
+		_, err := stmt.ExecContext(ctx, reviews[i].BookingID, reviews[i].UserID, reviews[i].Text, reviews[i].Score,
+			reviews[i].Cleanliness, reviews[i].Accuracy, reviews[i].Location, reviews[i].Value, reviews[i].Status, reviews[i].FlagReason, reviews[i].ID)
 		if err != nil {
 			zap.S().Errorf("failed to update review at index %d: %v", i, err)
 			return fmt.Errorf("failed to update reviews")
@@ -155,24 +180,37 @@ func (pg *Postgres) UpdateReviews(ctx context.Context, reviews []model.Review) e
 	return nil
 }
 
-func (pg *Postgres) DeleteReview(ctx context.Context, id int) error {
-	query := `DELETE FROM reviews WHERE id = $1`
-
-	result, err := pg.conn.ExecContext(ctx, query, id)
+func (pg *Postgres) DeleteReview(ctx context.Context, id int, revealAfterDays int) error {
+	tx, err := pg.conn.BeginTxx(ctx, nil)
 	if err != nil {
-		zap.S().Errorf("failed to delete review: %v", err)
+		zap.S().Errorf("failed to begin transaction: %v", err)
 		return fmt.Errorf("failed to delete review")
 	}
+	defer tx.Rollback()
 
-	rowsAffected, err := result.RowsAffected()
+	var bookingID int
+	err = tx.GetContext(ctx, &bookingID, `SELECT booking_id FROM reviews WHERE id = $1`, id)
 	if err != nil {
-		zap.S().Errorf("failed to get rows affected: %v", err)
+		if err == sql.ErrNoRows {
+			zap.S().Errorf("review with id %d not found", id)
+			return fmt.Errorf("review not found")
+		}
+		zap.S().Errorf("failed to get review: %v", err)
 		return fmt.Errorf("failed to delete review")
 	}
 
-	if rowsAffected == 0 {
-		zap.S().Errorf("review with id %d not found", id)
-		return fmt.Errorf("review not found")
+	if err := checkBookingReviewsNotRevealed(ctx, tx, bookingID, revealAfterDays); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM reviews WHERE id = $1`, id); err != nil {
+		zap.S().Errorf("failed to delete review: %v", err)
+		return fmt.Errorf("failed to delete review")
+	}
+
+	if err := tx.Commit(); err != nil {
+		zap.S().Errorf("failed to commit transaction: %v", err)
+		return fmt.Errorf("failed to delete review")
 	}
 
 	return nil
@@ -199,3 +237,153 @@ func (pg *Postgres) DeleteReviews(ctx context.Context, ids []int) error {
 
 	return nil
 }
+
+func (pg *Postgres) GetReviewsByStatus(ctx context.Context, status string, limit, offset int) ([]model.Review, error) {
+	query := `SELECT id, booking_id, user_id, text, score, cleanliness_score, accuracy_score, location_score, value_score, status, flag_reason, host_response, host_response_at, created_at, updated_at
+		FROM reviews WHERE status = $1 ORDER BY created_at, id LIMIT $2 OFFSET $3`
+
+	reviews := make([]model.Review, 0)
+	err := pg.conn.SelectContext(ctx, &reviews, query, status, limit, offset)
+	if err != nil {
+		zap.S().Errorf("failed to get reviews with status %s: %v", status, err)
+		return nil, fmt.Errorf("failed to get reviews")
+	}
+
+	return reviews, nil
+}
+
+func (pg *Postgres) UpdateReviewStatus(ctx context.Context, id int, status string) error {
+	query := `UPDATE reviews SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`
+
+	result, err := pg.conn.ExecContext(ctx, query, status, id)
+	if err != nil {
+		zap.S().Errorf("failed to update review status: %v", err)
+		return fmt.Errorf("failed to update review status")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		zap.S().Errorf("failed to get rows affected: %v", err)
+		return fmt.Errorf("failed to update review status")
+	}
+
+	if rowsAffected == 0 {
+		zap.S().Errorf("review with id %d not found", id)
+		return fmt.Errorf("review not found")
+	}
+
+	return nil
+}
+
+func (pg *Postgres) UpdateReviewHostResponse(ctx context.Context, id int, response string) error {
+	query := `UPDATE reviews SET host_response = $1, host_response_at = CURRENT_TIMESTAMP WHERE id = $2`
+
+	result, err := pg.conn.ExecContext(ctx, query, response, id)
+	if err != nil {
+		zap.S().Errorf("failed to update review host response: %v", err)
+		return fmt.Errorf("failed to update review host response")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		zap.S().Errorf("failed to get rows affected: %v", err)
+		return fmt.Errorf("failed to update review host response")
+	}
+
+	if rowsAffected == 0 {
+		zap.S().Errorf("review with id %d not found", id)
+		return fmt.Errorf("review not found")
+	}
+
+	return nil
+}
+
+func (pg *Postgres) GetReviewsPage(ctx context.Context, filter model.ReviewsFilter) ([]model.Review, error) {
+	where, args := reviewsFilterConditions(filter)
+
+	orderBy := `r.created_at DESC, r.id DESC`
+	if filter.Sort == model.ReviewsSortScore {
+		orderBy = `r.score DESC, r.id DESC`
+	}
+
+	if filter.Cursor != nil {
+		if filter.Sort == model.ReviewsSortScore {
+			args = append(args, filter.Cursor.Score, filter.Cursor.ID)
+			where += fmt.Sprintf(` AND (r.score, r.id) < ($%d, $%d)`, len(args)-1, len(args))
+		} else {
+			args = append(args, filter.Cursor.CreatedAt, filter.Cursor.ID)
+			where += fmt.Sprintf(` AND (r.created_at, r.id) < ($%d, $%d)`, len(args)-1, len(args))
+		}
+	}
+
+	args = append(args, filter.Limit)
+	query := fmt.Sprintf(`SELECT r.id, r.booking_id, r.user_id, r.text, r.score, r.cleanliness_score, r.accuracy_score,
+			r.location_score, r.value_score, r.status, r.flag_reason, r.host_response, r.host_response_at, r.created_at, r.updated_at
+		FROM reviews r
+		JOIN bookings b ON r.booking_id = b.booking_id
+		WHERE %s
+		ORDER BY %s
+		LIMIT $%d`, where, orderBy, len(args))
+
+	reviews := make([]model.Review, 0, filter.Limit)
+	err := pg.conn.SelectContext(ctx, &reviews, query, args...)
+	if err != nil {
+		zap.S().Errorf("failed to get reviews page: %v", err)
+		return nil, fmt.Errorf("failed to get reviews")
+	}
+
+	return reviews, nil
+}
+
+func (pg *Postgres) GetReviewsSummary(ctx context.Context, filter model.ReviewsFilter) (*model.ReviewsSummary, error) {
+	where, args := reviewsFilterConditions(filter)
+
+	query := fmt.Sprintf(`SELECT r.score, COUNT(*) AS reviews_count
+		FROM reviews r
+		JOIN bookings b ON r.booking_id = b.booking_id
+		WHERE %s
+		GROUP BY r.score`, where)
+
+	var rows []struct {
+		Score int `db:"score"`
+		Count int `db:"reviews_count"`
+	}
+	err := pg.conn.SelectContext(ctx, &rows, query, args...)
+	if err != nil {
+		zap.S().Errorf("failed to get reviews summary: %v", err)
+		return nil, fmt.Errorf("failed to get reviews summary")
+	}
+
+	summary := model.ReviewsSummary{
+		Histogram: map[int]int{1: 0, 2: 0, 3: 0, 4: 0, 5: 0},
+	}
+	total := 0
+	for i := range rows {
+		summary.Histogram[rows[i].Score] = rows[i].Count
+		summary.Count += rows[i].Count
+		total += rows[i].Score * rows[i].Count
+	}
+	if summary.Count > 0 {
+		summary.Average = float64(total) / float64(summary.Count)
+	}
+
+	return &summary, nil
+}
+
+// в публичную выдачу попадают только опубликованные и раскрытые отзывы
+func reviewsFilterConditions(filter model.ReviewsFilter) (string, []any) {
+	args := []any{model.ReviewStatusPublished, filter.RevealAfterDays}
+	where := `r.status = $1 AND is_booking_reviews_revealed(r.booking_id, $2)`
+
+	if filter.ListingID != nil {
+		args = append(args, *filter.ListingID)
+		where += fmt.Sprintf(` AND b.listing_id = $%d`, len(args))
+	}
+
+	if filter.UserID != nil {
+		args = append(args, *filter.UserID)
+		where += fmt.Sprintf(` AND r.user_id = $%d`, len(args))
+	}
+
+	return where, args
+}
@@ -0,0 +1,32 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"go.uber.org/zap"
+)
+
+// GetTripsByGuestID читает брони гостя из bookings_payments_analytics, где уже вычислен booking_status.
+// В представлении по строке на платеж, для каждой брони берется последний
+func (pg *Postgres) GetTripsByGuestID(ctx context.Context, guestID int) ([]model.Trip, error) {
+	query := `SELECT * FROM (
+			SELECT DISTINCT ON (a.booking_id)
+				a.booking_id, a.listing_id, a.host_id, a.host_name, a.in_date, a.out_date, a.duration_days,
+				a.total_price, a.is_paid, a.payment_status, a.payment_amount, a.paid_at, a.review_id, a.booking_status
+			FROM bookings_payments_analytics a
+			WHERE a.guest_id = $1
+			ORDER BY a.booking_id, a.payment_id DESC NULLS LAST
+		) t
+		ORDER BY t.in_date, t.booking_id`
+
+	var trips []model.Trip
+	err := pg.conn.SelectContext(ctx, &trips, query, guestID)
+	if err != nil {
+		zap.S().Errorf("failed to get trips for guest %d: %v", guestID, err)
+		return nil, fmt.Errorf("failed to get trips")
+	}
+
+	return trips, nil
+}
@@ -0,0 +1,238 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+const wishlistColumns = `w.id, w.user_id, w.name, w.is_default, w.share_token, w.created_at, w.updated_at,
+	(SELECT COUNT(*) FROM favorites f WHERE f.wishlist_id = w.id) AS items_count`
+
+func (pg *Postgres) CreateWishlist(ctx context.Context, wishlist *model.Wishlist) error {
+	query := `INSERT INTO wishlists (user_id, name, is_default) VALUES ($1, $2, $3)
+		RETURNING id, created_at, updated_at`
+
+	err := pg.conn.QueryRowxContext(ctx, query, wishlist.UserID, wishlist.Name, wishlist.IsDefault).
+		Scan(&wishlist.ID, &wishlist.CreatedAt, &wishlist.UpdatedAt)
+	if err != nil {
+		zap.S().Errorf("failed to create wishlist: %v", err)
+		return fmt.Errorf("failed to create wishlist")
+	}
+
+	return nil
+}
+
+func (pg *Postgres) GetWishlistByID(ctx context.Context, id int) (*model.Wishlist, error) {
+	var wishlist model.Wishlist
+	query := `SELECT ` + wishlistColumns + ` FROM wishlists w WHERE w.id = $1`
+	err := pg.conn.GetContext(ctx, &wishlist, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			zap.S().Errorf("wishlist with id %d not found", id)
+			return nil, fmt.Errorf("wishlist not found")
+		}
+		zap.S().Errorf("failed to get wishlist: %v", err)
+		return nil, fmt.Errorf("failed to get wishlist")
+	}
+	return &wishlist, nil
+}
+
+func (pg *Postgres) GetWishlistByShareToken(ctx context.Context, token string) (*model.Wishlist, error) {
+	var wishlist model.Wishlist
+	query := `SELECT ` + wishlistColumns + ` FROM wishlists w WHERE w.share_token = $1`
+	err := pg.conn.GetContext(ctx, &wishlist, query, token)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("wishlist not found")
+		}
+		zap.S().Errorf("failed to get shared wishlist: %v", err)
+		return nil, fmt.Errorf("failed to get wishlist")
+	}
+	return &wishlist, nil
+}
+
+func (pg *Postgres) GetWishlistsByUserID(ctx context.Context, userID int) ([]model.Wishlist, error) {
+	var wishlists []model.Wishlist
+	query := `SELECT ` + wishlistColumns + ` FROM wishlists w WHERE w.user_id = $1 ORDER BY w.is_default DESC, w.id`
+	err := pg.conn.SelectContext(ctx, &wishlists, query, userID)
+	if err != nil {
+		zap.S().Errorf("failed to get wishlists for user %d: %v", userID, err)
+		return nil, fmt.Errorf("failed to get wishlists for user")
+	}
+	return wishlists, nil
+}
+
+// GetOrCreateDefaultWishlist возвращает список по умолчанию, создавая его при первом обращении
+func (pg *Postgres) GetOrCreateDefaultWishlist(ctx context.Context, userID int) (*model.Wishlist, error) {
+	query := `INSERT INTO wishlists (user_id, name, is_default) VALUES ($1, $2, TRUE)
+		ON CONFLICT (user_id) WHERE is_default DO NOTHING`
+
+	if _, err := pg.conn.ExecContext(ctx, query, userID, model.DefaultWishlistName); err != nil {
+		zap.S().Errorf("failed to create default wishlist for user %d: %v", userID, err)
+		return nil, fmt.Errorf("failed to get default wishlist")
+	}
+
+	var wishlist model.Wishlist
+	err := pg.conn.GetContext(ctx, &wishlist, `SELECT `+wishlistColumns+` FROM wishlists w
+		WHERE w.user_id = $1 AND w.is_default`, userID)
+	if err != nil {
+		zap.S().Errorf("failed to get default wishlist for user %d: %v", userID, err)
+		return nil, fmt.Errorf("failed to get default wishlist")
+	}
+
+	return &wishlist, nil
+}
+
+func (pg *Postgres) UpdateWishlist(ctx context.Context, wishlist *model.Wishlist) error {
+	query := `UPDATE wishlists SET name = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2 RETURNING updated_at`
+
+	err := pg.conn.QueryRowxContext(ctx, query, wishlist.Name, wishlist.ID).Scan(&wishlist.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			zap.S().Errorf("wishlist with id %d not found", wishlist.ID)
+			return fmt.Errorf("wishlist not found")
+		}
+		zap.S().Errorf("failed to update wishlist: %v", err)
+		return fmt.Errorf("failed to update wishlist")
+	}
+
+	return nil
+}
+
+func (pg *Postgres) UpdateWishlistShareToken(ctx context.Context, id int, token *string) error {
+	query := `UPDATE wishlists SET share_token = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`
+
+	result, err := pg.conn.ExecContext(ctx, query, token, id)
+	if err != nil {
+		zap.S().Errorf("failed to update wishlist share token: %v", err)
+		return fmt.Errorf("failed to update wishlist")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		zap.S().Errorf("failed to get rows affected: %v", err)
+		return fmt.Errorf("failed to update wishlist")
+	}
+
+	if rowsAffected == 0 {
+		zap.S().Errorf("wishlist with id %d not found", id)
+		return fmt.Errorf("wishlist not found")
+	}
+
+	return nil
+}
+
+func (pg *Postgres) DeleteWishlist(ctx context.Context, id int) error {
+	query := `DELETE FROM wishlists WHERE id = $1`
+
+	result, err := pg.conn.ExecContext(ctx, query, id)
+	if err != nil {
+		zap.S().Errorf("failed to delete wishlist: %v", err)
+		return fmt.Errorf("failed to delete wishlist")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		zap.S().Errorf("failed to get rows affected: %v", err)
+		return fmt.Errorf("failed to delete wishlist")
+	}
+
+	if rowsAffected == 0 {
+		zap.S().Errorf("wishlist with id %d not found", id)
+		return fmt.Errorf("wishlist not found")
+	}
+
+	return nil
+}
+
+func (pg *Postgres) GetFavoritesByWishlistID(ctx context.Context, wishlistID int) ([]model.Favorite, error) {
+	var favorites []model.Favorite
+	query := `SELECT id, user_id, listing_id, wishlist_id, note, created_at FROM favorites
+		WHERE wishlist_id = $1 ORDER BY created_at DESC, id DESC`
+	err := pg.conn.SelectContext(ctx, &favorites, query, wishlistID)
+	if err != nil {
+		zap.S().Errorf("failed to get favorites for wishlist %d: %v", wishlistID, err)
+		return nil, fmt.Errorf("failed to get wishlist items")
+	}
+	return favorites, nil
+}
+
+func (pg *Postgres) UpdateFavoriteNote(ctx context.Context, favorite *model.Favorite) error {
+	query := `UPDATE favorites SET note = $1 WHERE wishlist_id = $2 AND listing_id = $3
+		RETURNING id, user_id, created_at`
+
+	err := pg.conn.QueryRowxContext(ctx, query, favorite.Note, favorite.WishlistID, favorite.ListingID).
+		Scan(&favorite.ID, &favorite.UserID, &favorite.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			zap.S().Errorf("listing %d not found in wishlist %d", favorite.ListingID, favorite.WishlistID)
+			return fmt.Errorf("wishlist item not found")
+		}
+		zap.S().Errorf("failed to update favorite note: %v", err)
+		return fmt.Errorf("failed to update wishlist item")
+	}
+
+	return nil
+}
+
+func (pg *Postgres) DeleteFavoriteFromWishlist(ctx context.Context, wishlistID int, listingID int) error {
+	query := `DELETE FROM favorites WHERE wishlist_id = $1 AND listing_id = $2`
+
+	result, err := pg.conn.ExecContext(ctx, query, wishlistID, listingID)
+	if err != nil {
+		zap.S().Errorf("failed to delete favorite: %v", err)
+		return fmt.Errorf("failed to delete wishlist item")
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		zap.S().Errorf("failed to get rows affected: %v", err)
+		return fmt.Errorf("failed to delete wishlist item")
+	}
+
+	if rowsAffected == 0 {
+		zap.S().Errorf("listing %d not found in wishlist %d", listingID, wishlistID)
+		return fmt.Errorf("wishlist item not found")
+	}
+
+	return nil
+}
+
+func (pg *Postgres) GetListingCards(ctx context.Context, listingIDs []int) ([]model.ListingCard, error) {
+	if len(listingIDs) == 0 {
+		return nil, nil
+	}
+
+	// для карточки берем миниатюру основного фото, если она уже готова
+	query, args, err := sqlx.In(`SELECT l.id AS listing_id, l.address, l.price_per_night, l.is_available,
+			l.average_rating, l.reviews_count, COALESCE(v.image_url, i.image_url) AS primary_image_url
+		FROM listings l
+		LEFT JOIN images i ON i.listing_id = l.id AND i.is_primary
+		LEFT JOIN image_variants v ON v.image_id = i.image_id AND v.variant = 'thumbnail'
+		WHERE l.id IN (?)`, listingIDs)
+	if err != nil {
+		zap.S().Errorf("failed to build query: %v", err)
+		return nil, fmt.Errorf("failed to get listing cards")
+	}
+
+	var cards []model.ListingCard
+	err = pg.conn.SelectContext(ctx, &cards, pg.conn.Rebind(query), args...)
+	if err != nil {
+		zap.S().Errorf("failed to get listing cards: %v", err)
+		return nil, fmt.Errorf("failed to get listing cards")
+	}
+
+	return cards, nil
+}
+
+func nullableID(id int) *int {
+	if id == 0 {
+		return nil
+	}
+	return &id
+}
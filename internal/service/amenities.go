@@ -2,24 +2,68 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	"github.com/Rissochek/db-cw/internal/model"
 )
 
 func (s *Service) CreateAmenity(ctx context.Context, amenity *model.Amenity) error {
-	return s.repo.CreateAmenity(ctx, amenity)
+	if err := s.checkAmenityCategory(ctx, amenity.Category); err != nil {
+		return err
+	}
+
+	if err := s.repo.CreateAmenity(ctx, amenity); err != nil {
+		return err
+	}
+
+	amenity.Label = amenity.Labels.Resolve("", amenity.Name)
+	return nil
 }
 
-func (s *Service) GetAmenityByID(ctx context.Context, id int) (*model.Amenity, error) {
-	return s.repo.GetAmenityByID(ctx, id)
+func (s *Service) GetAmenityByID(ctx context.Context, id int, locale string) (*model.Amenity, error) {
+	amenity, err := s.repo.GetAmenityByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	amenity.Label = amenity.Labels.Resolve(locale, amenity.Name)
+	return amenity, nil
 }
 
-func (s *Service) GetAllAmenities(ctx context.Context) ([]model.Amenity, error) {
-	return s.repo.GetAllAmenities(ctx)
+func (s *Service) GetAllAmenities(ctx context.Context, category *string, locale string) ([]model.Amenity, error) {
+	amenities, err := s.repo.GetAllAmenities(ctx, category)
+	if err != nil {
+		return nil, err
+	}
+
+	localizeAmenities(amenities, locale)
+	return amenities, nil
+}
+
+func (s *Service) GetAmenityCategories(ctx context.Context, locale string) ([]model.AmenityCategory, error) {
+	categories, err := s.repo.GetAmenityCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range categories {
+		categories[i].Label = categories[i].Labels.Resolve(locale, categories[i].Name)
+	}
+	return categories, nil
 }
 
 func (s *Service) UpdateAmenity(ctx context.Context, amenity *model.Amenity) error {
-	return s.repo.UpdateAmenity(ctx, amenity)
+	if err := s.checkAmenityCategory(ctx, amenity.Category); err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateAmenity(ctx, amenity); err != nil {
+		return err
+	}
+
+	amenity.Label = amenity.Labels.Resolve("", amenity.Name)
+	return nil
 }
 
 func (s *Service) DeleteAmenity(ctx context.Context, id int) error {
@@ -44,10 +88,58 @@ func (s *Service) RemoveAmenityFromListing(ctx context.Context, listingID int, a
 	return s.repo.RemoveAmenityFromListing(ctx, listingID, amenityID)
 }
 
-func (s *Service) GetAmenitiesByListingID(ctx context.Context, listingID int) ([]model.Amenity, error) {
-	return s.repo.GetAmenitiesByListingID(ctx, listingID)
+func (s *Service) GetAmenitiesByListingID(ctx context.Context, listingID int, locale string) ([]model.Amenity, error) {
+	amenities, err := s.repo.GetAmenitiesByListingID(ctx, listingID)
+	if err != nil {
+		return nil, err
+	}
+
+	localizeAmenities(amenities, locale)
+	return amenities, nil
+}
+
+func (s *Service) ReplaceListingAmenities(ctx context.Context, listingID int, amenityIDs []int, locale string) ([]model.Amenity, error) {
+	_, err := s.repo.GetListingByID(ctx, listingID)
+	if err != nil {
+		return nil, err
+	}
+
+	amenityIDs = slices.Clone(amenityIDs)
+	slices.Sort(amenityIDs)
+	amenityIDs = slices.Compact(amenityIDs)
+
+	if err := s.repo.ReplaceListingAmenities(ctx, listingID, amenityIDs); err != nil {
+		return nil, err
+	}
+
+	return s.GetAmenitiesByListingID(ctx, listingID, locale)
 }
 
 func (s *Service) CreateListingAmenities(ctx context.Context, listingAmenities []model.ListingAmenity) error {
 	return s.repo.CreateListingAmenities(ctx, listingAmenities)
 }
+
+func (s *Service) checkAmenityCategory(ctx context.Context, category *string) error {
+	if category == nil {
+		return nil
+	}
+
+	categories, err := s.repo.GetAmenityCategories(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range categories {
+		if c.Key == *category {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid amenity category: %s", *category)
+}
+
+func localizeAmenities(amenities []model.Amenity, locale string) {
+	for i := range amenities {
+		amenities[i].Label = amenities[i].Labels.Resolve(locale, amenities[i].Name)
+	}
+}
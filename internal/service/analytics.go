@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Rissochek/db-cw/internal/model"
+)
+
+var (
+	analyticsBookingStatuses = []string{"completed", "active", "upcoming"}
+	analyticsPaymentStatuses = []string{"pending", "completed", "failed", "refunded"}
+)
+
+func (s *Service) GetListingsAnalytics(ctx context.Context, filter model.AnalyticsFilter) (*model.ListingsAnalyticsPage, error) {
+	if err := validateAnalyticsFilter(&filter, model.ListingsAnalyticsSortFields, "total_revenue"); err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.GetListingsAnalytics(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.repo.CountListingsAnalytics(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.ListingsAnalyticsPage{Items: items, Total: total, Limit: filter.Limit, Offset: filter.Offset}, nil
+}
+
+func (s *Service) GetHostsAnalytics(ctx context.Context, filter model.AnalyticsFilter) (*model.HostsAnalyticsPage, error) {
+	if err := validateAnalyticsFilter(&filter, model.HostsAnalyticsSortFields, "total_revenue"); err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.GetHostsAnalytics(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.repo.CountHostsAnalytics(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.HostsAnalyticsPage{Items: items, Total: total, Limit: filter.Limit, Offset: filter.Offset}, nil
+}
+
+func (s *Service) GetBookingsAnalytics(ctx context.Context, filter model.AnalyticsFilter) (*model.BookingsAnalyticsPage, error) {
+	if err := validateAnalyticsFilter(&filter, model.BookingsAnalyticsSortFields, "in_date"); err != nil {
+		return nil, err
+	}
+
+	if filter.BookingStatus != nil && !slices.Contains(analyticsBookingStatuses, *filter.BookingStatus) {
+		return nil, fmt.Errorf("invalid analytics filter: status must be one of %s", strings.Join(analyticsBookingStatuses, ", "))
+	}
+	if filter.PaymentStatus != nil && !slices.Contains(analyticsPaymentStatuses, *filter.PaymentStatus) {
+		return nil, fmt.Errorf("invalid analytics filter: payment_status must be one of %s", strings.Join(analyticsPaymentStatuses, ", "))
+	}
+
+	items, err := s.repo.GetBookingsAnalytics(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.repo.CountBookingsAnalytics(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.BookingsAnalyticsPage{Items: items, Total: total, Limit: filter.Limit, Offset: filter.Offset}, nil
+}
+
+// validateAnalyticsFilter проверяет сортировку по белому списку; по умолчанию - defaultSort по убыванию
+func validateAnalyticsFilter(filter *model.AnalyticsFilter, sortFields []string, defaultSort string) error {
+	if filter.Sort == "" {
+		filter.Sort = defaultSort
+		if filter.Order == "" {
+			filter.Order = model.SortOrderDesc
+		}
+	}
+	if !slices.Contains(sortFields, filter.Sort) {
+		return fmt.Errorf("invalid analytics filter: sort must be one of %s", strings.Join(sortFields, ", "))
+	}
+
+	if filter.Order == "" {
+		filter.Order = model.SortOrderAsc
+	}
+	if filter.Order != model.SortOrderAsc && filter.Order != model.SortOrderDesc {
+		return fmt.Errorf("invalid analytics filter: order must be asc or desc")
+	}
+
+	if filter.StartDate != nil && filter.EndDate != nil && filter.StartDate.After(*filter.EndDate) {
+		return fmt.Errorf("invalid analytics filter: start_date is after end_date")
+	}
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/Rissochek/db-cw/internal/model"
+)
+
+var (
+	benchmarkPeriodDays      = 90
+	defaultBenchmarkRadiusKm = 25.0
+	// при меньшем числе похожих поблизости сравниваем без учета места, а цену не советуем
+	minBenchmarkComparables = 5
+	maxBenchmarkComparables = 20
+)
+
+// GetListingBenchmark сравнивает цену, рейтинг, число бронирований и загрузку объявления с похожими
+func (s *Service) GetListingBenchmark(ctx context.Context, listingID int, radiusKm *float64) (*model.ListingBenchmark, error) {
+	if radiusKm == nil {
+		radius := defaultBenchmarkRadiusKm
+		radiusKm = &radius
+	}
+	if *radiusKm <= 0 || *radiusKm > maxSearchRadiusKm {
+		return nil, fmt.Errorf("invalid benchmark: radius_km must be between 0 and %.0f", maxSearchRadiusKm)
+	}
+
+	listing, err := s.repo.GetListingByID(ctx, listingID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	periodStart := now.AddDate(0, 0, -benchmarkPeriodDays)
+
+	// радиус применяется только при координатах у объявления, без них отбор идет по городу,
+	// а без города get_listing_comparables место не учитывает
+	benchmark := &model.ListingBenchmark{
+		ListingID:        listingID,
+		PeriodDays:       benchmarkPeriodDays,
+		LocationFiltered: listing.Latitude != nil || listing.City != nil,
+	}
+	if listing.Latitude != nil {
+		benchmark.RadiusKm = radiusKm
+	}
+
+	rows, err := s.repo.GetListingComparables(ctx, listingID, periodStart, now, radiusKm)
+	if err != nil {
+		return nil, err
+	}
+	if benchmark.LocationFiltered && len(rows)-1 < minBenchmarkComparables {
+		rows, err = s.repo.GetListingComparables(ctx, listingID, periodStart, now, nil)
+		if err != nil {
+			return nil, err
+		}
+		benchmark.RadiusKm = nil
+		benchmark.LocationFiltered = false
+	}
+
+	// get_listing_comparables возвращает само объявление первой строкой
+	if len(rows) == 0 || !rows[0].IsTarget {
+		return nil, fmt.Errorf("listing not found")
+	}
+	target, comparables := rows[0], rows[1:]
+
+	benchmark.Listing = target
+	benchmark.ComparablesCount = len(comparables)
+	benchmark.Percentiles = benchmarkPercentiles(target, comparables)
+
+	if len(comparables) >= minBenchmarkComparables {
+		prices := make([]float64, len(comparables))
+		for i, comparable := range comparables {
+			prices[i] = comparable.PricePerNight
+		}
+		slices.Sort(prices)
+		benchmark.SuggestedPrice = &model.PriceRange{
+			Min:    roundPrice(quantile(prices, 0.25)),
+			Median: roundPrice(quantile(prices, 0.5)),
+			Max:    roundPrice(quantile(prices, 0.75)),
+		}
+	}
+
+	benchmark.Comparables = comparables[:min(len(comparables), maxBenchmarkComparables)]
+
+	return benchmark, nil
+}
+
+func benchmarkPercentiles(target model.ListingComparable, comparables []model.ListingComparable) model.BenchmarkPercentiles {
+	var prices, ratings, bookings, occupancy []float64
+	for _, comparable := range comparables {
+		prices = append(prices, comparable.PricePerNight)
+		bookings = append(bookings, float64(comparable.BookingsCount))
+		occupancy = append(occupancy, comparable.OccupancyRate)
+		// рейтинг без отзывов равен нулю и в сравнении не участвует
+		if comparable.ReviewsCount > 0 {
+			ratings = append(ratings, comparable.AverageRating)
+		}
+	}
+
+	percentiles := model.BenchmarkPercentiles{
+		PricePerNight: percentileRank(target.PricePerNight, prices),
+		BookingsCount: percentileRank(float64(target.BookingsCount), bookings),
+		OccupancyRate: percentileRank(target.OccupancyRate, occupancy),
+	}
+	if target.ReviewsCount > 0 {
+		percentiles.AverageRating = percentileRank(target.AverageRating, ratings)
+	}
+
+	return percentiles
+}
+
+// percentileRank доля значений ниже value, равные считаются за половину
+func percentileRank(value float64, values []float64) *float64 {
+	if len(values) == 0 {
+		return nil
+	}
+
+	below, equal := 0, 0
+	for _, v := range values {
+		switch {
+		case v < value:
+			below++
+		case v == value:
+			equal++
+		}
+	}
+
+	rank := math.Round((float64(below)+float64(equal)/2)/float64(len(values))*10000) / 100
+	return &rank
+}
+
+// quantile с линейной интерполяцией по отсортированным значениям
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	lower := int(math.Floor(pos))
+	upper := int(math.Ceil(pos))
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(pos-float64(lower))
+}
+
+func roundPrice(price float64) float64 {
+	return math.Round(price*100) / 100
+}
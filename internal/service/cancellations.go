@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Rissochek/db-cw/internal/model"
+)
+
+func (s *Service) GetListingsCancellationReport(ctx context.Context, startDate, endDate *time.Time) ([]model.ListingCancellationReport, error) {
+	if err := validateReportPeriod(startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetListingsCancellationReport(ctx, startDate, endDate)
+}
+
+func (s *Service) GetHostsCancellationReport(ctx context.Context, startDate, endDate *time.Time) ([]model.HostCancellationReport, error) {
+	if err := validateReportPeriod(startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetHostsCancellationReport(ctx, startDate, endDate)
+}
+
+func (s *Service) GetPaymentFailuresReport(ctx context.Context, startDate, endDate *time.Time) ([]model.PaymentFailureReport, error) {
+	if err := validateReportPeriod(startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetPaymentFailuresReport(ctx, startDate, endDate)
+}
+
+func validateReportPeriod(startDate, endDate *time.Time) error {
+	if startDate != nil && endDate != nil && !startDate.Before(*endDate) {
+		return fmt.Errorf("invalid report period: start_date must be before end_date")
+	}
+	return nil
+}
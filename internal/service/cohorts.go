@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Rissochek/db-cw/internal/model"
+)
+
+func (s *Service) GetGuestCohortCells(ctx context.Context, startDate, endDate *time.Time) ([]model.GuestCohortCell, error) {
+	if err := validateReportPeriod(startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetGuestCohortReport(ctx, startDate, endDate)
+}
+
+// GetGuestCohortReport собирает ячейки отчета в матрицу для тепловой карты:
+// строки - когорты, столбцы - месяцы после первого бронирования
+func (s *Service) GetGuestCohortReport(ctx context.Context, startDate, endDate *time.Time) (*model.GuestCohortReport, error) {
+	cells, err := s.GetGuestCohortCells(ctx, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.GuestCohortReport{
+		MonthOffsets: []int{},
+		Cohorts:      []model.GuestCohort{},
+	}
+
+	// ячейки отсортированы по когорте и месяцу, у самой старой когорты больше всего месяцев
+	for _, cell := range cells {
+		cohortMonth := cell.CohortMonth.Format("2006-01")
+		if len(report.Cohorts) == 0 || report.Cohorts[len(report.Cohorts)-1].CohortMonth != cohortMonth {
+			report.Cohorts = append(report.Cohorts, model.GuestCohort{
+				CohortMonth: cohortMonth,
+				CohortSize:  cell.CohortSize,
+			})
+		}
+
+		cohort := &report.Cohorts[len(report.Cohorts)-1]
+		cohort.ActiveGuests = append(cohort.ActiveGuests, cell.ActiveGuests)
+		cohort.RetentionRates = append(cohort.RetentionRates, cell.RetentionRate)
+		cohort.CumulativeSpend = append(cohort.CumulativeSpend, cell.CumulativeSpend)
+
+		if cell.MonthOffset >= len(report.MonthOffsets) {
+			report.MonthOffsets = append(report.MonthOffsets, cell.MonthOffset)
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"go.uber.org/zap"
+)
+
+var (
+	maxMessageLength     = 2000
+	messagePreviewLength = 100
+)
+
+// StartConversation открывает переписку гостя с хозяином объявления; если она уже есть, возвращает существующую
+func (s *Service) StartConversation(ctx context.Context, conversation *model.Conversation) error {
+	if conversation.BookingID != nil {
+		booking, err := s.repo.GetBookingByID(ctx, *conversation.BookingID)
+		if err != nil {
+			return err
+		}
+
+		if conversation.ListingID == 0 {
+			conversation.ListingID = booking.ListingID
+		}
+		if conversation.GuestID == 0 {
+			conversation.GuestID = booking.GuestID
+		}
+		if booking.ListingID != conversation.ListingID || booking.GuestID != conversation.GuestID {
+			return fmt.Errorf("invalid conversation: booking does not match listing and guest")
+		}
+	}
+
+	listing, err := s.repo.GetListingByID(ctx, conversation.ListingID)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.repo.GetUserByID(ctx, conversation.GuestID)
+	if err != nil {
+		return err
+	}
+
+	if listing.HostID == conversation.GuestID {
+		return fmt.Errorf("invalid conversation: host cannot message themselves")
+	}
+
+	conversation.HostID = listing.HostID
+	return s.repo.GetOrCreateConversation(ctx, conversation)
+}
+
+func (s *Service) GetUserConversations(ctx context.Context, userID int, limit, offset int) ([]model.Conversation, error) {
+	_, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetConversationsByUserID(ctx, userID, limit, offset)
+}
+
+func (s *Service) GetConversation(ctx context.Context, id int, userID int) (*model.Conversation, error) {
+	conversation, err := s.repo.GetConversationByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// userID приходит от клиента и ничем не подтверждён: проверка участника - заглушка до появления аутентификации
+	if !conversation.IsParticipant(userID) {
+		return nil, fmt.Errorf("user is not a participant of the conversation")
+	}
+
+	return conversation, nil
+}
+
+func (s *Service) GetConversationMessages(ctx context.Context, id int, userID int, limit, offset int) ([]model.Message, error) {
+	if _, err := s.GetConversation(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetMessagesByConversationID(ctx, id, limit, offset)
+}
+
+func (s *Service) SendMessage(ctx context.Context, message *model.Message) error {
+	message.Text = strings.TrimSpace(message.Text)
+	if message.Text == "" {
+		return fmt.Errorf("invalid message: text is required")
+	}
+	if len([]rune(message.Text)) > maxMessageLength {
+		return fmt.Errorf("invalid message: text is longer than %d characters", maxMessageLength)
+	}
+
+	conversation, err := s.GetConversation(ctx, message.ConversationID, message.SenderID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.CreateMessage(ctx, message); err != nil {
+		return err
+	}
+
+	s.notifyNewMessage(ctx, conversation, message)
+	return nil
+}
+
+// MarkConversationRead отмечает прочитанными входящие сообщения и возвращает их количество
+func (s *Service) MarkConversationRead(ctx context.Context, id int, userID int) (int, error) {
+	if _, err := s.GetConversation(ctx, id, userID); err != nil {
+		return 0, err
+	}
+
+	return s.repo.MarkConversationRead(ctx, id, userID)
+}
+
+func (s *Service) notifyNewMessage(ctx context.Context, conversation *model.Conversation, message *model.Message) {
+	sender, err := s.repo.GetUserByID(ctx, message.SenderID)
+	if err != nil {
+		zap.S().Errorf("failed to notify about message %d: %v", message.ID, err)
+		return
+	}
+
+	preview := []rune(message.Text)
+	if len(preview) > messagePreviewLength {
+		preview = append(preview[:messagePreviewLength], '…')
+	}
+
+	notification := model.Notification{
+		UserID:    conversation.Recipient(message.SenderID),
+		ListingID: conversation.ListingID,
+		Type:      model.NotificationTypeMessage,
+		Message:   fmt.Sprintf("Новое сообщение от %s %s: %s", sender.FirstName, sender.SecondName, string(preview)),
+	}
+
+	// сообщение уже сохранено, поэтому ошибка уведомления только логируется
+	if err := s.repo.CreateNotification(ctx, &notification); err != nil {
+		zap.S().Errorf("failed to notify about message %d: %v", message.ID, err)
+		return
+	}
+
+	s.notifyNotificationWorker()
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/Rissochek/db-cw/internal/model"
+)
+
+const (
+	hostDashboardPeriodDays   = 30
+	hostDashboardUpcomingDays = 7
+)
+
+// GetHostDashboard собирает кабинет хоста за четыре запроса независимо от числа объявлений
+func (s *Service) GetHostDashboard(ctx context.Context, hostID int) (*model.HostDashboard, error) {
+	if _, err := s.repo.GetUserByID(ctx, hostID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	periodStart := now.AddDate(0, 0, -hostDashboardPeriodDays)
+
+	dashboard, err := s.repo.GetHostDashboardSummary(ctx, hostID, periodStart)
+	if err != nil {
+		return nil, err
+	}
+	dashboard.HostID = hostID
+	dashboard.PeriodDays = hostDashboardPeriodDays
+
+	listings, err := s.repo.GetHostDashboardListings(ctx, hostID, periodStart, now)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range listings {
+		if listings[i].AvailableNights > 0 {
+			listings[i].OccupancyRate = float64(listings[i].BookedNights) / float64(listings[i].AvailableNights)
+		}
+		dashboard.BookedNights += listings[i].BookedNights
+		dashboard.AvailableNights += listings[i].AvailableNights
+	}
+	if dashboard.AvailableNights > 0 {
+		dashboard.OccupancyRate = float64(dashboard.BookedNights) / float64(dashboard.AvailableNights)
+	}
+	dashboard.Listings = listings
+
+	upcomingEnd := now.AddDate(0, 0, hostDashboardUpcomingDays)
+	movements, err := s.repo.GetHostUpcomingMovements(ctx, hostID, now, upcomingEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard.UpcomingCheckIns = []model.HostDashboardMovement{}
+	dashboard.UpcomingCheckOuts = []model.HostDashboardMovement{}
+	for _, movement := range movements {
+		if !movement.InDate.Before(now) && movement.InDate.Before(upcomingEnd) {
+			dashboard.UpcomingCheckIns = append(dashboard.UpcomingCheckIns, movement)
+		}
+		if !movement.OutDate.Before(now) && movement.OutDate.Before(upcomingEnd) {
+			dashboard.UpcomingCheckOuts = append(dashboard.UpcomingCheckOuts, movement)
+		}
+	}
+	slices.SortStableFunc(dashboard.UpcomingCheckOuts, func(a, b model.HostDashboardMovement) int {
+		return a.OutDate.Compare(b.OutDate)
+	})
+
+	return dashboard, nil
+}
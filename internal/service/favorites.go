@@ -18,7 +18,22 @@ func (s *Service) CreateFavorite(ctx context.Context, favorite *model.Favorite)
 		return err
 	}
 
-	existing, err := s.repo.GetFavoriteByUserAndListing(ctx, favorite.UserID, favorite.ListingID)
+	var wishlist *model.Wishlist
+	if favorite.WishlistID == 0 {
+		wishlist, err = s.repo.GetOrCreateDefaultWishlist(ctx, favorite.UserID)
+	} else {
+		wishlist, err = s.repo.GetWishlistByID(ctx, favorite.WishlistID)
+	}
+	if err != nil {
+		return err
+	}
+
+	if wishlist.UserID != favorite.UserID {
+		return fmt.Errorf("wishlist belongs to another user")
+	}
+	favorite.WishlistID = wishlist.ID
+
+	existing, err := s.repo.GetFavoriteByWishlistAndListing(ctx, favorite.WishlistID, favorite.ListingID)
 	if err != nil {
 		return err
 	}
@@ -34,8 +49,19 @@ func (s *Service) GetFavoriteByID(ctx context.Context, id int) (*model.Favorite,
 	return s.repo.GetFavoriteByID(ctx, id)
 }
 
-func (s *Service) GetFavoritesByUserID(ctx context.Context, userID int) ([]model.Favorite, error) {
-	return s.repo.GetFavoritesByUserID(ctx, userID)
+func (s *Service) GetFavoritesByUserID(ctx context.Context, userID int, withCards bool) ([]model.Favorite, error) {
+	favorites, err := s.repo.GetFavoritesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if withCards {
+		if err := s.attachListingCards(ctx, favorites); err != nil {
+			return nil, err
+		}
+	}
+
+	return favorites, nil
 }
 
 func (s *Service) DeleteFavorite(ctx context.Context, id int) error {
@@ -49,3 +75,26 @@ func (s *Service) DeleteFavoriteByUserAndListing(ctx context.Context, userID int
 func (s *Service) CreateFavorites(ctx context.Context, favorites []model.Favorite) error {
 	return s.repo.CreateFavorites(ctx, favorites)
 }
+
+func (s *Service) attachListingCards(ctx context.Context, favorites []model.Favorite) error {
+	listingIDs := make([]int, 0, len(favorites))
+	for _, favorite := range favorites {
+		listingIDs = append(listingIDs, favorite.ListingID)
+	}
+
+	cards, err := s.repo.GetListingCards(ctx, listingIDs)
+	if err != nil {
+		return err
+	}
+
+	cardsByID := make(map[int]*model.ListingCard, len(cards))
+	for i := range cards {
+		cardsByID[cards[i].ListingID] = &cards[i]
+	}
+
+	for i := range favorites {
+		favorites[i].Listing = cardsByID[favorites[i].ListingID]
+	}
+
+	return nil
+}
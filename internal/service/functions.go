@@ -27,6 +27,10 @@ func (s *Service) GetListingsStatisticsReport(ctx context.Context) ([]model.List
 	return s.repo.GetListingsStatisticsReport(ctx)
 }
 
+func (s *Service) GetCitiesStatisticsReport(ctx context.Context, country *string) ([]model.CityStatisticsReport, error) {
+	return s.repo.GetCitiesStatisticsReport(ctx, country)
+}
+
 func (s *Service) GetHostsPerformanceReport(ctx context.Context) ([]model.HostPerformanceReport, error) {
 	return s.repo.GetHostsPerformanceReport(ctx)
 }
@@ -38,3 +42,27 @@ func (s *Service) GetBookingsReport(ctx context.Context, startDate, endDate *tim
 func (s *Service) GetPaymentsSummaryReport(ctx context.Context, startDate, endDate *time.Time) ([]model.PaymentSummaryReport, error) {
 	return s.repo.GetPaymentsSummaryReport(ctx, startDate, endDate)
 }
+
+func (s *Service) GetReportStaleAsOf(ctx context.Context, viewName string) (time.Time, error) {
+	return s.repo.GetReportRefreshedAt(ctx, viewName)
+}
+
+func (s *Service) StreamListingsStatisticsReport(ctx context.Context, fn func(model.ListingStatisticsReport) error) error {
+	return s.repo.StreamListingsStatisticsReport(ctx, fn)
+}
+
+func (s *Service) StreamHostsPerformanceReport(ctx context.Context, fn func(model.HostPerformanceReport) error) error {
+	return s.repo.StreamHostsPerformanceReport(ctx, fn)
+}
+
+func (s *Service) StreamBookingsReport(ctx context.Context, startDate, endDate *time.Time, fn func(model.BookingReport) error) error {
+	return s.repo.StreamBookingsReport(ctx, startDate, endDate, fn)
+}
+
+func (s *Service) StreamCitiesStatisticsReport(ctx context.Context, country *string, fn func(model.CityStatisticsReport) error) error {
+	return s.repo.StreamCitiesStatisticsReport(ctx, country, fn)
+}
+
+func (s *Service) StreamPaymentsSummaryReport(ctx context.Context, startDate, endDate *time.Time, fn func(model.PaymentSummaryReport) error) error {
+	return s.repo.StreamPaymentsSummaryReport(ctx, startDate, endDate, fn)
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Rissochek/db-cw/internal/model"
+)
+
+func (s *Service) CreateGuestReview(ctx context.Context, review *model.GuestReview) error {
+	booking, err := s.repo.GetBookingByID(ctx, review.BookingID)
+	if err != nil {
+		return err
+	}
+
+	if booking.HostID != review.HostID {
+		return fmt.Errorf("only the listing host can review the guest")
+	}
+
+	if err := checkReviewWindow(booking, time.Now(), s.reviewWindowDays); err != nil {
+		return err
+	}
+
+	if review.Score < 1 || review.Score > 5 {
+		return fmt.Errorf("invalid review score: must be between 1 and 5")
+	}
+
+	if flagged, reason := s.reviewFilter.Check(review.Text); flagged {
+		return fmt.Errorf("review rejected by moderation: %s", reason)
+	}
+
+	existing, err := s.repo.GetGuestReviewByBookingID(ctx, review.BookingID)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		return fmt.Errorf("guest review already exists")
+	}
+
+	review.GuestID = booking.GuestID
+	if err := s.repo.CreateGuestReview(ctx, review); err != nil {
+		return err
+	}
+
+	review.Revealed, err = s.repo.IsBookingReviewsRevealed(ctx, review.BookingID, s.reviewWindowDays)
+	return err
+}
+
+func (s *Service) GetGuestReviewByID(ctx context.Context, id int) (*model.GuestReview, error) {
+	review, err := s.repo.GetGuestReviewByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	review.Revealed, err = s.repo.IsBookingReviewsRevealed(ctx, review.BookingID, s.reviewWindowDays)
+	if err != nil {
+		return nil, err
+	}
+
+	if !review.Revealed {
+		review.Text = ""
+		review.Score = 0
+	}
+
+	return review, nil
+}
+
+func (s *Service) DeleteGuestReview(ctx context.Context, id int) error {
+	return s.repo.DeleteGuestReview(ctx, id, s.reviewWindowDays)
+}
+
+func (s *Service) GetGuestReputation(ctx context.Context, guestID int) (*model.GuestReputation, error) {
+	if _, err := s.repo.GetUserByID(ctx, guestID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetGuestReputation(ctx, guestID, s.reviewWindowDays)
+}
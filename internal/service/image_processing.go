@@ -0,0 +1,156 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Rissochek/db-cw/internal/imaging"
+	"github.com/Rissochek/db-cw/internal/model"
+	"go.uber.org/zap"
+)
+
+var (
+	imageWorkerInterval = 30 * time.Second
+	// сколько изображение может числиться в обработке, прежде чем его заберет другой воркер
+	imageProcessingLease = 10 * time.Minute
+	// файлы удаленных изображений, которые хранилище не удалило сразу, повторяются не чаще интервала
+	orphanedBlobRetryInterval = 5 * time.Minute
+	orphanedBlobSweepBatch    = 100
+	imageVariantSpecs         = []imaging.VariantSpec{
+		{Name: model.ImageVariantThumbnail, MaxSize: 320},
+		{Name: model.ImageVariantMedium, MaxSize: 1024},
+		{Name: model.ImageVariantLarge, MaxSize: 2048},
+	}
+)
+
+// RunImageWorker обрабатывает загруженные изображения до отмены контекста.
+// Новые загрузки будят воркер сразу, таймер подбирает зависшие после падения других воркеров.
+func (s *Service) RunImageWorker(ctx context.Context) {
+	ticker := time.NewTicker(imageWorkerInterval)
+	defer ticker.Stop()
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		processed, err := s.processNextImage(ctx)
+		if err != nil {
+			zap.S().Errorf("image worker: %v", err)
+		}
+		if processed {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.imageJobs:
+		case <-ticker.C:
+			if err := s.repo.ResetStaleImageProcessing(ctx, imageProcessingLease); err != nil {
+				zap.S().Errorf("image worker: %v", err)
+			}
+			if err := s.SweepOrphanedBlobs(ctx); err != nil {
+				zap.S().Errorf("image worker: %v", err)
+			}
+		}
+	}
+}
+
+func (s *Service) notifyImageWorker() {
+	select {
+	case s.imageJobs <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Service) processNextImage(ctx context.Context) (bool, error) {
+	image, err := s.repo.ClaimPendingImage(ctx)
+	if err != nil || image == nil {
+		return false, err
+	}
+
+	status := model.ImageProcessingReady
+	var processingError *string
+	if err := s.generateImageVariants(ctx, image); err != nil {
+		// при остановке изображение остается в обработке и вернется в очередь по истечении lease
+		if ctx.Err() != nil {
+			return false, nil
+		}
+		zap.S().Errorf("failed to process image %d: %v", image.ImageID, err)
+		status = model.ImageProcessingFailed
+		message := err.Error()
+		processingError = &message
+	}
+
+	if err := s.repo.UpdateImageProcessingStatus(ctx, image.ImageID, status, processingError); err != nil {
+		return true, err
+	}
+
+	return true, nil
+}
+
+func (s *Service) generateImageVariants(ctx context.Context, image *model.Image) error {
+	if image.StorageKey == nil {
+		return fmt.Errorf("image has no stored original")
+	}
+
+	reader, err := s.blobStore.Get(ctx, *image.StorageKey)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		return fmt.Errorf("failed to read original: %w", err)
+	}
+
+	generated, err := imaging.GenerateVariants(data, imageVariantSpecs)
+	if err != nil {
+		if errors.Is(err, imaging.ErrUnsupportedFormat) {
+			return fmt.Errorf("variants are not supported for %s", stringValue(image.ContentType))
+		}
+		return err
+	}
+
+	variants := make([]model.ImageVariant, 0, len(generated))
+	for _, g := range generated {
+		key := imageVariantKey(*image.StorageKey, g.Name)
+		url, err := s.blobStore.Put(ctx, key, g.ContentType, int64(len(g.Data)), bytes.NewReader(g.Data))
+		if err != nil {
+			return err
+		}
+
+		variants = append(variants, model.ImageVariant{
+			ImageID:     image.ImageID,
+			Variant:     g.Name,
+			StorageKey:  key,
+			ImageURL:    url,
+			ContentType: g.ContentType,
+			Width:       g.Width,
+			Height:      g.Height,
+			SizeBytes:   int64(len(g.Data)),
+		})
+	}
+
+	return s.repo.SaveImageVariants(ctx, variants)
+}
+
+// imageVariantKey кладет варианты рядом с оригиналом: listings/1/abc.png -> listings/1/abc_thumbnail.jpg
+func imageVariantKey(originalKey string, variant string) string {
+	base := strings.TrimSuffix(originalKey, path.Ext(originalKey))
+	return base + "_" + variant + ".jpg"
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -1,10 +1,27 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
 	"time"
 
+	"github.com/Rissochek/db-cw/internal/imaging"
 	"github.com/Rissochek/db-cw/internal/model"
+	"go.uber.org/zap"
+)
+
+var (
+	maxImageSize      = int64(10 << 20)
+	allowedImageTypes = map[string]string{
+		"image/jpeg": ".jpg",
+		"image/png":  ".png",
+		"image/webp": ".webp",
+	}
 )
 
 func (s *Service) CreateImage(ctx context.Context, image *model.Image) error {
@@ -20,12 +37,109 @@ func (s *Service) CreateImage(ctx context.Context, image *model.Image) error {
 	return s.repo.CreateImage(ctx, image)
 }
 
+func (s *Service) UploadImage(ctx context.Context, image *model.Image, size int64, body io.Reader) error {
+	_, err := s.repo.GetListingByID(ctx, image.ListingID)
+	if err != nil {
+		return err
+	}
+
+	if size <= 0 {
+		return fmt.Errorf("invalid image: file is empty")
+	}
+
+	if size > maxImageSize {
+		return fmt.Errorf("invalid image: file exceeds %d bytes", maxImageSize)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, maxImageSize+1))
+	if err != nil {
+		zap.S().Errorf("failed to read uploaded image: %v", err)
+		return fmt.Errorf("failed to read uploaded image")
+	}
+
+	if int64(len(data)) > maxImageSize {
+		return fmt.Errorf("invalid image: file exceeds %d bytes", maxImageSize)
+	}
+
+	// тип определяем по содержимому файла, а не по заголовку клиента
+	contentType := http.DetectContentType(data)
+	ext, ok := allowedImageTypes[contentType]
+	if !ok {
+		return fmt.Errorf("invalid image: unsupported content type %s", contentType)
+	}
+
+	// оригинал публичный, поэтому exif с координатами убираем до сохранения
+	data, err = imaging.StripMetadata(contentType, data)
+	if err != nil {
+		return fmt.Errorf("invalid image: %v", err)
+	}
+
+	key, err := newImageStorageKey(image.ListingID, ext)
+	if err != nil {
+		return err
+	}
+
+	storedSize := int64(len(data))
+	url, err := s.blobStore.Put(ctx, key, contentType, storedSize, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	status := model.ImageProcessingPending
+	image.ImageURL = url
+	image.StorageKey = &key
+	image.ContentType = &contentType
+	image.SizeBytes = &storedSize
+	image.ProcessingStatus = &status
+	image.UploadedAt = time.Now()
+
+	if err := s.repo.CreateImage(ctx, image); err != nil {
+		if delErr := s.blobStore.Delete(ctx, key); delErr != nil {
+			zap.S().Errorf("failed to clean up stored object %v: %v", key, delErr)
+		}
+		return err
+	}
+
+	s.notifyImageWorker()
+
+	return nil
+}
+
 func (s *Service) GetImageByID(ctx context.Context, imageID int) (*model.Image, error) {
-	return s.repo.GetImageByID(ctx, imageID)
+	image, err := s.repo.GetImageByID(ctx, imageID)
+	if err != nil {
+		return nil, err
+	}
+
+	image.Variants, err = s.repo.GetImageVariantsByImageID(ctx, imageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return image, nil
 }
 
 func (s *Service) GetImagesByListingID(ctx context.Context, listingID int) ([]model.Image, error) {
-	return s.repo.GetImagesByListingID(ctx, listingID)
+	images, err := s.repo.GetImagesByListingID(ctx, listingID)
+	if err != nil {
+		return nil, err
+	}
+
+	variants, err := s.repo.GetImageVariantsByListingID(ctx, listingID)
+	if err != nil {
+		return nil, err
+	}
+
+	variantsByImage := make(map[int][]model.ImageVariant, len(images))
+	for _, variant := range variants {
+		variantsByImage[variant.ImageID] = append(variantsByImage[variant.ImageID], variant)
+	}
+
+	for i := range images {
+		images[i].Variants = variantsByImage[images[i].ImageID]
+	}
+
+	return images, nil
 }
 
 func (s *Service) UpdateImage(ctx context.Context, image *model.Image) error {
@@ -39,13 +153,92 @@ func (s *Service) UpdateImage(ctx context.Context, image *model.Image) error {
 		image.UploadedAt = dbImage.UploadedAt
 	}
 
+	if dbImage.StorageKey != nil {
+		image.ImageURL = dbImage.ImageURL
+	}
+
 	return s.repo.UpdateImage(ctx, image)
 }
 
 func (s *Service) DeleteImage(ctx context.Context, imageID int) error {
-	return s.repo.DeleteImage(ctx, imageID)
+	image, err := s.repo.GetImageByID(ctx, imageID)
+	if err != nil {
+		return err
+	}
+
+	variants, err := s.repo.GetImageVariantsByImageID(ctx, imageID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteImage(ctx, imageID); err != nil {
+		return err
+	}
+
+	// строка уже удалена, а ключи файлов сохранены в orphaned_blobs той же транзакцией,
+	// поэтому ошибка хранилища не делает удаление неуспешным: файл дочистит воркер изображений
+	keys := make([]string, 0, len(variants)+1)
+	for _, variant := range variants {
+		keys = append(keys, variant.StorageKey)
+	}
+	if image.StorageKey != nil {
+		keys = append(keys, *image.StorageKey)
+	}
+
+	s.deleteOrphanedBlobs(ctx, keys)
+	return nil
+}
+
+// SweepOrphanedBlobs повторяет удаление файлов, которые не удалось удалить вместе с изображением
+func (s *Service) SweepOrphanedBlobs(ctx context.Context) error {
+	keys, err := s.repo.GetOrphanedBlobs(ctx, orphanedBlobRetryInterval, orphanedBlobSweepBatch)
+	if err != nil {
+		return err
+	}
+
+	s.deleteOrphanedBlobs(ctx, keys)
+	return nil
+}
+
+func (s *Service) deleteOrphanedBlobs(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := s.blobStore.Delete(ctx, key); err != nil {
+			zap.S().Warnf("failed to delete orphaned blob %s, will retry: %v", key, err)
+			if err := s.repo.MarkOrphanedBlobFailed(ctx, key, err.Error()); err != nil {
+				zap.S().Errorf("failed to record orphaned blob %s: %v", key, err)
+			}
+			continue
+		}
+
+		if err := s.repo.DeleteOrphanedBlob(ctx, key); err != nil {
+			zap.S().Errorf("failed to forget deleted blob %s: %v", key, err)
+		}
+	}
+}
+
+func (s *Service) ReorderImages(ctx context.Context, listingID int, imageIDs []int) ([]model.Image, error) {
+	_, err := s.repo.GetListingByID(ctx, listingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.ReorderImages(ctx, listingID, imageIDs); err != nil {
+		return nil, err
+	}
+
+	return s.GetImagesByListingID(ctx, listingID)
 }
 
 func (s *Service) CreateImages(ctx context.Context, images []model.Image) error {
 	return s.repo.CreateImages(ctx, images)
 }
+
+func newImageStorageKey(listingID int, ext string) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		zap.S().Errorf("failed to generate storage key: %v", err)
+		return "", fmt.Errorf("failed to generate storage key")
+	}
+
+	return fmt.Sprintf("listings/%d/%s%s", listingID, hex.EncodeToString(buf), ext), nil
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Rissochek/db-cw/internal/model"
+)
+
+var (
+	defaultSearchRadiusKm = 10.0
+	maxSearchRadiusKm     = 500.0
+	maxSearchQueryLength  = 200
+)
+
+func (s *Service) SearchListings(ctx context.Context, filter model.ListingsSearchFilter, locale string) (*model.ListingsSearchResult, error) {
+	if err := validateListingsSearchFilter(&filter); err != nil {
+		return nil, err
+	}
+
+	listings, err := s.repo.SearchListings(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	total, err := s.repo.CountListings(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	facets, err := s.repo.GetAmenityFacets(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range facets {
+		facets[i].Label = facets[i].Labels.Resolve(locale, facets[i].Name)
+	}
+
+	return &model.ListingsSearchResult{
+		Listings:      listings,
+		Total:         total,
+		AmenityFacets: facets,
+	}, nil
+}
+
+// GetListings возвращает страницу объявлений без фасетов; с lat/lng - ближайшие в радиусе, по возрастанию расстояния
+func (s *Service) GetListings(ctx context.Context, filter model.ListingsSearchFilter) ([]model.Listing, error) {
+	if err := validateListingsSearchFilter(&filter); err != nil {
+		return nil, err
+	}
+
+	return s.repo.SearchListings(ctx, filter)
+}
+
+func validateListingsSearchFilter(filter *model.ListingsSearchFilter) error {
+	if filter.Query != nil && len([]rune(*filter.Query)) > maxSearchQueryLength {
+		return fmt.Errorf("invalid search filter: q is longer than %d characters", maxSearchQueryLength)
+	}
+
+	if filter.MinPrice != nil && filter.MaxPrice != nil && *filter.MinPrice > *filter.MaxPrice {
+		return fmt.Errorf("invalid search filter: min_price is greater than max_price")
+	}
+
+	if (filter.Latitude == nil) != (filter.Longitude == nil) {
+		return fmt.Errorf("invalid search filter: lat and lng must be set together")
+	}
+
+	if filter.Latitude != nil {
+		if *filter.Latitude < -90 || *filter.Latitude > 90 || *filter.Longitude < -180 || *filter.Longitude > 180 {
+			return fmt.Errorf("invalid search filter: coordinates are out of range")
+		}
+		if filter.RadiusKm == nil {
+			radiusKm := defaultSearchRadiusKm
+			filter.RadiusKm = &radiusKm
+		}
+	}
+
+	if filter.RadiusKm != nil {
+		if filter.Latitude == nil {
+			return fmt.Errorf("invalid search filter: radius_km requires lat and lng")
+		}
+		if *filter.RadiusKm <= 0 || *filter.RadiusKm > maxSearchRadiusKm {
+			return fmt.Errorf("invalid search filter: radius_km must be between 0 and %.0f", maxSearchRadiusKm)
+		}
+	}
+
+	if bounds := filter.Bounds; bounds != nil {
+		for _, lat := range []float64{bounds.MinLatitude, bounds.MaxLatitude} {
+			if lat < -90 || lat > 90 {
+				return fmt.Errorf("invalid search filter: bounding box is out of range")
+			}
+		}
+		for _, lng := range []float64{bounds.MinLongitude, bounds.MaxLongitude} {
+			if lng < -180 || lng > 180 {
+				return fmt.Errorf("invalid search filter: bounding box is out of range")
+			}
+		}
+		// min_lng > max_lng допустим: это область через 180-й меридиан
+		if bounds.MinLatitude > bounds.MaxLatitude {
+			return fmt.Errorf("invalid search filter: bounding box minimum latitude is greater than maximum")
+		}
+	}
+
+	return nil
+}
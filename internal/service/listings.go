@@ -2,12 +2,23 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/Rissochek/db-cw/internal/model"
+	"go.uber.org/zap"
 )
 
+var geocodeBackfillBatch = 500
+
 func (s *Service) CreateListing(ctx context.Context, listing *model.Listing) error {
 	listing.IsAvailable = true
+	normalizeAddress(listing)
+
+	if err := validateCoordinates(listing.Latitude, listing.Longitude); err != nil {
+		return err
+	}
+	s.geocodeListing(ctx, listing)
 
 	return s.repo.CreateListing(ctx, listing)
 }
@@ -24,8 +35,23 @@ func (s *Service) UpdateListing(ctx context.Context, listing *model.Listing) err
 
 	listing.HostID = dbListing.HostID
 	listing.Address = dbListing.Address
+	listing.Street, listing.City, listing.Region = dbListing.Street, dbListing.City, dbListing.Region
+	listing.PostalCode, listing.Country = dbListing.PostalCode, dbListing.Country
+
+	if err := validateCoordinates(listing.Latitude, listing.Longitude); err != nil {
+		return err
+	}
+	if listing.Latitude == nil {
+		listing.Latitude, listing.Longitude = dbListing.Latitude, dbListing.Longitude
+	}
 	
-	return s.repo.UpdateListing(ctx, listing)
+	if err := s.repo.UpdateListing(ctx, listing); err != nil {
+		return err
+	}
+
+	s.notifyListingChanges(ctx, dbListing, listing)
+
+	return nil
 }
 
 func (s *Service) DeleteListing(ctx context.Context, id int) error {
@@ -33,5 +59,120 @@ func (s *Service) DeleteListing(ctx context.Context, id int) error {
 }
 
 func (s *Service) CreateListings(ctx context.Context, listings []model.Listing) error {
+	for i := range listings {
+		normalizeAddress(&listings[i])
+		if err := validateCoordinates(listings[i].Latitude, listings[i].Longitude); err != nil {
+			return fmt.Errorf("%w at index %d", err, i)
+		}
+		s.geocodeListing(ctx, &listings[i])
+	}
+
 	return s.repo.CreateListings(ctx, listings)
+}
+
+// geocodeListing проставляет координаты по адресу, если их не передали; адрес, который не удалось
+// распознать, не мешает сохранить объявление
+func (s *Service) geocodeListing(ctx context.Context, listing *model.Listing) {
+	if listing.Latitude != nil {
+		return
+	}
+
+	latitude, longitude, err := s.geocoder.Geocode(ctx, listing.Address)
+	if err != nil {
+		zap.S().Warnf("failed to geocode address %q: %v", listing.Address, err)
+		return
+	}
+
+	listing.Latitude, listing.Longitude = &latitude, &longitude
+}
+
+// BackfillListingCoordinates геокодирует объявления, созданные до появления координат,
+// чтобы они находились поиском по радиусу и по области карты
+func (s *Service) BackfillListingCoordinates(ctx context.Context) {
+	afterID, located, missed := 0, 0, 0
+	for {
+		listings, err := s.repo.GetListingsWithoutCoordinates(ctx, afterID, geocodeBackfillBatch)
+		if err != nil {
+			zap.S().Errorf("coordinates backfill: %v", err)
+			return
+		}
+		if len(listings) == 0 {
+			break
+		}
+
+		for _, listing := range listings {
+			afterID = listing.ID
+
+			latitude, longitude, err := s.geocoder.Geocode(ctx, listing.Address)
+			if err != nil {
+				missed++
+				continue
+			}
+
+			if err := s.repo.UpdateListingCoordinates(ctx, listing.ID, latitude, longitude); err != nil {
+				zap.S().Errorf("coordinates backfill: %v", err)
+				return
+			}
+			located++
+		}
+	}
+
+	if located > 0 || missed > 0 {
+		zap.S().Infof("coordinates backfill: located %d listings, %d addresses not recognized", located, missed)
+	}
+}
+
+// normalizeAddress убирает пустые части адреса и собирает address из частей, если его не передали;
+// если частей нет, их разберет из address триггер в БД
+func normalizeAddress(listing *model.Listing) {
+	listing.Street = trimOptional(listing.Street)
+	listing.City = trimOptional(listing.City)
+	listing.Region = trimOptional(listing.Region)
+	listing.PostalCode = trimOptional(listing.PostalCode)
+	listing.Country = trimOptional(listing.Country)
+
+	listing.Address = strings.TrimSpace(listing.Address)
+	if listing.Address != "" {
+		return
+	}
+
+	// тот же порядок "улица, город, регион индекс, страна", который понимает разбор в БД
+	regionPart := strings.TrimSpace(stringValue(listing.Region) + " " + stringValue(listing.PostalCode))
+	var addressParts []string
+	for _, part := range []string{stringValue(listing.Street), stringValue(listing.City), regionPart, stringValue(listing.Country)} {
+		if part != "" {
+			addressParts = append(addressParts, part)
+		}
+	}
+	listing.Address = strings.Join(addressParts, ", ")
+}
+
+func trimOptional(value *string) *string {
+	if value == nil {
+		return nil
+	}
+
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+func validateCoordinates(latitude, longitude *float64) error {
+	if (latitude == nil) != (longitude == nil) {
+		return fmt.Errorf("invalid listing: latitude and longitude must be set together")
+	}
+	if latitude == nil {
+		return nil
+	}
+
+	if *latitude < -90 || *latitude > 90 {
+		return fmt.Errorf("invalid listing: latitude must be between -90 and 90")
+	}
+	if *longitude < -180 || *longitude > 180 {
+		return fmt.Errorf("invalid listing: longitude must be between -180 and 180")
+	}
+
+	return nil
 }
\ No newline at end of file
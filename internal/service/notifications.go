@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"go.uber.org/zap"
+)
+
+var (
+	notificationDeliveryTimeout = time.Minute
+	notificationRetryInterval   = time.Minute
+	notificationBatch           = 100
+	// после стольких неудачных попыток уведомление остается недоставленным
+	notificationMaxAttempts = 8
+	notificationMaxBackoff  = 6 * time.Hour
+)
+
+// notifyListingChanges сравнивает объявление до и после обновления и оповещает тех, у кого оно в избранном
+func (s *Service) notifyListingChanges(ctx context.Context, before *model.Listing, after *model.Listing) {
+	var changes []model.Notification
+
+	if after.PricePerNight < before.PricePerNight {
+		oldPrice, newPrice := before.PricePerNight, after.PricePerNight
+		changes = append(changes, model.Notification{
+			ListingID: after.ID,
+			Type:      model.NotificationTypePriceDrop,
+			Message: fmt.Sprintf("Цена на жилье по адресу %s снизилась с %.2f до %.2f за ночь",
+				after.Address, oldPrice, newPrice),
+			OldPrice: &oldPrice,
+			NewPrice: &newPrice,
+		})
+	}
+
+	if !before.IsAvailable && after.IsAvailable {
+		changes = append(changes, model.Notification{
+			ListingID: after.ID,
+			Type:      model.NotificationTypeAvailability,
+			Message:   fmt.Sprintf("Жилье по адресу %s снова доступно для бронирования", after.Address),
+		})
+	}
+
+	for _, change := range changes {
+		notifications, err := s.repo.CreateListingNotifications(ctx, change)
+		if err != nil {
+			// обновление объявления уже прошло, поэтому ошибка уведомлений только логируется
+			zap.S().Errorf("failed to notify about listing %d: %v", after.ID, err)
+			continue
+		}
+
+		if len(notifications) > 0 {
+			s.notifyNotificationWorker()
+		}
+	}
+}
+
+func (s *Service) notifyNotificationWorker() {
+	select {
+	case s.notificationJobs <- struct{}{}:
+	default:
+	}
+}
+
+// RunNotificationWorker доставляет уведомления до отмены контекста. Новые уведомления будят воркер сразу,
+// неудачные он повторяет по таймеру с нарастающей задержкой.
+func (s *Service) RunNotificationWorker(ctx context.Context) {
+	ticker := time.NewTicker(notificationRetryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.notificationJobs:
+		case <-ticker.C:
+		}
+
+		for ctx.Err() == nil {
+			notifications, err := s.repo.ClaimUndeliveredNotifications(ctx, notificationMaxAttempts,
+				notificationDeliveryTimeout, notificationBatch)
+			if err != nil {
+				zap.S().Errorf("notification worker: %v", err)
+				break
+			}
+
+			// при остановке невзятые уведомления вернутся в очередь по истечении lease
+			for i := 0; i < len(notifications) && ctx.Err() == nil; i++ {
+				s.deliverNotification(ctx, &notifications[i])
+			}
+
+			if len(notifications) < notificationBatch {
+				break
+			}
+		}
+	}
+}
+
+func (s *Service) deliverNotification(ctx context.Context, notification *model.Notification) {
+	user, err := s.repo.GetUserByID(ctx, notification.UserID)
+	if err == nil {
+		err = s.notificationChannel.Deliver(ctx, user, notification)
+	}
+
+	if err != nil {
+		zap.S().Errorf("failed to deliver notification %d: %v", notification.ID, err)
+		retryAfter := notificationBackoff(notification.DeliveryAttempts)
+		if err := s.repo.MarkNotificationDeliveryFailed(ctx, notification.ID, err.Error(), retryAfter); err != nil {
+			zap.S().Errorf("failed to schedule notification %d retry: %v", notification.ID, err)
+		}
+		return
+	}
+
+	if err := s.repo.MarkNotificationDelivered(ctx, notification.ID); err != nil {
+		zap.S().Errorf("failed to deliver notification %d: %v", notification.ID, err)
+	}
+}
+
+// notificationBackoff: 1, 2, 4 ... минуты после каждой неудачи, но не больше notificationMaxBackoff
+func notificationBackoff(attempts int) time.Duration {
+	backoff := notificationRetryInterval << min(attempts, 16)
+	return min(backoff, notificationMaxBackoff)
+}
+
+func (s *Service) GetUserNotifications(ctx context.Context, userID int, unreadOnly bool, limit, offset int) ([]model.Notification, error) {
+	_, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetNotificationsByUserID(ctx, userID, unreadOnly, limit, offset)
+}
+
+func (s *Service) MarkNotificationRead(ctx context.Context, id int) error {
+	return s.repo.MarkNotificationRead(ctx, id)
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Rissochek/db-cw/internal/model"
+)
+
+var (
+	defaultOccupancyPeriod = 30 * 24 * time.Hour
+	maxOccupancyPeriod     = 3 * 366 * 24 * time.Hour
+)
+
+func (s *Service) GetListingsOccupancyReport(ctx context.Context, startDate, endDate *time.Time) ([]model.ListingOccupancyReport, error) {
+	start, end, err := occupancyPeriod(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetListingsOccupancyReport(ctx, start, end)
+}
+
+func (s *Service) GetHostsOccupancyReport(ctx context.Context, startDate, endDate *time.Time) ([]model.HostOccupancyReport, error) {
+	start, end, err := occupancyPeriod(startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetHostsOccupancyReport(ctx, start, end)
+}
+
+func (s *Service) StreamListingsOccupancyReport(ctx context.Context, startDate, endDate *time.Time, fn func(model.ListingOccupancyReport) error) error {
+	start, end, err := occupancyPeriod(startDate, endDate)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.StreamListingsOccupancyReport(ctx, start, end, fn)
+}
+
+func (s *Service) StreamHostsOccupancyReport(ctx context.Context, startDate, endDate *time.Time, fn func(model.HostOccupancyReport) error) error {
+	start, end, err := occupancyPeriod(startDate, endDate)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.StreamHostsOccupancyReport(ctx, start, end, fn)
+}
+
+// occupancyPeriod достраивает незаданные границы: без периода берутся последние 30 дней
+func occupancyPeriod(startDate, endDate *time.Time) (time.Time, time.Time, error) {
+	var start, end time.Time
+
+	switch {
+	case startDate != nil && endDate != nil:
+		start, end = *startDate, *endDate
+	case startDate != nil:
+		start = *startDate
+		end = start.Add(defaultOccupancyPeriod)
+	case endDate != nil:
+		end = *endDate
+		start = end.Add(-defaultOccupancyPeriod)
+	default:
+		end = time.Now()
+		start = end.Add(-defaultOccupancyPeriod)
+	}
+
+	if !start.Before(end) {
+		return start, end, fmt.Errorf("invalid report period: start_date must be before end_date")
+	}
+
+	if end.Sub(start) > maxOccupancyPeriod {
+		return start, end, fmt.Errorf("invalid report period: period is longer than %d days", int(maxOccupancyPeriod.Hours()/24))
+	}
+
+	return start, end, nil
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"go.uber.org/zap"
+)
+
+func (s *Service) ReconcilePayments(ctx context.Context, fix bool) (*model.PaymentReconciliationReport, error) {
+	discrepancies, err := s.repo.GetPaymentDiscrepancies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &model.PaymentReconciliationReport{
+		GeneratedAt:   time.Now(),
+		TotalCount:    len(discrepancies),
+		CountsByType:  make(map[string]int),
+		Discrepancies: discrepancies,
+	}
+
+	for i := range discrepancies {
+		d := &discrepancies[i]
+		report.CountsByType[d.Type]++
+		d.Fixable = isFixableDiscrepancy(d)
+
+		if !fix || !d.Fixable {
+			continue
+		}
+
+		if err := s.updateBookingIsPaidStatus(ctx, *d.BookingID); err != nil {
+			zap.S().Errorf("failed to fix discrepancy %s for booking %d: %v", d.Type, *d.BookingID, err)
+			continue
+		}
+		d.Fixed = true
+		report.FixedCount++
+	}
+
+	return report, nil
+}
+
+// автоматически исправляется только флаг is_paid, остальные расхождения требуют ручного разбора
+func isFixableDiscrepancy(d *model.PaymentDiscrepancy) bool {
+	if d.BookingID == nil {
+		return false
+	}
+
+	return d.Type == model.DiscrepancyPaidWithoutPayment || d.Type == model.DiscrepancyCompletedPaymentNotMarked
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"go.uber.org/zap"
+)
+
+// ручное обновление отчетов не запускается, если представления обновлялись недавно
+const reportsManualRefreshInterval = time.Minute
+
+// RunReportsRefresher обновляет материализованные представления отчетов сразу при старте
+// и затем с заданным интервалом до отмены контекста.
+func (s *Service) RunReportsRefresher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		s.reportsRefreshMu.Lock()
+		err := s.repo.RefreshReportViews(ctx)
+		s.reportsRefreshMu.Unlock()
+		if err != nil && ctx.Err() == nil {
+			zap.S().Errorf("reports refresher: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RefreshReports обновляет отчеты по запросу; одновременно идет не больше одного обновления
+func (s *Service) RefreshReports(ctx context.Context) error {
+	if !s.reportsRefreshMu.TryLock() {
+		return fmt.Errorf("reports refresh is already in progress")
+	}
+	defer s.reportsRefreshMu.Unlock()
+
+	recent := true
+	for _, view := range []string{model.ReportViewListingsStatistics, model.ReportViewHostsPerformance} {
+		refreshedAt, err := s.repo.GetReportRefreshedAt(ctx, view)
+		if err != nil {
+			return err
+		}
+		if time.Since(refreshedAt) >= reportsManualRefreshInterval {
+			recent = false
+		}
+	}
+	if recent {
+		return fmt.Errorf("reports were refreshed recently, try again later")
+	}
+
+	return s.repo.RefreshReportViews(ctx)
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Rissochek/db-cw/internal/model"
+)
+
+func (s *Service) GetListingReviews(ctx context.Context, listingID int, sort, cursor string, limit int) (*model.ReviewsPage, error) {
+	if _, err := s.repo.GetListingByID(ctx, listingID); err != nil {
+		return nil, err
+	}
+
+	return s.getReviewsPage(ctx, model.ReviewsFilter{ListingID: &listingID}, sort, cursor, limit)
+}
+
+func (s *Service) GetUserReviews(ctx context.Context, userID int, sort, cursor string, limit int) (*model.ReviewsPage, error) {
+	if _, err := s.repo.GetUserByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.getReviewsPage(ctx, model.ReviewsFilter{UserID: &userID}, sort, cursor, limit)
+}
+
+func (s *Service) getReviewsPage(ctx context.Context, filter model.ReviewsFilter, sort, cursor string, limit int) (*model.ReviewsPage, error) {
+	if sort == "" {
+		sort = model.ReviewsSortNewest
+	}
+	if sort != model.ReviewsSortNewest && sort != model.ReviewsSortScore {
+		return nil, fmt.Errorf("invalid sort: use newest or score")
+	}
+
+	filter.Sort = sort
+	filter.RevealAfterDays = s.reviewWindowDays
+
+	summary, err := s.repo.GetReviewsSummary(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor != "" {
+		filter.Cursor, err = decodeReviewCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница
+	filter.Limit = limit + 1
+	reviews, err := s.repo.GetReviewsPage(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &model.ReviewsPage{
+		Reviews: reviews,
+		Summary: *summary,
+	}
+
+	if len(reviews) > limit {
+		page.Reviews = reviews[:limit]
+		next := encodeReviewCursor(&page.Reviews[limit-1])
+		page.NextCursor = &next
+	}
+
+	for i := range page.Reviews {
+		page.Reviews[i].Revealed = true
+	}
+
+	return page, nil
+}
+
+func encodeReviewCursor(review *model.Review) string {
+	raw := fmt.Sprintf("%d:%d:%d", review.CreatedAt.UnixNano(), review.Score, review.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeReviewCursor(cursor string) (*model.ReviewCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	createdAt, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	score, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	id, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	return &model.ReviewCursor{
+		ID:        id,
+		Score:     score,
+		CreatedAt: time.Unix(0, createdAt),
+	}, nil
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var reviewRevealCheckInterval = time.Hour
+
+// RunReviewRevealRefresher учитывает в рейтингах объявлений отзывы, у которых истек срок слепого периода.
+// Первый проход пересчитывает все объявления, следующие - только те, чьи отзывы раскрылись с прошлого прохода
+func (s *Service) RunReviewRevealRefresher(ctx context.Context) {
+	ticker := time.NewTicker(reviewRevealCheckInterval)
+	defer ticker.Stop()
+
+	var since *time.Time
+	for {
+		startedAt := time.Now()
+		updated, err := s.repo.RefreshRevealedReviewsStats(ctx, since)
+		if err != nil {
+			zap.S().Errorf("review reveal refresher: %v", err)
+		} else {
+			// окна прохода перекрываются на интервал: пересчет идемпотентен, а расхождение часов приложения и БД не теряет отзывы
+			next := startedAt.Add(-reviewRevealCheckInterval)
+			since = &next
+			if updated > 0 {
+				zap.S().Infof("review reveal refresher: recalculated %d listings", updated)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -3,22 +3,57 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/Rissochek/db-cw/internal/model"
 )
 
+// LoadReviewSettings читает срок на отзыв из БД, где по нему же считаются рейтинги и раскрытие отзывов
+func (s *Service) LoadReviewSettings(ctx context.Context) error {
+	days, err := s.repo.GetReviewRevealAfterDays(ctx)
+	if err != nil {
+		return err
+	}
+
+	s.reviewWindowDays = days
+	return nil
+}
+
 func (s *Service) CreateReview(ctx context.Context, review *model.Review) error {
 	dbBooking, err := s.repo.GetBookingByID(ctx, review.BookingID)
 	if err != nil {
 		return err
 	}
 
+	if err := checkReviewWindow(dbBooking, time.Now(), s.reviewWindowDays); err != nil {
+		return err
+	}
+
+	if err := validateReviewScores(review); err != nil {
+		return err
+	}
+
 	review.UserID = dbBooking.GuestID
+	s.moderateReview(review)
 	return s.repo.CreateReview(ctx, review)
 }
 
 func (s *Service) GetReviewByID(ctx context.Context, id int) (*model.Review, error) {
-	return s.repo.GetReviewByID(ctx, id)
+	review, err := s.repo.GetReviewByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	review.Revealed, err = s.repo.IsBookingReviewsRevealed(ctx, review.BookingID, s.reviewWindowDays)
+	if err != nil {
+		return nil, err
+	}
+
+	if !review.Revealed {
+		hideReviewContent(review)
+	}
+
+	return review, nil
 }
 
 func (s *Service) UpdateReview(ctx context.Context, review *model.Review) error {
@@ -27,13 +62,38 @@ func (s *Service) UpdateReview(ctx context.Context, review *model.Review) error
 		return err
 	}
 
+	dbBooking, err := s.repo.GetBookingByID(ctx, dbReview.BookingID)
+	if err != nil {
+		return err
+	}
+
+	if err := checkReviewWindow(dbBooking, time.Now(), s.reviewWindowDays); err != nil {
+		return err
+	}
+
+	if err := validateReviewScores(review); err != nil {
+		return err
+	}
+
 	review.BookingID = dbReview.BookingID
 	review.UserID = dbReview.UserID
-	return s.repo.UpdateReview(ctx, review)
+	review.HostResponse = dbReview.HostResponse
+	review.HostResponseAt = dbReview.HostResponseAt
+	review.CreatedAt = dbReview.CreatedAt
+
+	if dbReview.Status == model.ReviewStatusHidden {
+		review.Status = dbReview.Status
+		review.FlagReason = dbReview.FlagReason
+	} else {
+		s.moderateReview(review)
+	}
+
+	return s.repo.UpdateReview(ctx, review, s.reviewWindowDays)
 }
 
+// после раскрытия отзывы обеих сторон заморожены, иначе слепой обмен можно обойти
 func (s *Service) DeleteReview(ctx context.Context, id int) error {
-	return s.repo.DeleteReview(ctx, id)
+	return s.repo.DeleteReview(ctx, id, s.reviewWindowDays)
 }
 
 func (s *Service) CreateReviews(ctx context.Context, reviews []model.Review) error {
@@ -62,8 +122,131 @@ func (s *Service) CreateReviews(ctx context.Context, reviews []model.Review) err
 		if !ok {
 			return fmt.Errorf("booking with id %d not found", reviews[i].BookingID)
 		}
+		if err := validateReviewScores(&reviews[i]); err != nil {
+			return err
+		}
 		reviews[i].UserID = booking.GuestID
+		s.moderateReview(&reviews[i])
 	}
 
 	return s.repo.CreateReviews(ctx, reviews)
 }
+
+func (s *Service) GetReviewsModerationQueue(ctx context.Context, status string, limit, offset int) ([]model.Review, error) {
+	if !isValidReviewStatus(status) {
+		return nil, fmt.Errorf("invalid review status")
+	}
+
+	return s.repo.GetReviewsByStatus(ctx, status, limit, offset)
+}
+
+func (s *Service) ModerateReview(ctx context.Context, id int, status string) error {
+	if !isValidReviewStatus(status) {
+		return fmt.Errorf("invalid review status")
+	}
+
+	return s.repo.UpdateReviewStatus(ctx, id, status)
+}
+
+func (s *Service) RespondToReview(ctx context.Context, id int, hostID int, response string) (*model.Review, error) {
+	if response == "" {
+		return nil, fmt.Errorf("response text must not be empty")
+	}
+
+	review, err := s.repo.GetReviewByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	booking, err := s.repo.GetBookingByID(ctx, review.BookingID)
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.HostID != hostID {
+		return nil, fmt.Errorf("only the listing host can respond to the review")
+	}
+
+	revealed, err := s.repo.IsBookingReviewsRevealed(ctx, review.BookingID, s.reviewWindowDays)
+	if err != nil {
+		return nil, err
+	}
+
+	if !revealed {
+		return nil, fmt.Errorf("review is not revealed yet")
+	}
+
+	if flagged, reason := s.reviewFilter.Check(response); flagged {
+		return nil, fmt.Errorf("response rejected by moderation: %s", reason)
+	}
+
+	if err := s.repo.UpdateReviewHostResponse(ctx, id, response); err != nil {
+		return nil, err
+	}
+
+	return s.GetReviewByID(ctx, id)
+}
+
+func (s *Service) moderateReview(review *model.Review) {
+	if flagged, reason := s.reviewFilter.Check(review.Text); flagged {
+		review.Status = model.ReviewStatusPending
+		review.FlagReason = &reason
+		return
+	}
+
+	review.Status = model.ReviewStatusPublished
+	review.FlagReason = nil
+}
+
+// до раскрытия вторая сторона видит только факт наличия отзыва
+func hideReviewContent(review *model.Review) {
+	review.Text = ""
+	review.Score = 0
+	review.Cleanliness = nil
+	review.Accuracy = nil
+	review.Location = nil
+	review.Value = nil
+	review.HostResponse = nil
+	review.HostResponseAt = nil
+}
+
+func checkReviewWindow(booking *model.Booking, now time.Time, windowDays int) error {
+	if now.Before(booking.OutDate) {
+		return fmt.Errorf("review window is not open until the stay is over")
+	}
+
+	if now.After(booking.OutDate.AddDate(0, 0, windowDays)) {
+		return fmt.Errorf("review window of %d days after check-out has expired", windowDays)
+	}
+
+	return nil
+}
+
+func validateReviewScores(review *model.Review) error {
+	if review.Score < 1 || review.Score > 5 {
+		return fmt.Errorf("invalid review score: must be between 1 and 5")
+	}
+
+	categories := map[string]*int{
+		"cleanliness_score": review.Cleanliness,
+		"accuracy_score":    review.Accuracy,
+		"location_score":    review.Location,
+		"value_score":       review.Value,
+	}
+	for name, score := range categories {
+		if score != nil && (*score < 1 || *score > 5) {
+			return fmt.Errorf("invalid review %s: must be between 1 and 5", name)
+		}
+	}
+
+	return nil
+}
+
+func isValidReviewStatus(status string) bool {
+	switch status {
+	case model.ReviewStatusPending, model.ReviewStatusPublished, model.ReviewStatusHidden:
+		return true
+	}
+
+	return false
+}
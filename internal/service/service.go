@@ -2,23 +2,58 @@ package service
 
 import (
 	"context"
+	"io"
+	"sync"
 	"time"
 
 	"github.com/Rissochek/db-cw/internal/model"
 )
 
 type Service struct {
-	faker Faker
-	repo  Repo
+	faker               Faker
+	repo                Repo
+	reviewFilter        ReviewFilter
+	blobStore           BlobStore
+	notificationChannel NotificationChannel
+	geocoder            Geocoder
+	imageJobs           chan struct{}
+	notificationJobs    chan struct{}
+	reportsRefreshMu    sync.Mutex
+	reviewWindowDays    int
 }
 
-func NewService(faker Faker, repo Repo) *Service {
+func NewService(faker Faker, repo Repo, reviewFilter ReviewFilter, blobStore BlobStore,
+	notificationChannel NotificationChannel, geocoder Geocoder) *Service {
 	return &Service{
-		faker: faker,
-		repo:  repo,
+		faker:               faker,
+		repo:                repo,
+		reviewFilter:        reviewFilter,
+		blobStore:           blobStore,
+		notificationChannel: notificationChannel,
+		geocoder:            geocoder,
+		imageJobs:           make(chan struct{}, 1),
+		notificationJobs:    make(chan struct{}, 1),
 	}
 }
 
+type ReviewFilter interface {
+	Check(text string) (flagged bool, reason string)
+}
+
+type NotificationChannel interface {
+	Deliver(ctx context.Context, user *model.User, notification *model.Notification) error
+}
+
+type Geocoder interface {
+	Geocode(ctx context.Context, address string) (latitude, longitude float64, err error)
+}
+
+type BlobStore interface {
+	Put(ctx context.Context, key string, contentType string, size int64, body io.Reader) (url string, err error)
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
 type Faker interface {
 	GenerateFakeUsers(toGen int) (users []model.User)
 	GenerateFakeListings(toGen int, users []model.User) (listings []model.Listing, listingsMap map[int][]model.Listing)
@@ -44,7 +79,12 @@ type Repo interface {
 
 	CreateListing(ctx context.Context, listing *model.Listing) error
 	GetListingByID(ctx context.Context, id int) (*model.Listing, error)
+	SearchListings(ctx context.Context, filter model.ListingsSearchFilter) ([]model.Listing, error)
+	CountListings(ctx context.Context, filter model.ListingsSearchFilter) (int, error)
+	GetAmenityFacets(ctx context.Context, filter model.ListingsSearchFilter) ([]model.AmenityFacet, error)
 	UpdateListing(ctx context.Context, listing *model.Listing) error
+	GetListingsWithoutCoordinates(ctx context.Context, afterID int, limit int) ([]model.Listing, error)
+	UpdateListingCoordinates(ctx context.Context, id int, latitude, longitude float64) error
 	DeleteListing(ctx context.Context, id int) error
 
 	CreateBooking(ctx context.Context, booking *model.Booking) error
@@ -56,12 +96,28 @@ type Repo interface {
 
 	CreateReview(ctx context.Context, review *model.Review) error
 	GetReviewByID(ctx context.Context, id int) (*model.Review, error)
-	UpdateReview(ctx context.Context, review *model.Review) error
-	DeleteReview(ctx context.Context, id int) error
+	UpdateReview(ctx context.Context, review *model.Review, revealAfterDays int) error
+	DeleteReview(ctx context.Context, id int, revealAfterDays int) error
+	GetReviewsByStatus(ctx context.Context, status string, limit, offset int) ([]model.Review, error)
+	UpdateReviewStatus(ctx context.Context, id int, status string) error
+	UpdateReviewHostResponse(ctx context.Context, id int, response string) error
+	GetReviewsPage(ctx context.Context, filter model.ReviewsFilter) ([]model.Review, error)
+	GetReviewsSummary(ctx context.Context, filter model.ReviewsFilter) (*model.ReviewsSummary, error)
+
+	CreateGuestReview(ctx context.Context, review *model.GuestReview) error
+	GetGuestReviewByID(ctx context.Context, id int) (*model.GuestReview, error)
+	GetGuestReviewByBookingID(ctx context.Context, bookingID int) (*model.GuestReview, error)
+	DeleteGuestReview(ctx context.Context, id int, revealAfterDays int) error
+	IsBookingReviewsRevealed(ctx context.Context, bookingID int, revealAfterDays int) (bool, error)
+	GetReviewRevealAfterDays(ctx context.Context) (int, error)
+	GetGuestReputation(ctx context.Context, guestID int, revealAfterDays int) (*model.GuestReputation, error)
+	RefreshRevealedReviewsStats(ctx context.Context, since *time.Time) (int, error)
 
 	CreateAmenity(ctx context.Context, amenity *model.Amenity) error
 	GetAmenityByID(ctx context.Context, id int) (*model.Amenity, error)
-	GetAllAmenities(ctx context.Context) ([]model.Amenity, error)
+	GetAllAmenities(ctx context.Context, category *string) ([]model.Amenity, error)
+	GetAmenityCategories(ctx context.Context) ([]model.AmenityCategory, error)
+	ReplaceListingAmenities(ctx context.Context, listingID int, amenityIDs []int) error
 	UpdateAmenity(ctx context.Context, amenity *model.Amenity) error
 	DeleteAmenity(ctx context.Context, id int) error
 	AddAmenityToListing(ctx context.Context, listingID int, amenityID int) error
@@ -71,12 +127,38 @@ type Repo interface {
 	CreateFavorite(ctx context.Context, favorite *model.Favorite) error
 	GetFavoriteByID(ctx context.Context, id int) (*model.Favorite, error)
 	GetFavoritesByUserID(ctx context.Context, userID int) ([]model.Favorite, error)
-	GetFavoriteByUserAndListing(ctx context.Context, userID int, listingID int) (*model.Favorite, error)
+	GetFavoriteByWishlistAndListing(ctx context.Context, wishlistID int, listingID int) (*model.Favorite, error)
 	DeleteFavorite(ctx context.Context, id int) error
 	DeleteFavoriteByUserAndListing(ctx context.Context, userID int, listingID int) error
 
 	CreateListingAmenities(ctx context.Context, listingAmenities []model.ListingAmenity) error
 	CreateFavorites(ctx context.Context, favorites []model.Favorite) error
+	CreateWishlist(ctx context.Context, wishlist *model.Wishlist) error
+	GetWishlistByID(ctx context.Context, id int) (*model.Wishlist, error)
+	GetWishlistByShareToken(ctx context.Context, token string) (*model.Wishlist, error)
+	GetWishlistsByUserID(ctx context.Context, userID int) ([]model.Wishlist, error)
+	GetOrCreateDefaultWishlist(ctx context.Context, userID int) (*model.Wishlist, error)
+	UpdateWishlist(ctx context.Context, wishlist *model.Wishlist) error
+	UpdateWishlistShareToken(ctx context.Context, id int, token *string) error
+	DeleteWishlist(ctx context.Context, id int) error
+	GetFavoritesByWishlistID(ctx context.Context, wishlistID int) ([]model.Favorite, error)
+	UpdateFavoriteNote(ctx context.Context, favorite *model.Favorite) error
+	DeleteFavoriteFromWishlist(ctx context.Context, wishlistID int, listingID int) error
+	GetListingCards(ctx context.Context, listingIDs []int) ([]model.ListingCard, error)
+	CreateListingNotifications(ctx context.Context, notification model.Notification) ([]model.Notification, error)
+	GetNotificationsByUserID(ctx context.Context, userID int, unreadOnly bool, limit, offset int) ([]model.Notification, error)
+	MarkNotificationRead(ctx context.Context, id int) error
+	MarkNotificationDelivered(ctx context.Context, id int) error
+	MarkNotificationDeliveryFailed(ctx context.Context, id int, deliveryError string, retryAfter time.Duration) error
+	ClaimUndeliveredNotifications(ctx context.Context, maxAttempts int, lease time.Duration, limit int) ([]model.Notification, error)
+	CreateNotification(ctx context.Context, notification *model.Notification) error
+
+	GetOrCreateConversation(ctx context.Context, conversation *model.Conversation) error
+	GetConversationByID(ctx context.Context, id int, viewerID int) (*model.Conversation, error)
+	GetConversationsByUserID(ctx context.Context, userID int, limit, offset int) ([]model.Conversation, error)
+	CreateMessage(ctx context.Context, message *model.Message) error
+	GetMessagesByConversationID(ctx context.Context, conversationID int, limit, offset int) ([]model.Message, error)
+	MarkConversationRead(ctx context.Context, conversationID int, readerID int) (int, error)
 
 	CreatePayment(ctx context.Context, payment *model.Payment) error
 	GetPaymentByID(ctx context.Context, paymentID int) (*model.Payment, error)
@@ -92,7 +174,17 @@ type Repo interface {
 	GetImagesByListingID(ctx context.Context, listingID int) ([]model.Image, error)
 	UpdateImage(ctx context.Context, image *model.Image) error
 	DeleteImage(ctx context.Context, imageID int) error
+	ReorderImages(ctx context.Context, listingID int, imageIDs []int) error
 	CreateImages(ctx context.Context, images []model.Image) error
+	ClaimPendingImage(ctx context.Context) (*model.Image, error)
+	ResetStaleImageProcessing(ctx context.Context, lease time.Duration) error
+	GetOrphanedBlobs(ctx context.Context, retryAfter time.Duration, limit int) ([]string, error)
+	DeleteOrphanedBlob(ctx context.Context, key string) error
+	MarkOrphanedBlobFailed(ctx context.Context, key string, deleteErr string) error
+	UpdateImageProcessingStatus(ctx context.Context, imageID int, status string, processingError *string) error
+	SaveImageVariants(ctx context.Context, variants []model.ImageVariant) error
+	GetImageVariantsByImageID(ctx context.Context, imageID int) ([]model.ImageVariant, error)
+	GetImageVariantsByListingID(ctx context.Context, listingID int) ([]model.ImageVariant, error)
 
 	GetHostTotalRevenue(ctx context.Context, hostID int) (float64, error)
 	GetGuestTotalSpent(ctx context.Context, guestID int) (float64, error)
@@ -100,11 +192,45 @@ type Repo interface {
 	GetListingActiveBookingsCount(ctx context.Context, listingID int) (int, error)
 
 	GetListingsStatisticsReport(ctx context.Context) ([]model.ListingStatisticsReport, error)
+	GetCitiesStatisticsReport(ctx context.Context, country *string) ([]model.CityStatisticsReport, error)
 	GetHostsPerformanceReport(ctx context.Context) ([]model.HostPerformanceReport, error)
+	GetReportRefreshedAt(ctx context.Context, viewName string) (time.Time, error)
+	RefreshReportViews(ctx context.Context) error
+	StreamListingsStatisticsReport(ctx context.Context, fn func(model.ListingStatisticsReport) error) error
+	StreamHostsPerformanceReport(ctx context.Context, fn func(model.HostPerformanceReport) error) error
+	StreamBookingsReport(ctx context.Context, startDate, endDate *time.Time, fn func(model.BookingReport) error) error
+	StreamListingsOccupancyReport(ctx context.Context, startDate, endDate time.Time, fn func(model.ListingOccupancyReport) error) error
+	StreamCitiesStatisticsReport(ctx context.Context, country *string, fn func(model.CityStatisticsReport) error) error
+	StreamPaymentsSummaryReport(ctx context.Context, startDate, endDate *time.Time, fn func(model.PaymentSummaryReport) error) error
+	StreamHostsOccupancyReport(ctx context.Context, startDate, endDate time.Time, fn func(model.HostOccupancyReport) error) error
+	StreamTimeseriesReport(ctx context.Context, filter model.TimeseriesFilter, fn func(model.TimeseriesPoint) error) error
 	GetBookingsReport(ctx context.Context, startDate, endDate *time.Time) ([]model.BookingReport, error)
 	GetPaymentsSummaryReport(ctx context.Context, startDate, endDate *time.Time) ([]model.PaymentSummaryReport, error)
+	GetListingsOccupancyReport(ctx context.Context, startDate, endDate time.Time) ([]model.ListingOccupancyReport, error)
+	GetHostsOccupancyReport(ctx context.Context, startDate, endDate time.Time) ([]model.HostOccupancyReport, error)
+	GetTimeseriesReport(ctx context.Context, filter model.TimeseriesFilter) ([]model.TimeseriesPoint, error)
+	GetListingsCancellationReport(ctx context.Context, startDate, endDate *time.Time) ([]model.ListingCancellationReport, error)
+	GetHostsCancellationReport(ctx context.Context, startDate, endDate *time.Time) ([]model.HostCancellationReport, error)
+	GetPaymentFailuresReport(ctx context.Context, startDate, endDate *time.Time) ([]model.PaymentFailureReport, error)
+	GetGuestCohortReport(ctx context.Context, startDate, endDate *time.Time) ([]model.GuestCohortCell, error)
+
+	GetHostDashboardSummary(ctx context.Context, hostID int, periodStart time.Time) (*model.HostDashboard, error)
+	GetHostDashboardListings(ctx context.Context, hostID int, periodStart, periodEnd time.Time) ([]model.HostDashboardListing, error)
+	GetHostUpcomingMovements(ctx context.Context, hostID int, from, to time.Time) ([]model.HostDashboardMovement, error)
+
+	GetTripsByGuestID(ctx context.Context, guestID int) ([]model.Trip, error)
+	GetListingComparables(ctx context.Context, listingID int, startDate, endDate time.Time, radiusKm *float64) ([]model.ListingComparable, error)
 
 	CreateBookingWithPayment(ctx context.Context, listingID, guestID int, inDate, outDate time.Time, paymentMethod string) (*model.CreateBookingWithPaymentResult, error)
 	ConfirmPayment(ctx context.Context, paymentID int, transactionID *string) error
 	CancelBookingWithRefund(ctx context.Context, bookingID int) error
+
+	GetPaymentDiscrepancies(ctx context.Context) ([]model.PaymentDiscrepancy, error)
+
+	GetListingsAnalytics(ctx context.Context, filter model.AnalyticsFilter) ([]model.ListingSummary, error)
+	CountListingsAnalytics(ctx context.Context, filter model.AnalyticsFilter) (int, error)
+	GetHostsAnalytics(ctx context.Context, filter model.AnalyticsFilter) ([]model.HostAnalytics, error)
+	CountHostsAnalytics(ctx context.Context, filter model.AnalyticsFilter) (int, error)
+	GetBookingsAnalytics(ctx context.Context, filter model.AnalyticsFilter) ([]model.BookingPaymentAnalytics, error)
+	CountBookingsAnalytics(ctx context.Context, filter model.AnalyticsFilter) (int, error)
 }
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Rissochek/db-cw/internal/model"
+)
+
+var (
+	timeseriesMetrics       = []string{model.TimeseriesMetricRevenue, model.TimeseriesMetricRefunds, model.TimeseriesMetricBookings}
+	timeseriesGranularities = []string{model.TimeseriesGranularityDay, model.TimeseriesGranularityWeek, model.TimeseriesGranularityMonth}
+
+	// период по умолчанию и верхняя граница числа интервалов для каждой гранулярности
+	timeseriesDefaultPeriods = map[string]time.Duration{
+		model.TimeseriesGranularityDay:   30 * 24 * time.Hour,
+		model.TimeseriesGranularityWeek:  12 * 7 * 24 * time.Hour,
+		model.TimeseriesGranularityMonth: 365 * 24 * time.Hour,
+	}
+	timeseriesBucketSizes = map[string]time.Duration{
+		model.TimeseriesGranularityDay:   24 * time.Hour,
+		model.TimeseriesGranularityWeek:  7 * 24 * time.Hour,
+		model.TimeseriesGranularityMonth: 28 * 24 * time.Hour,
+	}
+	maxTimeseriesBuckets = 1000
+)
+
+func (s *Service) GetTimeseriesReport(ctx context.Context, filter model.TimeseriesFilter) (*model.TimeseriesReport, error) {
+	if err := validateTimeseriesFilter(&filter); err != nil {
+		return nil, err
+	}
+
+	points, err := s.repo.GetTimeseriesReport(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.TimeseriesReport{
+		Metric:      filter.Metric,
+		Granularity: filter.Granularity,
+		From:        *filter.From,
+		To:          *filter.To,
+		HostID:      filter.HostID,
+		ListingID:   filter.ListingID,
+		Points:      points,
+	}, nil
+}
+
+func (s *Service) StreamTimeseriesReport(ctx context.Context, filter model.TimeseriesFilter, fn func(model.TimeseriesPoint) error) error {
+	if err := validateTimeseriesFilter(&filter); err != nil {
+		return err
+	}
+
+	return s.repo.StreamTimeseriesReport(ctx, filter, fn)
+}
+
+func validateTimeseriesFilter(filter *model.TimeseriesFilter) error {
+	if filter.Metric == "" {
+		filter.Metric = model.TimeseriesMetricRevenue
+	}
+	if !slices.Contains(timeseriesMetrics, filter.Metric) {
+		return fmt.Errorf("invalid timeseries report: metric must be one of %s", strings.Join(timeseriesMetrics, ", "))
+	}
+
+	if filter.Granularity == "" {
+		filter.Granularity = model.TimeseriesGranularityDay
+	}
+	if !slices.Contains(timeseriesGranularities, filter.Granularity) {
+		return fmt.Errorf("invalid timeseries report: granularity must be one of %s", strings.Join(timeseriesGranularities, ", "))
+	}
+
+	if filter.To == nil {
+		to := time.Now()
+		filter.To = &to
+	}
+	if filter.From == nil {
+		from := filter.To.Add(-timeseriesDefaultPeriods[filter.Granularity])
+		filter.From = &from
+	}
+
+	if !filter.From.Before(*filter.To) {
+		return fmt.Errorf("invalid timeseries report: from must be before to")
+	}
+
+	if filter.To.Sub(*filter.From)/timeseriesBucketSizes[filter.Granularity] > time.Duration(maxTimeseriesBuckets) {
+		return fmt.Errorf("invalid timeseries report: more than %d %s buckets requested", maxTimeseriesBuckets, filter.Granularity)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/Rissochek/db-cw/internal/model"
+)
+
+// GetGuestTrips раскладывает брони гостя по статусу из представления и отмечает поездки,
+// на которые еще можно оставить отзыв
+func (s *Service) GetGuestTrips(ctx context.Context, guestID int) (*model.GuestTrips, error) {
+	if _, err := s.repo.GetUserByID(ctx, guestID); err != nil {
+		return nil, err
+	}
+
+	trips, err := s.repo.GetTripsByGuestID(ctx, guestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachTripListingCards(ctx, trips); err != nil {
+		return nil, err
+	}
+
+	totalSpent, err := s.repo.GetGuestTotalSpent(ctx, guestID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.GuestTrips{
+		GuestID:        guestID,
+		TotalSpent:     totalSpent,
+		Upcoming:       []model.Trip{},
+		Current:        []model.Trip{},
+		Past:           []model.Trip{},
+		PendingReviews: []model.Trip{},
+	}
+
+	now := time.Now()
+	for _, trip := range trips {
+		switch trip.Status {
+		case model.TripStatusUpcoming:
+			result.Upcoming = append(result.Upcoming, trip)
+		case model.TripStatusActive:
+			result.Current = append(result.Current, trip)
+		default:
+			if trip.ReviewID == nil && checkReviewWindow(&model.Booking{OutDate: trip.OutDate}, now, s.reviewWindowDays) == nil {
+				deadline := trip.OutDate.AddDate(0, 0, s.reviewWindowDays)
+				trip.ReviewDeadline = &deadline
+				result.PendingReviews = append(result.PendingReviews, trip)
+			}
+			result.Past = append(result.Past, trip)
+		}
+	}
+
+	// прошлые поездки - от последней к первой
+	slices.Reverse(result.Past)
+	slices.Reverse(result.PendingReviews)
+
+	return result, nil
+}
+
+func (s *Service) attachTripListingCards(ctx context.Context, trips []model.Trip) error {
+	listingIDs := make([]int, 0, len(trips))
+	for _, trip := range trips {
+		if !slices.Contains(listingIDs, trip.ListingID) {
+			listingIDs = append(listingIDs, trip.ListingID)
+		}
+	}
+
+	cards, err := s.repo.GetListingCards(ctx, listingIDs)
+	if err != nil {
+		return err
+	}
+
+	cardsByID := make(map[int]*model.ListingCard, len(cards))
+	for i := range cards {
+		cardsByID[cards[i].ListingID] = &cards[i]
+	}
+
+	for i := range trips {
+		trips[i].Listing = cardsByID[trips[i].ListingID]
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/Rissochek/db-cw/internal/model"
+	"go.uber.org/zap"
+)
+
+var maxWishlistNameLength = 100
+
+func (s *Service) CreateWishlist(ctx context.Context, wishlist *model.Wishlist) error {
+	_, err := s.repo.GetUserByID(ctx, wishlist.UserID)
+	if err != nil {
+		return err
+	}
+
+	if err := validateWishlistName(&wishlist.Name); err != nil {
+		return err
+	}
+
+	wishlist.IsDefault = false
+	return s.repo.CreateWishlist(ctx, wishlist)
+}
+
+func (s *Service) GetWishlistsByUserID(ctx context.Context, userID int) ([]model.Wishlist, error) {
+	_, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.GetWishlistsByUserID(ctx, userID)
+}
+
+func (s *Service) GetWishlistByID(ctx context.Context, id int) (*model.Wishlist, error) {
+	wishlist, err := s.repo.GetWishlistByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.loadWishlistItems(ctx, wishlist); err != nil {
+		return nil, err
+	}
+
+	return wishlist, nil
+}
+
+func (s *Service) GetSharedWishlist(ctx context.Context, token string) (*model.Wishlist, error) {
+	wishlist, err := s.repo.GetWishlistByShareToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.loadWishlistItems(ctx, wishlist); err != nil {
+		return nil, err
+	}
+
+	return wishlist, nil
+}
+
+func (s *Service) UpdateWishlist(ctx context.Context, wishlist *model.Wishlist) error {
+	if err := validateWishlistName(&wishlist.Name); err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateWishlist(ctx, wishlist); err != nil {
+		return err
+	}
+
+	updated, err := s.repo.GetWishlistByID(ctx, wishlist.ID)
+	if err != nil {
+		return err
+	}
+
+	*wishlist = *updated
+	return nil
+}
+
+func (s *Service) DeleteWishlist(ctx context.Context, id int) error {
+	wishlist, err := s.repo.GetWishlistByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if wishlist.IsDefault {
+		return fmt.Errorf("cannot delete default wishlist")
+	}
+
+	return s.repo.DeleteWishlist(ctx, id)
+}
+
+func (s *Service) AddWishlistItem(ctx context.Context, favorite *model.Favorite) error {
+	wishlist, err := s.repo.GetWishlistByID(ctx, favorite.WishlistID)
+	if err != nil {
+		return err
+	}
+
+	favorite.UserID = wishlist.UserID
+	return s.CreateFavorite(ctx, favorite)
+}
+
+func (s *Service) UpdateWishlistItem(ctx context.Context, favorite *model.Favorite) error {
+	return s.repo.UpdateFavoriteNote(ctx, favorite)
+}
+
+func (s *Service) RemoveWishlistItem(ctx context.Context, wishlistID int, listingID int) error {
+	return s.repo.DeleteFavoriteFromWishlist(ctx, wishlistID, listingID)
+}
+
+// ShareWishlist выдает токен для просмотра списка по ссылке; повторный вызов возвращает тот же токен
+func (s *Service) ShareWishlist(ctx context.Context, id int) (*model.Wishlist, error) {
+	wishlist, err := s.repo.GetWishlistByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if wishlist.ShareToken != nil {
+		return wishlist, nil
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateWishlistShareToken(ctx, id, &token); err != nil {
+		return nil, err
+	}
+
+	wishlist.ShareToken = &token
+	return wishlist, nil
+}
+
+func (s *Service) UnshareWishlist(ctx context.Context, id int) error {
+	return s.repo.UpdateWishlistShareToken(ctx, id, nil)
+}
+
+func (s *Service) loadWishlistItems(ctx context.Context, wishlist *model.Wishlist) error {
+	items, err := s.repo.GetFavoritesByWishlistID(ctx, wishlist.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.attachListingCards(ctx, items); err != nil {
+		return err
+	}
+
+	wishlist.Items = items
+	return nil
+}
+
+func validateWishlistName(name *string) error {
+	*name = strings.TrimSpace(*name)
+	if *name == "" {
+		return fmt.Errorf("invalid wishlist: name is required")
+	}
+
+	if len([]rune(*name)) > maxWishlistNameLength {
+		return fmt.Errorf("invalid wishlist: name is longer than %d characters", maxWishlistNameLength)
+	}
+
+	return nil
+}
+
+func newShareToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		zap.S().Errorf("failed to generate share token: %v", err)
+		return "", fmt.Errorf("failed to generate share token")
+	}
+
+	return hex.EncodeToString(buf), nil
+}
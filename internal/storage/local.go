@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Rissochek/db-cw/internal/utils"
+	"go.uber.org/zap"
+)
+
+const LocalPublicPath = "/uploads"
+
+type LocalStore struct {
+	dir       string
+	publicURL string
+}
+
+func NewLocalStore(dir string, publicURL string) *LocalStore {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		zap.S().Fatalf("failed to create storage dir %v: %v", dir, err)
+	}
+
+	return &LocalStore{
+		dir:       dir,
+		publicURL: publicURL,
+	}
+}
+
+func NewLocalStoreFromEnv() *LocalStore {
+	dir := utils.GetKeyFromEnvOrDefault("STORAGE_LOCAL_DIR", "./uploads")
+	publicURL := utils.GetKeyFromEnvOrDefault("STORAGE_PUBLIC_URL", LocalPublicPath)
+
+	return NewLocalStore(dir, publicURL)
+}
+
+func (ls *LocalStore) Dir() string {
+	return ls.dir
+}
+
+func (ls *LocalStore) Put(ctx context.Context, key string, contentType string, size int64, body io.Reader) (string, error) {
+	path, err := ls.objectPath(key)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		zap.S().Errorf("failed to create dir for object %v: %v", key, err)
+		return "", fmt.Errorf("failed to store object")
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		zap.S().Errorf("failed to create object %v: %v", key, err)
+		return "", fmt.Errorf("failed to store object")
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, body); err != nil {
+		os.Remove(path)
+		zap.S().Errorf("failed to write object %v: %v", key, err)
+		return "", fmt.Errorf("failed to store object")
+	}
+
+	return objectURL(ls.publicURL, key), nil
+}
+
+func (ls *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := ls.objectPath(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrObjectNotFound
+		}
+		zap.S().Errorf("failed to open object %v: %v", key, err)
+		return nil, fmt.Errorf("failed to get object")
+	}
+
+	return file, nil
+}
+
+func (ls *LocalStore) Delete(ctx context.Context, key string) error {
+	path, err := ls.objectPath(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		zap.S().Errorf("failed to delete object %v: %v", key, err)
+		return fmt.Errorf("failed to delete object")
+	}
+
+	return nil
+}
+
+func (ls *LocalStore) objectPath(key string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(strings.TrimLeft(key, "/")))
+	if cleaned == "." || strings.HasPrefix(cleaned, "..") {
+		return "", fmt.Errorf("invalid object key")
+	}
+
+	return filepath.Join(ls.dir, cleaned), nil
+}
@@ -0,0 +1,203 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Rissochek/db-cw/internal/utils"
+	"go.uber.org/zap"
+)
+
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+type S3Config struct {
+	Endpoint  string
+	Bucket    string
+	Region    string
+	AccessKey string
+	SecretKey string
+	PublicURL string
+}
+
+// S3Store работает с любым S3-совместимым хранилищем (в том числе MinIO)
+// через path-style адреса и подпись запросов AWS Signature V4.
+type S3Store struct {
+	config S3Config
+	client *http.Client
+}
+
+func NewS3Store(config S3Config) *S3Store {
+	return &S3Store{
+		config: config,
+		client: &http.Client{Timeout: time.Minute},
+	}
+}
+
+func NewS3StoreFromEnv() *S3Store {
+	endpoint := utils.GetKeyFromEnv("S3_ENDPOINT")
+	bucket := utils.GetKeyFromEnv("S3_BUCKET")
+
+	return NewS3Store(S3Config{
+		Endpoint:  endpoint,
+		Bucket:    bucket,
+		Region:    utils.GetKeyFromEnvOrDefault("S3_REGION", "us-east-1"),
+		AccessKey: utils.GetKeyFromEnv("S3_ACCESS_KEY"),
+		SecretKey: utils.GetKeyFromEnv("S3_SECRET_KEY"),
+		PublicURL: utils.GetKeyFromEnvOrDefault("S3_PUBLIC_URL", strings.TrimRight(endpoint, "/")+"/"+bucket),
+	})
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, contentType string, size int64, body io.Reader) (string, error) {
+	req, err := s.newRequest(ctx, http.MethodPut, key, body)
+	if err != nil {
+		return "", fmt.Errorf("failed to store object")
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+	s.sign(req, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		zap.S().Errorf("failed to put object %v: %v", key, err)
+		return "", fmt.Errorf("failed to store object")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		zap.S().Errorf("failed to put object %v: %v", key, readS3Error(resp))
+		return "", fmt.Errorf("failed to store object")
+	}
+
+	return objectURL(s.config.PublicURL, key), nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	req, err := s.newRequest(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get object")
+	}
+	s.sign(req, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		zap.S().Errorf("failed to get object %v: %v", key, err)
+		return nil, fmt.Errorf("failed to get object")
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrObjectNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		zap.S().Errorf("failed to get object %v: %v", key, readS3Error(resp))
+		return nil, fmt.Errorf("failed to get object")
+	}
+
+	return resp.Body, nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete object")
+	}
+	s.sign(req, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		zap.S().Errorf("failed to delete object %v: %v", key, err)
+		return fmt.Errorf("failed to delete object")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		zap.S().Errorf("failed to delete object %v: %v", key, readS3Error(resp))
+		return fmt.Errorf("failed to delete object")
+	}
+
+	return nil
+}
+
+func (s *S3Store) newRequest(ctx context.Context, method string, key string, body io.Reader) (*http.Request, error) {
+	endpoint, err := url.Parse(strings.TrimRight(s.config.Endpoint, "/"))
+	if err != nil {
+		zap.S().Errorf("invalid s3 endpoint %v: %v", s.config.Endpoint, err)
+		return nil, err
+	}
+
+	endpoint.Path = "/" + s.config.Bucket + "/" + strings.TrimLeft(key, "/")
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), body)
+	if err != nil {
+		zap.S().Errorf("failed to build s3 request: %v", err)
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (s *S3Store) sign(req *http.Request, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	shortDate := now.Format("20060102")
+
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + unsignedPayload + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	scope := shortDate + "/" + s.config.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.config.SecretKey), shortDate)
+	signingKey = hmacSHA256(signingKey, s.config.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.config.AccessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func readS3Error(resp *http.Response) string {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Sprintf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,13 @@
+package storage
+
+import (
+	"errors"
+	"path"
+	"strings"
+)
+
+var ErrObjectNotFound = errors.New("object not found")
+
+func objectURL(baseURL string, key string) string {
+	return strings.TrimRight(baseURL, "/") + "/" + path.Clean(strings.TrimLeft(key, "/"))
+}
@@ -19,4 +19,12 @@ func GetKeyFromEnv(key string) string {
 		zap.S().Fatalf("value is not set in .env file: %v", key)
 	}
 	return value
-}
\ No newline at end of file
+}
+
+func GetKeyFromEnvOrDefault(key string, defaultValue string) string {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return defaultValue
+	}
+	return value
+}